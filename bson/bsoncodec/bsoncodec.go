@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
@@ -134,6 +135,9 @@ type EncodeContext struct {
 	nilByteSliceAsEmpty     bool
 	omitZeroStruct          bool
 	useJSONStructTags       bool
+	useJSONMarshalers       bool
+	parseJSONRawMessage     bool
+	durationUnit            time.Duration
 }
 
 // ErrorOnInlineDuplicates causes the Encoder to return an error if there is a duplicate field in
@@ -195,6 +199,43 @@ func (ec *EncodeContext) UseJSONStructTags() {
 	ec.useJSONStructTags = true
 }
 
+// UseJSONMarshalers causes the Encoder to marshal values that implement json.Marshaler, but not
+// Marshaler or ValueMarshaler, by converting the output of their MarshalJSON method to BSON.
+//
+// Deprecated: Use [go.mongodb.org/mongo-driver/bson.Encoder.UseJSONMarshalers] instead.
+func (ec *EncodeContext) UseJSONMarshalers() {
+	ec.useJSONMarshalers = true
+}
+
+// ParseJSONRawMessage causes the Encoder to marshal json.RawMessage values by parsing them as
+// relaxed Extended JSON instead of as BSON binary values.
+//
+// Deprecated: Use [go.mongodb.org/mongo-driver/bson.Encoder.ParseJSONRawMessage] instead.
+func (ec *EncodeContext) ParseJSONRawMessage() {
+	ec.parseJSONRawMessage = true
+}
+
+// DurationUnit causes the Encoder to marshal time.Duration values as the number of whole units
+// of the given duration (e.g. time.Millisecond) instead of nanoseconds.
+//
+// Deprecated: Use [go.mongodb.org/mongo-driver/bson.Encoder.DurationUnit] instead.
+func (ec *EncodeContext) DurationUnit(unit time.Duration) {
+	ec.durationUnit = unit
+}
+
+// LookupEncoder returns the first matching encoder in the EncodeContext's Registry. If the
+// EncodeContext is configured to use json.Marshaler implementations, an encoder that calls
+// MarshalJSON is returned for types that implement json.Marshaler and would otherwise be encoded
+// by a kind encoder. See Registry.LookupEncoder for the lookup order.
+func (ec EncodeContext) LookupEncoder(valueType reflect.Type) (ValueEncoder, error) {
+	if ec.useJSONMarshalers {
+		if enc, ok := ec.Registry.lookupJSONMarshalerEncoder(valueType); ok {
+			return enc, nil
+		}
+	}
+	return ec.Registry.LookupEncoder(valueType)
+}
+
 // DecodeContext is the contextual information required for a Codec to decode a
 // value.
 type DecodeContext struct {
@@ -222,11 +263,15 @@ type DecodeContext struct {
 	// error. DocumentType overrides the Ancestor field.
 	defaultDocumentType reflect.Type
 
-	binaryAsSlice     bool
-	useJSONStructTags bool
-	useLocalTimeZone  bool
-	zeroMaps          bool
-	zeroStructs       bool
+	binaryAsSlice        bool
+	errorOnUnknownFields bool
+	useJSONStructTags    bool
+	useLocalTimeZone     bool
+	zeroMaps             bool
+	zeroStructs          bool
+	durationUnit         time.Duration
+
+	canonicalJSONRawMessage bool
 }
 
 // BinaryAsSlice causes the Decoder to unmarshal BSON binary field values that are the "Generic" or
@@ -237,6 +282,15 @@ func (dc *DecodeContext) BinaryAsSlice() {
 	dc.binaryAsSlice = true
 }
 
+// ErrorOnUnknownFields causes the Decoder to return an error when decoding a BSON document into a
+// Go struct if the document contains a field that does not map to a struct field. Fields collected
+// by an "inline" map are not considered unknown.
+//
+// Deprecated: Use [go.mongodb.org/mongo-driver/bson.Decoder.ErrorOnUnknownFields] instead.
+func (dc *DecodeContext) ErrorOnUnknownFields() {
+	dc.errorOnUnknownFields = true
+}
+
 // UseJSONStructTags causes the Decoder to fall back to using the "json" struct tag if a "bson"
 // struct tag is not specified.
 //
@@ -269,6 +323,22 @@ func (dc *DecodeContext) ZeroStructs() {
 	dc.zeroStructs = true
 }
 
+// DurationUnit causes the Decoder to unmarshal BSON integer values into time.Duration values as a
+// number of the given unit (e.g. time.Millisecond) instead of nanoseconds.
+//
+// Deprecated: Use [go.mongodb.org/mongo-driver/bson.Decoder.DurationUnit] instead.
+func (dc *DecodeContext) DurationUnit(unit time.Duration) {
+	dc.durationUnit = unit
+}
+
+// CanonicalJSONRawMessage causes the Decoder to unmarshal BSON values into json.RawMessage values
+// as canonical Extended JSON instead of relaxed Extended JSON.
+//
+// Deprecated: Use [go.mongodb.org/mongo-driver/bson.Decoder.CanonicalJSONRawMessage] instead.
+func (dc *DecodeContext) CanonicalJSONRawMessage() {
+	dc.canonicalJSONRawMessage = true
+}
+
 // DefaultDocumentM causes the Decoder to always unmarshal documents into the primitive.M type. This
 // behavior is restricted to data typed as "interface{}" or "map[string]interface{}".
 //
@@ -7,6 +7,7 @@
 package bsoncodec
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -90,6 +91,7 @@ func (dvd DefaultValueDecoders) RegisterDefaultDecoders(rb *RegistryBuilder) {
 		RegisterTypeDecoder(tOID, decodeAdapter{dvd.ObjectIDDecodeValue, dvd.objectIDDecodeType}).
 		RegisterTypeDecoder(tDecimal, decodeAdapter{dvd.Decimal128DecodeValue, dvd.decimal128DecodeType}).
 		RegisterTypeDecoder(tJSONNumber, decodeAdapter{dvd.JSONNumberDecodeValue, dvd.jsonNumberDecodeType}).
+		RegisterTypeDecoder(tJSONRawMessage, decodeAdapter{jsonRawMessageDecodeValue, jsonRawMessageDecodeType}).
 		RegisterTypeDecoder(tURL, decodeAdapter{dvd.URLDecodeValue, dvd.urlDecodeType}).
 		RegisterTypeDecoder(tCoreDocument, ValueDecoderFunc(dvd.CoreDocumentDecodeValue)).
 		RegisterTypeDecoder(tCodeWithScope, decodeAdapter{dvd.CodeWithScopeDecodeValue, dvd.codeWithScopeDecodeType}).
@@ -328,6 +330,13 @@ func (DefaultValueDecoders) intDecodeType(dc DecodeContext, vr bsonrw.ValueReade
 
 		return reflect.ValueOf(int32(i64)), nil
 	case reflect.Int64:
+		if t == tDuration && dc.durationUnit > 1 {
+			unit := int64(dc.durationUnit)
+			if i64 > math.MaxInt64/unit || i64 < math.MinInt64/unit {
+				return emptyValue, fmt.Errorf("%d units of %v overflows time.Duration", i64, dc.durationUnit)
+			}
+			i64 *= unit
+		}
 		return reflect.ValueOf(i64), nil
 	case reflect.Int:
 		if int64(int(i64)) != i64 { // Can we fit this inside of an int
@@ -1238,6 +1247,78 @@ func (dvd DefaultValueDecoders) JSONNumberDecodeValue(dc DecodeContext, vr bsonr
 	return nil
 }
 
+// jsonRawMessageDecodeType converts a BSON value to a json.RawMessage containing relaxed Extended
+// JSON, or canonical Extended JSON if the DecodeContext is configured to use it. BSON null and
+// undefined are decoded as a nil json.RawMessage. BSON binary values of the generic subtype that
+// contain valid JSON, which is how json.RawMessage values are encoded unless the EncodeContext is
+// configured to parse them, are decoded as that JSON.
+func jsonRawMessageDecodeType(dc DecodeContext, vr bsonrw.ValueReader, t reflect.Type) (reflect.Value, error) {
+	if t != tJSONRawMessage {
+		return emptyValue, ValueDecoderError{
+			Name:     "jsonRawMessageDecodeValue",
+			Types:    []reflect.Type{tJSONRawMessage},
+			Received: reflect.Zero(t),
+		}
+	}
+
+	switch vr.Type() {
+	case bsontype.Null:
+		return reflect.Zero(tJSONRawMessage), vr.ReadNull()
+	case bsontype.Undefined:
+		return reflect.Zero(tJSONRawMessage), vr.ReadUndefined()
+	}
+
+	typ, data, err := bsonrw.Copier{}.CopyValueToBytes(vr)
+	if err != nil {
+		return emptyValue, err
+	}
+	if typ == bsontype.Binary {
+		subtype, bin, _, ok := bsoncore.ReadBinary(data)
+		if ok && subtype == bsontype.BinaryGeneric && json.Valid(bin) {
+			return reflect.ValueOf(json.RawMessage(append([]byte(nil), bin...))), nil
+		}
+	}
+
+	// The Extended JSON value writer only writes documents at the top level, so write the value as
+	// the only field of a document and extract the field's JSON.
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	doc = bsoncore.AppendValueElement(doc, "v", bsoncore.Value{Type: typ, Data: data})
+	if doc, err = bsoncore.AppendDocumentEnd(doc, idx); err != nil {
+		return emptyValue, err
+	}
+	buf := new(bytes.Buffer)
+	vw, err := bsonrw.NewExtJSONValueWriter(buf, dc.canonicalJSONRawMessage, false)
+	if err != nil {
+		return emptyValue, err
+	}
+	if err := (bsonrw.Copier{}).CopyDocumentFromBytes(vw, doc); err != nil {
+		return emptyValue, err
+	}
+	var wrapper struct {
+		V json.RawMessage `json:"v"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &wrapper); err != nil {
+		return emptyValue, err
+	}
+
+	return reflect.ValueOf(wrapper.V), nil
+}
+
+// jsonRawMessageDecodeValue is the ValueDecoderFunc for json.RawMessage.
+func jsonRawMessageDecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val reflect.Value) error {
+	if !val.CanSet() || val.Type() != tJSONRawMessage {
+		return ValueDecoderError{Name: "jsonRawMessageDecodeValue", Types: []reflect.Type{tJSONRawMessage}, Received: val}
+	}
+
+	elem, err := jsonRawMessageDecodeType(dc, vr, tJSONRawMessage)
+	if err != nil {
+		return err
+	}
+
+	val.Set(elem)
+	return nil
+}
+
 func (dvd DefaultValueDecoders) urlDecodeType(_ DecodeContext, vr bsonrw.ValueReader, t reflect.Type) (reflect.Value, error) {
 	if t != tURL {
 		return emptyValue, ValueDecoderError{
@@ -7,6 +7,7 @@
 package bsoncodec
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -80,6 +81,7 @@ func (dve DefaultValueEncoders) RegisterDefaultEncoders(rb *RegistryBuilder) {
 		RegisterTypeEncoder(tOID, ValueEncoderFunc(dve.ObjectIDEncodeValue)).
 		RegisterTypeEncoder(tDecimal, ValueEncoderFunc(dve.Decimal128EncodeValue)).
 		RegisterTypeEncoder(tJSONNumber, ValueEncoderFunc(dve.JSONNumberEncodeValue)).
+		RegisterTypeEncoder(tJSONRawMessage, ValueEncoderFunc(jsonRawMessageEncodeValue)).
 		RegisterTypeEncoder(tURL, ValueEncoderFunc(dve.URLEncodeValue)).
 		RegisterTypeEncoder(tJavaScript, ValueEncoderFunc(dve.JavaScriptEncodeValue)).
 		RegisterTypeEncoder(tSymbol, ValueEncoderFunc(dve.SymbolEncodeValue)).
@@ -149,6 +151,9 @@ func (dve DefaultValueEncoders) IntEncodeValue(ec EncodeContext, vw bsonrw.Value
 		return vw.WriteInt64(i64)
 	case reflect.Int64:
 		i64 := val.Int()
+		if val.Type() == tDuration && ec.durationUnit > 0 {
+			i64 /= int64(ec.durationUnit)
+		}
 		if ec.MinSize && fitsIn32Bits(i64) {
 			return vw.WriteInt32(int32(i64))
 		}
@@ -606,6 +611,57 @@ func (dve DefaultValueEncoders) MarshalerEncodeValue(_ EncodeContext, vw bsonrw.
 	return bsonrw.Copier{}.CopyValueFromBytes(vw, bsontype.EmbeddedDocument, data)
 }
 
+// jsonMarshalerEncodeValue is the ValueEncoderFunc used for json.Marshaler implementations when
+// the EncodeContext is configured to use them. The JSON returned by MarshalJSON is parsed as
+// relaxed Extended JSON and copied to vw.
+func jsonMarshalerEncodeValue(_ EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || !val.Type().Implements(tJSONMarshaler) {
+		return ValueEncoderError{Name: "jsonMarshalerEncodeValue", Types: []reflect.Type{tJSONMarshaler}, Received: val}
+	}
+	if isImplementationNil(val, tJSONMarshaler) {
+		return vw.WriteNull()
+	}
+
+	data, err := val.Interface().(json.Marshaler).MarshalJSON()
+	if err != nil {
+		return err
+	}
+	vr, err := bsonrw.NewExtJSONValueReader(bytes.NewReader(data), false)
+	if err != nil {
+		return err
+	}
+	return bsonrw.Copier{}.CopyValue(vw, vr)
+}
+
+// jsonRawMessageEncodeValue is the ValueEncoderFunc for json.RawMessage. If the EncodeContext is
+// configured to parse json.RawMessage values or to use json.Marshaler implementations, the JSON is
+// parsed as relaxed Extended JSON and copied to vw, so {"$oid": "..."} is encoded as a BSON
+// ObjectID, and a nil or empty json.RawMessage is encoded as BSON null. Otherwise, the value is
+// encoded like any other byte slice, as BSON binary.
+func jsonRawMessageEncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val reflect.Value) error {
+	if !val.IsValid() || val.Type() != tJSONRawMessage {
+		return ValueEncoderError{Name: "jsonRawMessageEncodeValue", Types: []reflect.Type{tJSONRawMessage}, Received: val}
+	}
+
+	if !ec.parseJSONRawMessage && !ec.useJSONMarshalers {
+		enc, ok := ec.Registry.kindEncoders[reflect.Slice]
+		if !ok {
+			return ErrNoEncoder{Type: tJSONRawMessage}
+		}
+		return enc.EncodeValue(ec, vw, val)
+	}
+
+	data := val.Bytes()
+	if len(bytes.TrimSpace(data)) == 0 {
+		return vw.WriteNull()
+	}
+	vr, err := bsonrw.NewExtJSONValueReader(bytes.NewReader(data), false)
+	if err != nil {
+		return err
+	}
+	return bsonrw.Copier{}.CopyValue(vw, vr)
+}
+
 // ProxyEncodeValue is the ValueEncoderFunc for Proxy implementations.
 //
 // Deprecated: Use [go.mongodb.org/mongo-driver/bson.NewRegistry] to get a registry with all default
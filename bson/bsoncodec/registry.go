@@ -223,6 +223,11 @@ func (rb *RegistryBuilder) Build() *Registry {
 		registry.typeEncoders[t] = enc
 	}
 
+	registry.registeredTypeEncoders = make(map[reflect.Type]struct{}, len(rb.registry.registeredTypeEncoders))
+	for t := range rb.registry.registeredTypeEncoders {
+		registry.registeredTypeEncoders[t] = struct{}{}
+	}
+
 	registry.typeDecoders = make(map[reflect.Type]ValueDecoder, len(rb.registry.typeDecoders))
 	for t, dec := range rb.registry.typeDecoders {
 		registry.typeDecoders[t] = dec
@@ -258,6 +263,10 @@ type Registry struct {
 	typeEncoders map[reflect.Type]ValueEncoder
 	typeDecoders map[reflect.Type]ValueDecoder
 
+	// registeredTypeEncoders records the types passed to RegisterTypeEncoder. It's necessary
+	// because typeEncoders also caches the results of LookupEncoder.
+	registeredTypeEncoders map[reflect.Type]struct{}
+
 	interfaceEncoders []interfaceValueEncoder
 	interfaceDecoders []interfaceValueDecoder
 
@@ -272,8 +281,9 @@ type Registry struct {
 // NewRegistry creates a new empty Registry.
 func NewRegistry() *Registry {
 	return &Registry{
-		typeEncoders: make(map[reflect.Type]ValueEncoder),
-		typeDecoders: make(map[reflect.Type]ValueDecoder),
+		typeEncoders:           make(map[reflect.Type]ValueEncoder),
+		registeredTypeEncoders: make(map[reflect.Type]struct{}),
+		typeDecoders:           make(map[reflect.Type]ValueDecoder),
 
 		interfaceEncoders: make([]interfaceValueEncoder, 0),
 		interfaceDecoders: make([]interfaceValueDecoder, 0),
@@ -297,6 +307,7 @@ func NewRegistry() *Registry {
 // RegisterTypeEncoder should not be called concurrently with any other Registry method.
 func (r *Registry) RegisterTypeEncoder(valueType reflect.Type, enc ValueEncoder) {
 	r.typeEncoders[valueType] = enc
+	r.registeredTypeEncoders[valueType] = struct{}{}
 }
 
 // RegisterTypeDecoder registers the provided ValueDecoder for the provided type.
@@ -457,6 +468,26 @@ func (r *Registry) LookupEncoder(valueType reflect.Type) (ValueEncoder, error) {
 	return enc, nil
 }
 
+// lookupJSONMarshalerEncoder returns an encoder that calls MarshalJSON if valueType implements
+// json.Marshaler and would otherwise be encoded by a kind encoder. Encoders registered for the exact
+// type and interface encoders (including the ones for Marshaler and ValueMarshaler) take precedence.
+func (r *Registry) lookupJSONMarshalerEncoder(valueType reflect.Type) (ValueEncoder, bool) {
+	if valueType == nil || !valueType.Implements(tJSONMarshaler) {
+		return nil, false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if _, ok := r.registeredTypeEncoders[valueType]; ok {
+		return nil, false
+	}
+	if _, ok := r.lookupInterfaceEncoder(valueType, true); ok {
+		return nil, false
+	}
+	return ValueEncoderFunc(jsonMarshalerEncodeValue), true
+}
+
 func (r *Registry) lookupTypeEncoder(valueType reflect.Type) (ValueEncoder, bool) {
 	enc, found := r.typeEncoders[valueType]
 	return enc, found
@@ -20,6 +20,10 @@ import (
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 )
 
+// ErrUnknownField is returned, wrapped in a DecodeError, when decoding a BSON document into a Go struct with the
+// ErrorOnUnknownFields decoder option set and the document contains a field that does not map to a struct field.
+var ErrUnknownField = errors.New("unknown field")
+
 // DecodeError represents an error that occurs when unmarshalling BSON bytes into a native Go type.
 type DecodeError struct {
 	keys    []string
@@ -165,6 +169,13 @@ func (sc *StructCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val
 		}
 
 		desc.encoder, rv, err = defaultValueEncoders.lookupElementEncoder(ec, desc.encoder, rv)
+		if err == nil && ec.useJSONMarshalers {
+			// Field encoders are cached without regard to the EncodeContext, so check whether the
+			// field should be encoded using its MarshalJSON method instead.
+			if enc, ok := ec.Registry.lookupJSONMarshalerEncoder(rv.Type()); ok {
+				desc.encoder = enc
+			}
+		}
 
 		if err != nil && err != errInvalidValue {
 			return err
@@ -211,6 +222,13 @@ func (sc *StructCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val
 			return err
 		}
 
+		if desc.null && isNullZero(rv) {
+			if err = vw2.WriteNull(); err != nil {
+				return err
+			}
+			continue
+		}
+
 		ectx := EncodeContext{
 			Registry:                ec.Registry,
 			MinSize:                 desc.minSize || ec.MinSize,
@@ -221,6 +239,9 @@ func (sc *StructCodec) EncodeValue(ec EncodeContext, vw bsonrw.ValueWriter, val
 			nilByteSliceAsEmpty:     ec.nilByteSliceAsEmpty,
 			omitZeroStruct:          ec.omitZeroStruct,
 			useJSONStructTags:       ec.useJSONStructTags,
+			useJSONMarshalers:       ec.useJSONMarshalers,
+			parseJSONRawMessage:     ec.parseJSONRawMessage,
+			durationUnit:            ec.durationUnit,
 		}
 		err = encoder.EncodeValue(ectx, vw2, rv)
 		if err != nil {
@@ -328,6 +349,10 @@ func (sc *StructCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val
 
 		if !exists {
 			if sd.inlineMap < 0 {
+				if dc.errorOnUnknownFields {
+					return newDecodeError(name, fmt.Errorf("%w for %v", ErrUnknownField, val.Type()))
+				}
+
 				// The encoding/json package requires a flag to return on error for non-existent fields.
 				// This functionality seems appropriate for the struct codec.
 				err = vr.Skip()
@@ -371,14 +396,18 @@ func (sc *StructCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val
 		field = field.Addr()
 
 		dctx := DecodeContext{
-			Registry:            dc.Registry,
-			Truncate:            fd.truncate || dc.Truncate,
-			defaultDocumentType: dc.defaultDocumentType,
-			binaryAsSlice:       dc.binaryAsSlice,
-			useJSONStructTags:   dc.useJSONStructTags,
-			useLocalTimeZone:    dc.useLocalTimeZone,
-			zeroMaps:            dc.zeroMaps,
-			zeroStructs:         dc.zeroStructs,
+			Registry:             dc.Registry,
+			Truncate:             fd.truncate || dc.Truncate,
+			defaultDocumentType:  dc.defaultDocumentType,
+			binaryAsSlice:        dc.binaryAsSlice,
+			errorOnUnknownFields: dc.errorOnUnknownFields,
+			useJSONStructTags:    dc.useJSONStructTags,
+			useLocalTimeZone:     dc.useLocalTimeZone,
+			zeroMaps:             dc.zeroMaps,
+			zeroStructs:          dc.zeroStructs,
+			durationUnit:         dc.durationUnit,
+
+			canonicalJSONRawMessage: dc.canonicalJSONRawMessage,
 		}
 
 		if fd.decoder == nil {
@@ -394,6 +423,16 @@ func (sc *StructCodec) DecodeValue(dc DecodeContext, vr bsonrw.ValueReader, val
 	return nil
 }
 
+// isNullZero returns true if a field with the null struct tag option should be marshalled as a BSON null. Unlike
+// isZero, it uses the zero value of the type regardless of encoder options, so empty but non-nil slices and maps and
+// non-zero structs are not considered zero. Types that implement Zeroer are zero if their IsZero method returns true.
+func isNullZero(v reflect.Value) bool {
+	if z, ok := v.Interface().(Zeroer); ok && (v.Kind() != reflect.Ptr || !v.IsNil()) {
+		return z.IsZero()
+	}
+	return v.IsZero()
+}
+
 func isZero(i interface{}, omitZeroStruct bool) bool {
 	v := reflect.ValueOf(i)
 
@@ -458,6 +497,7 @@ type fieldDescription struct {
 	fieldName string // struct field name
 	idx       int
 	omitEmpty bool
+	null      bool
 	minSize   bool
 	truncate  bool
 	inline    []int
@@ -557,6 +597,7 @@ func (sc *StructCodec) describeStruct(
 		}
 		description.name = stags.Name
 		description.omitEmpty = stags.OmitEmpty
+		description.null = stags.Null
 		description.minSize = stags.MinSize
 		description.truncate = stags.Truncate
 
@@ -7,6 +7,7 @@
 package bsoncodec
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
 )
@@ -54,6 +55,9 @@ func (stpf StructTagParserFunc) ParseStructTags(sf reflect.StructField) (StructT
 //	Skip       This struct field should be skipped. This is usually denoted by parsing a "-"
 //	           for the name.
 //
+//	Null       Marshal the field as a BSON null if it's set to the zero value for the type. This
+//	           cannot be combined with OmitEmpty.
+//
 // Deprecated: Defining custom BSON struct tag parsers will not be supported in Go Driver 2.0.
 type StructTags struct {
 	Name      string
@@ -62,6 +66,7 @@ type StructTags struct {
 	Truncate  bool
 	Inline    bool
 	Skip      bool
+	Null      bool
 }
 
 // DefaultStructTagParser is the StructTagParser used by the StructCodec by default.
@@ -120,9 +125,15 @@ func parseTags(key string, tag string) (StructTags, error) {
 			st.Truncate = true
 		case "inline":
 			st.Inline = true
+		case "null":
+			st.Null = true
 		}
 	}
 
+	if st.OmitEmpty && st.Null {
+		return st, fmt.Errorf("struct tag for field %q cannot contain both omitempty and null", key)
+	}
+
 	st.Name = key
 
 	return st, nil
@@ -122,6 +122,12 @@ func TestStructTagParsers(t *testing.T) {
 			StructTags{Name: "foo", OmitEmpty: true, MinSize: true, Truncate: true, Inline: true},
 			JSONFallbackStructTagParser,
 		},
+		{
+			"default null",
+			reflect.StructField{Name: "foo", Tag: reflect.StructTag(`bson:"bar,null,minsize"`)},
+			StructTags{Name: "bar", Null: true, MinSize: true},
+			DefaultStructTagParser,
+		},
 		{
 			"JSONFallback json tag all options",
 			reflect.StructField{Name: "foo", Tag: reflect.StructTag(`json:"bar,omitempty,minsize,truncate,inline"`)},
@@ -157,4 +163,12 @@ func TestStructTagParsers(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("omitempty and null", func(t *testing.T) {
+		sf := reflect.StructField{Name: "foo", Tag: reflect.StructTag(`bson:",omitempty,null"`)}
+		_, err := DefaultStructTagParser(sf)
+		if err == nil {
+			t.Fatal("expected an error for a struct tag with both omitempty and null, got nil")
+		}
+	})
 }
@@ -22,16 +22,19 @@ var tInt32 = reflect.TypeOf(int32(0))
 var tInt64 = reflect.TypeOf(int64(0))
 var tString = reflect.TypeOf("")
 var tTime = reflect.TypeOf(time.Time{})
+var tDuration = reflect.TypeOf(time.Duration(0))
 
 var tEmpty = reflect.TypeOf((*interface{})(nil)).Elem()
 var tByteSlice = reflect.TypeOf([]byte(nil))
 var tByte = reflect.TypeOf(byte(0x00))
 var tURL = reflect.TypeOf(url.URL{})
 var tJSONNumber = reflect.TypeOf(json.Number(""))
+var tJSONRawMessage = reflect.TypeOf(json.RawMessage(nil))
 
 var tValueMarshaler = reflect.TypeOf((*ValueMarshaler)(nil)).Elem()
 var tValueUnmarshaler = reflect.TypeOf((*ValueUnmarshaler)(nil)).Elem()
 var tMarshaler = reflect.TypeOf((*Marshaler)(nil)).Elem()
+var tJSONMarshaler = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
 var tUnmarshaler = reflect.TypeOf((*Unmarshaler)(nil)).Elem()
 var tProxy = reflect.TypeOf((*Proxy)(nil)).Elem()
 
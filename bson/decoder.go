@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
@@ -39,11 +40,15 @@ type Decoder struct {
 	defaultDocumentM bool
 	defaultDocumentD bool
 
-	binaryAsSlice     bool
-	useJSONStructTags bool
-	useLocalTimeZone  bool
-	zeroMaps          bool
-	zeroStructs       bool
+	binaryAsSlice        bool
+	errorOnUnknownFields bool
+	useJSONStructTags    bool
+	useLocalTimeZone     bool
+	zeroMaps             bool
+	zeroStructs          bool
+	durationUnit         time.Duration
+
+	canonicalJSONRawMessage bool
 }
 
 // NewDecoder returns a new decoder that uses the DefaultRegistry to read from vr.
@@ -118,6 +123,9 @@ func (d *Decoder) Decode(val interface{}) error {
 	if d.binaryAsSlice {
 		d.dc.BinaryAsSlice()
 	}
+	if d.errorOnUnknownFields {
+		d.dc.ErrorOnUnknownFields()
+	}
 	if d.useJSONStructTags {
 		d.dc.UseJSONStructTags()
 	}
@@ -130,6 +138,12 @@ func (d *Decoder) Decode(val interface{}) error {
 	if d.zeroStructs {
 		d.dc.ZeroStructs()
 	}
+	if d.durationUnit > 0 {
+		d.dc.DurationUnit(d.durationUnit)
+	}
+	if d.canonicalJSONRawMessage {
+		d.dc.CanonicalJSONRawMessage()
+	}
 
 	return decoder.DecodeValue(d.dc, d.vr, rval)
 }
@@ -183,6 +197,14 @@ func (d *Decoder) BinaryAsSlice() {
 	d.binaryAsSlice = true
 }
 
+// ErrorOnUnknownFields causes the Decoder to return an error when decoding a BSON document into a Go
+// struct if the document contains a field that does not map to a struct field. Fields collected by
+// an "inline" map are not considered unknown. The returned error wraps bsoncodec.ErrUnknownField.
+// This is similar to encoding/json's Decoder.DisallowUnknownFields.
+func (d *Decoder) ErrorOnUnknownFields() {
+	d.errorOnUnknownFields = true
+}
+
 // UseJSONStructTags causes the Decoder to fall back to using the "json" struct tag if a "bson"
 // struct tag is not specified.
 func (d *Decoder) UseJSONStructTags() {
@@ -206,3 +228,17 @@ func (d *Decoder) ZeroMaps() {
 func (d *Decoder) ZeroStructs() {
 	d.zeroStructs = true
 }
+
+// DurationUnit causes the Decoder to unmarshal BSON integer values into time.Duration values as a
+// number of the given unit instead of nanoseconds. For example, with time.Millisecond, the BSON
+// int64 1500 is unmarshaled as 1500*time.Millisecond. An error is returned if the result overflows
+// time.Duration. If unit is not positive, BSON integers are unmarshaled as nanoseconds.
+func (d *Decoder) DurationUnit(unit time.Duration) {
+	d.durationUnit = unit
+}
+
+// CanonicalJSONRawMessage causes the Decoder to unmarshal BSON values into json.RawMessage values
+// as canonical Extended JSON (e.g. {"$numberInt":"1"}) instead of relaxed Extended JSON (e.g. 1).
+func (d *Decoder) CanonicalJSONRawMessage() {
+	d.canonicalJSONRawMessage = true
+}
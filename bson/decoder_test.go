@@ -8,6 +8,7 @@ package bson
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"reflect"
 	"testing"
@@ -18,6 +19,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
 	"go.mongodb.org/mongo-driver/bson/bsonrw/bsonrwtest"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/require"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
@@ -328,6 +330,15 @@ func TestDecoderConfiguration(t *testing.T) {
 		MyInt    int
 	}
 
+	type durationUnitTest struct {
+		MyDuration time.Duration
+		MyInt64    int64
+	}
+
+	type jsonRawMessageTest struct {
+		MyJSON json.RawMessage
+	}
+
 	testCases := []struct {
 		description string
 		configure   func(*Decoder)
@@ -473,6 +484,35 @@ func TestDecoderConfiguration(t *testing.T) {
 			},
 			want: &zeroStructsTest{MyString: "test value"},
 		},
+		// Test that DurationUnit causes the Decoder to decode BSON integers into time.Duration
+		// values as a number of the given unit. Other int64 values are not affected.
+		{
+			description: "DurationUnit",
+			configure: func(dec *Decoder) {
+				dec.DurationUnit(time.Second)
+			},
+			input: bsoncore.NewDocumentBuilder().
+				AppendInt32("myDuration", 90).
+				AppendInt64("myInt64", 90).
+				Build(),
+			decodeInto: func() interface{} { return &durationUnitTest{} },
+			want:       &durationUnitTest{MyDuration: 90 * time.Second, MyInt64: 90},
+		},
+		// Test that CanonicalJSONRawMessage causes the Decoder to decode BSON values into
+		// json.RawMessage values as canonical Extended JSON.
+		{
+			description: "CanonicalJSONRawMessage",
+			configure: func(dec *Decoder) {
+				dec.CanonicalJSONRawMessage()
+			},
+			input: bsoncore.NewDocumentBuilder().
+				AppendDocument("myJSON", bsoncore.NewDocumentBuilder().
+					AppendInt32("x", 1).
+					Build()).
+				Build(),
+			decodeInto: func() interface{} { return &jsonRawMessageTest{} },
+			want:       &jsonRawMessageTest{MyJSON: json.RawMessage(`{"x":{"$numberInt":"1"}}`)},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -544,4 +584,278 @@ func TestDecoderConfiguration(t *testing.T) {
 		}
 		assert.Equal(t, want, got, "expected and actual decode results do not match")
 	})
+	t.Run("ErrorOnUnknownFields", func(t *testing.T) {
+		t.Parallel()
+
+		type inner struct {
+			A string
+		}
+		type strict struct {
+			A string
+			B inner
+		}
+		type withInlineMap struct {
+			A     string
+			Extra map[string]interface{} `bson:",inline"`
+		}
+
+		matching := bsoncore.NewDocumentBuilder().
+			AppendString("a", "x").
+			AppendDocument("b", bsoncore.NewDocumentBuilder().AppendString("a", "y").Build()).
+			Build()
+		extraTopLevel := bsoncore.NewDocumentBuilder().
+			AppendString("a", "x").
+			AppendString("c", "unknown").
+			Build()
+		extraNested := bsoncore.NewDocumentBuilder().
+			AppendString("a", "x").
+			AppendDocument("b", bsoncore.NewDocumentBuilder().AppendString("c", "unknown").Build()).
+			Build()
+
+		testCases := []struct {
+			description string
+			input       []byte
+			strict      bool
+			decodeInto  interface{}
+			wantKeys    []string // Keys of the expected DecodeError, or nil if no error is expected.
+		}{
+			{"matching document, default", matching, false, &strict{}, nil},
+			{"matching document, ErrorOnUnknownFields", matching, true, &strict{}, nil},
+			{"extra field, default", extraTopLevel, false, &strict{}, nil},
+			{"extra field, ErrorOnUnknownFields", extraTopLevel, true, &strict{}, []string{"c"}},
+			{"extra nested field, default", extraNested, false, &strict{}, nil},
+			{"extra nested field, ErrorOnUnknownFields", extraNested, true, &strict{}, []string{"b", "c"}},
+			{"extra field in inline map, ErrorOnUnknownFields", extraTopLevel, true, &withInlineMap{}, nil},
+			{"extra field into map, ErrorOnUnknownFields", extraTopLevel, true, &M{}, nil},
+		}
+		for _, tc := range testCases {
+			tc := tc // Capture range variable.
+
+			t.Run(tc.description, func(t *testing.T) {
+				t.Parallel()
+
+				dec, err := NewDecoder(bsonrw.NewBSONDocumentReader(tc.input))
+				require.NoError(t, err, "NewDecoder error")
+				if tc.strict {
+					dec.ErrorOnUnknownFields()
+				}
+
+				err = dec.Decode(tc.decodeInto)
+				if tc.wantKeys == nil {
+					assert.NoError(t, err, "Decode error")
+					return
+				}
+
+				assert.True(t, errors.Is(err, bsoncodec.ErrUnknownField),
+					"expected error %v, got %v", bsoncodec.ErrUnknownField, err)
+				var de *bsoncodec.DecodeError
+				require.True(t, errors.As(err, &de), "expected DecodeError, got %v", err)
+				assert.Equal(t, tc.wantKeys, de.Keys(), "expected keys %v, got %v", tc.wantKeys, de.Keys())
+			})
+		}
+	})
+}
+
+func TestDurationUnitRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	type config struct {
+		Timeout  time.Duration
+		Interval time.Duration
+	}
+
+	testCases := []struct {
+		description string
+		unit        time.Duration
+		want        bsoncore.Document
+	}{
+		{
+			description: "nanoseconds",
+			unit:        0,
+			want: bsoncore.NewDocumentBuilder().
+				AppendInt64("timeout", int64(3*time.Second)).
+				AppendInt64("interval", int64(250*time.Millisecond)).
+				Build(),
+		},
+		{
+			description: "milliseconds",
+			unit:        time.Millisecond,
+			want: bsoncore.NewDocumentBuilder().
+				AppendInt64("timeout", 3000).
+				AppendInt64("interval", 250).
+				Build(),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			in := config{Timeout: 3 * time.Second, Interval: 250 * time.Millisecond}
+
+			buf := new(bytes.Buffer)
+			vw, err := bsonrw.NewBSONValueWriter(buf)
+			require.NoError(t, err, "bsonrw.NewBSONValueWriter error")
+			enc, err := NewEncoder(vw)
+			require.NoError(t, err, "NewEncoder error")
+			enc.DurationUnit(tc.unit)
+			err = enc.Encode(in)
+			require.NoError(t, err, "Encode error")
+			assert.Equal(t, []byte(tc.want), buf.Bytes(), "expected and actual encoded BSON do not match")
+
+			dec, err := NewDecoder(bsonrw.NewBSONDocumentReader(buf.Bytes()))
+			require.NoError(t, err, "NewDecoder error")
+			dec.DurationUnit(tc.unit)
+			var out config
+			err = dec.Decode(&out)
+			require.NoError(t, err, "Decode error")
+			assert.Equal(t, in, out, "expected and actual decoded values do not match")
+		})
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		t.Parallel()
+
+		input := bsoncore.NewDocumentBuilder().
+			AppendInt64("timeout", int64(time.Duration(1<<62))).
+			Build()
+
+		dec, err := NewDecoder(bsonrw.NewBSONDocumentReader(input))
+		require.NoError(t, err, "NewDecoder error")
+		dec.DurationUnit(time.Second)
+		var out config
+		err = dec.Decode(&out)
+		assert.NotNil(t, err, "expected Decode error, got nil")
+	})
+}
+
+func TestJSONRawMessageRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	oid, err := primitive.ObjectIDFromHex("5ef7fdd91c19e3222b41b839")
+	require.NoError(t, err, "ObjectIDFromHex error")
+
+	testCases := []struct {
+		description string
+		bson        bsoncore.Value
+		json        json.RawMessage
+	}{
+		{
+			description: "document",
+			bson: bsoncore.Value{
+				Type: bsontype.EmbeddedDocument,
+				Data: bsoncore.NewDocumentBuilder().
+					AppendString("name", "widget").
+					AppendInt32("count", 3).
+					AppendObjectID("ref", oid).
+					Build(),
+			},
+			json: json.RawMessage(`{"name":"widget","count":3,"ref":{"$oid":"5ef7fdd91c19e3222b41b839"}}`),
+		},
+		{
+			description: "array",
+			bson: bsoncore.Value{
+				Type: bsontype.Array,
+				Data: bsoncore.NewArrayBuilder().AppendInt32(1).AppendString("a").Build(),
+			},
+			json: json.RawMessage(`[1,"a"]`),
+		},
+		{
+			description: "string",
+			bson:        bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, "hello")},
+			json:        json.RawMessage(`"hello"`),
+		},
+		{
+			description: "double",
+			bson:        bsoncore.Value{Type: bsontype.Double, Data: bsoncore.AppendDouble(nil, 1.5)},
+			json:        json.RawMessage(`1.5`),
+		},
+	}
+
+	type wrapper struct {
+		Value json.RawMessage
+	}
+	marshalParsed := func(val interface{}) ([]byte, error) {
+		buf := new(bytes.Buffer)
+		vw, err := bsonrw.NewBSONValueWriter(buf)
+		if err != nil {
+			return nil, err
+		}
+		enc, err := NewEncoder(vw)
+		if err != nil {
+			return nil, err
+		}
+		enc.ParseJSONRawMessage()
+		err = enc.Encode(val)
+		return buf.Bytes(), err
+	}
+
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			doc := bsoncore.NewDocumentBuilder().AppendValue("value", tc.bson).Build()
+
+			var got wrapper
+			err := Unmarshal(doc, &got)
+			require.NoError(t, err, "Unmarshal error")
+			assert.Equal(t, string(tc.json), string(got.Value), "expected and actual JSON do not match")
+
+			b, err := marshalParsed(got)
+			require.NoError(t, err, "Encode error")
+			assert.Equal(t, []byte(doc), b, "expected and actual BSON do not match")
+		})
+	}
+
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+
+		doc := bsoncore.NewDocumentBuilder().AppendNull("value").Build()
+
+		got := wrapper{Value: json.RawMessage(`"not null"`)}
+		err := Unmarshal(doc, &got)
+		require.NoError(t, err, "Unmarshal error")
+		assert.Nil(t, got.Value, "expected nil json.RawMessage, got %s", got.Value)
+
+		b, err := marshalParsed(got)
+		require.NoError(t, err, "Encode error")
+		assert.Equal(t, []byte(doc), b, "expected and actual BSON do not match")
+	})
+
+	t.Run("legacy binary", func(t *testing.T) {
+		t.Parallel()
+
+		doc := bsoncore.NewDocumentBuilder().
+			AppendBinary("value", bsontype.BinaryGeneric, []byte(`{"x": 1}`)).
+			Build()
+
+		var got wrapper
+		err := Unmarshal(doc, &got)
+		require.NoError(t, err, "Unmarshal error")
+		assert.Equal(t, `{"x": 1}`, string(got.Value), "expected and actual JSON do not match")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := marshalParsed(wrapper{Value: json.RawMessage(`{"x":`)})
+		assert.NotNil(t, err, "expected Encode error, got nil")
+	})
+
+	t.Run("binary by default", func(t *testing.T) {
+		t.Parallel()
+
+		// Without ParseJSONRawMessage, json.RawMessage values are marshaled as BSON binary, so
+		// invalid JSON does not cause an error.
+		for _, raw := range []json.RawMessage{json.RawMessage(`{"x": 1}`), json.RawMessage(`{"x":`)} {
+			b, err := Marshal(wrapper{Value: raw})
+			require.NoError(t, err, "Marshal error")
+			want := bsoncore.NewDocumentBuilder().AppendBinary("value", bsontype.BinaryGeneric, raw).Build()
+			assert.Equal(t, []byte(want), b, "expected and actual BSON do not match")
+		}
+	})
 }
@@ -136,6 +136,14 @@
 //     This tag can be used with fields that are pointers to structs. If an inlined pointer field is nil, it will not be
 //     marshalled. For fields that are not maps or structs, this tag is ignored.
 //
+//  5. null: If the null struct tag is specified on a field, the field will be marshalled as a BSON null if it is set to
+//     the zero value for its type, such as 0 for integers, "" for strings, a zero struct, or nil for pointers, slices,
+//     maps, and interfaces. Unlike omitempty, empty but non-nil slices and maps are not considered zero, and the
+//     NilSliceAsEmpty, NilMapAsEmpty, and NilByteSliceAsEmpty Encoder options do not apply to the field. Fields whose
+//     types implement the bsoncodec.Zeroer interface are zero if the IsZero method returns true. Nil pointer fields are
+//     always marshalled as BSON null unless omitempty is specified, so this tag is mainly useful for non-pointer fields.
+//     When unmarshalling, a BSON null sets the field to its zero value. This tag cannot be combined with omitempty.
+//
 // # Marshalling and Unmarshalling
 //
 // Manually marshalling and unmarshalling can be done with the Marshal and Unmarshal family of functions.
@@ -10,6 +10,7 @@ import (
 	"errors"
 	"reflect"
 	"sync"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
@@ -38,6 +39,9 @@ type Encoder struct {
 	nilByteSliceAsEmpty     bool
 	omitZeroStruct          bool
 	useJSONStructTags       bool
+	useJSONMarshalers       bool
+	parseJSONRawMessage     bool
+	durationUnit            time.Duration
 }
 
 // NewEncoder returns a new encoder that uses the DefaultRegistry to write to vw.
@@ -84,11 +88,6 @@ func (e *Encoder) Encode(val interface{}) error {
 		return bsonrw.Copier{}.CopyDocumentFromBytes(e.vw, buf)
 	}
 
-	encoder, err := e.ec.LookupEncoder(reflect.TypeOf(val))
-	if err != nil {
-		return err
-	}
-
 	// Copy the configurations applied to the Encoder over to the EncodeContext, which actually
 	// communicates those configurations to the default ValueEncoders.
 	if e.errorOnInlineDuplicates {
@@ -115,6 +114,22 @@ func (e *Encoder) Encode(val interface{}) error {
 	if e.useJSONStructTags {
 		e.ec.UseJSONStructTags()
 	}
+	if e.useJSONMarshalers {
+		e.ec.UseJSONMarshalers()
+	}
+	if e.parseJSONRawMessage {
+		e.ec.ParseJSONRawMessage()
+	}
+	if e.durationUnit > 0 {
+		e.ec.DurationUnit(e.durationUnit)
+	}
+
+	// Look up the encoder after the configurations are copied because they can affect which
+	// encoder is used.
+	encoder, err := e.ec.LookupEncoder(reflect.TypeOf(val))
+	if err != nil {
+		return err
+	}
 
 	return encoder.EncodeValue(e.ec, e.vw, reflect.ValueOf(val))
 }
@@ -197,3 +212,31 @@ func (e *Encoder) OmitZeroStruct() {
 func (e *Encoder) UseJSONStructTags() {
 	e.useJSONStructTags = true
 }
+
+// UseJSONMarshalers causes the Encoder to marshal values that implement json.Marshaler by
+// converting the output of their MarshalJSON method to BSON. The JSON is parsed as relaxed
+// Extended JSON, so a MarshalJSON output like {"$oid": "..."} is marshaled as the corresponding
+// BSON type.
+//
+// MarshalJSON is only used as a fallback. Implementations of Marshaler or ValueMarshaler, any
+// other interface encoder in the registry, and encoders registered for the exact type (e.g. the
+// encoders for time.Time and primitive.ObjectID) take precedence.
+func (e *Encoder) UseJSONMarshalers() {
+	e.useJSONMarshalers = true
+}
+
+// ParseJSONRawMessage causes the Encoder to marshal json.RawMessage values by parsing them as
+// relaxed Extended JSON, so {"x": 1} is marshaled as a BSON document and {"$oid": "..."} as a BSON
+// ObjectID. A nil or empty json.RawMessage is marshaled as BSON null, and invalid JSON causes an
+// error. By default, json.RawMessage values are marshaled as BSON binary, like other byte slices.
+func (e *Encoder) ParseJSONRawMessage() {
+	e.parseJSONRawMessage = true
+}
+
+// DurationUnit causes the Encoder to marshal time.Duration values as the number of whole units of
+// the given duration instead of nanoseconds. For example, with time.Millisecond, 1500*time.Microsecond
+// is marshaled as the BSON int64 1. Any remainder smaller than the unit is truncated. If unit is not
+// positive, time.Duration values are marshaled as nanoseconds.
+func (e *Encoder) DurationUnit(unit time.Duration) {
+	e.durationUnit = unit
+}
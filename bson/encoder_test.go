@@ -11,6 +11,7 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
@@ -144,6 +145,32 @@ func (stringerTest) String() string {
 	return "test key"
 }
 
+type jsonMarshalerOnly struct {
+	Value string
+}
+
+func (jm jsonMarshalerOnly) MarshalJSON() ([]byte, error) {
+	return []byte(`{"json":"` + jm.Value + `"}`), nil
+}
+
+type jsonStringMarshaler string
+
+func (jsm jsonStringMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(`"json ` + string(jsm) + `"`), nil
+}
+
+type jsonAndBSONMarshaler struct {
+	Value string
+}
+
+func (jbm jsonAndBSONMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(`{"json":"` + jbm.Value + `"}`), nil
+}
+
+func (jbm jsonAndBSONMarshaler) MarshalBSON() ([]byte, error) {
+	return Marshal(D{{"bson", jbm.Value}})
+}
+
 func TestEncoderConfiguration(t *testing.T) {
 	type inlineDuplicateInner struct {
 		Duplicate string
@@ -200,6 +227,22 @@ func TestEncoderConfiguration(t *testing.T) {
 				AppendInt32("myUint64", 1).
 				Build(),
 		},
+		// Test that DurationUnit encodes time.Duration values as a number of the given unit and
+		// truncates any remainder. Other int64 values are not affected.
+		{
+			description: "DurationUnit",
+			configure: func(enc *Encoder) {
+				enc.DurationUnit(time.Millisecond)
+			},
+			input: D{
+				{Key: "myDuration", Value: 1500 * time.Microsecond},
+				{Key: "myInt64", Value: int64(1500)},
+			},
+			want: bsoncore.NewDocumentBuilder().
+				AppendInt64("myDuration", 1).
+				AppendInt64("myInt64", 1500).
+				Build(),
+		},
 		// Test that StringifyMapKeysWithFmt uses fmt.Sprint to convert map keys to BSON field names.
 		{
 			description: "StringifyMapKeysWithFmt",
@@ -274,6 +317,76 @@ func TestEncoderConfiguration(t *testing.T) {
 				AppendString("jsonFieldName", "test value").
 				Build(),
 		},
+		// Test that UseJSONMarshalers causes the Encoder to marshal types that only implement
+		// json.Marshaler using their MarshalJSON method.
+		{
+			description: "UseJSONMarshalers",
+			configure: func(enc *Encoder) {
+				enc.UseJSONMarshalers()
+			},
+			input: struct {
+				Doc    jsonMarshalerOnly
+				DocPtr *jsonMarshalerOnly
+				NilPtr *jsonMarshalerOnly
+				Str    jsonStringMarshaler
+				Iface  interface{}
+			}{
+				Doc:    jsonMarshalerOnly{Value: "a"},
+				DocPtr: &jsonMarshalerOnly{Value: "b"},
+				Str:    "c",
+				Iface:  jsonMarshalerOnly{Value: "d"},
+			},
+			want: bsoncore.NewDocumentBuilder().
+				AppendDocument("doc", bsoncore.NewDocumentBuilder().AppendString("json", "a").Build()).
+				AppendDocument("docptr", bsoncore.NewDocumentBuilder().AppendString("json", "b").Build()).
+				AppendNull("nilptr").
+				AppendString("str", "json c").
+				AppendDocument("iface", bsoncore.NewDocumentBuilder().AppendString("json", "d").Build()).
+				Build(),
+		},
+		// Test that UseJSONMarshalers causes the Encoder to use MarshalJSON for a top-level value.
+		{
+			description: "UseJSONMarshalers top-level value",
+			configure: func(enc *Encoder) {
+				enc.UseJSONMarshalers()
+			},
+			input: jsonMarshalerOnly{Value: "a"},
+			want: bsoncore.NewDocumentBuilder().
+				AppendString("json", "a").
+				Build(),
+		},
+		// Test that Marshaler implementations and encoders registered for a specific type take
+		// precedence over MarshalJSON when UseJSONMarshalers is set.
+		{
+			description: "UseJSONMarshalers precedence",
+			configure: func(enc *Encoder) {
+				enc.UseJSONMarshalers()
+			},
+			input: struct {
+				Both jsonAndBSONMarshaler
+				Time time.Time
+			}{
+				Both: jsonAndBSONMarshaler{Value: "a"},
+				Time: time.Unix(1, 0),
+			},
+			want: bsoncore.NewDocumentBuilder().
+				AppendDocument("both", bsoncore.NewDocumentBuilder().AppendString("bson", "a").Build()).
+				AppendDateTime("time", 1000).
+				Build(),
+		},
+		// Test that the Encoder ignores MarshalJSON if UseJSONMarshalers is not set.
+		{
+			description: "json.Marshaler without UseJSONMarshalers",
+			configure:   func(*Encoder) {},
+			input: struct {
+				Doc jsonMarshalerOnly
+			}{
+				Doc: jsonMarshalerOnly{Value: "a"},
+			},
+			want: bsoncore.NewDocumentBuilder().
+				AppendDocument("doc", bsoncore.NewDocumentBuilder().AppendString("value", "a").Build()).
+				Build(),
+		},
 	}
 
 	for _, tc := range testCases {
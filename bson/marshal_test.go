@@ -17,6 +17,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsonrw"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/require"
@@ -380,3 +381,91 @@ func TestMarshalExtJSONIndent(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalNullStructTag(t *testing.T) {
+	type nested struct {
+		A int32
+	}
+	type nullFields struct {
+		Int    int32             `bson:"int,null"`
+		Str    string            `bson:"str,null"`
+		Struct nested            `bson:"struct,null"`
+		Time   time.Time         `bson:"time,null"`
+		Slice  []int32           `bson:"slice,null"`
+		Map    map[string]string `bson:"map,null"`
+		Ptr    *int32            `bson:"ptr,null"`
+		Iface  interface{}       `bson:"iface,null"`
+	}
+
+	t.Run("zero values", func(t *testing.T) {
+		b, err := Marshal(nullFields{})
+		require.NoError(t, err, "Marshal error")
+
+		want := D{
+			{"int", nil},
+			{"str", nil},
+			{"struct", nil},
+			{"time", nil},
+			{"slice", nil},
+			{"map", nil},
+			{"ptr", nil},
+			{"iface", nil},
+		}
+		wantBytes, err := Marshal(want)
+		require.NoError(t, err, "Marshal error")
+		assert.Equal(t, Raw(wantBytes), Raw(b), "expected %v, got %v", Raw(wantBytes), Raw(b))
+
+		var got nullFields
+		err = Unmarshal(b, &got)
+		require.NoError(t, err, "Unmarshal error")
+		assert.Equal(t, nullFields{}, got, "expected zero values after round trip, got %v", got)
+	})
+	t.Run("non-zero values", func(t *testing.T) {
+		i := int32(0)
+		val := nullFields{
+			Int:    1,
+			Str:    "a",
+			Struct: nested{A: 2},
+			Time:   time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			Slice:  []int32{},
+			Map:    map[string]string{},
+			Ptr:    &i,
+			Iface:  "b",
+		}
+		b, err := Marshal(val)
+		require.NoError(t, err, "Marshal error")
+
+		for _, key := range []string{"int", "str", "struct", "time", "slice", "map", "ptr", "iface"} {
+			typ := Raw(b).Lookup(key).Type
+			assert.NotEqual(t, bsontype.Null, typ, "expected %q not to be null, got %v", key, Raw(b))
+		}
+
+		var got nullFields
+		err = Unmarshal(b, &got)
+		require.NoError(t, err, "Unmarshal error")
+		assert.Equal(t, val, got, "expected %v after round trip, got %v", val, got)
+	})
+	t.Run("encoder options do not apply", func(t *testing.T) {
+		buf := new(bytes.Buffer)
+		vw, err := bsonrw.NewBSONValueWriter(buf)
+		require.NoError(t, err, "NewBSONValueWriter error")
+		enc, err := NewEncoder(vw)
+		require.NoError(t, err, "NewEncoder error")
+		enc.NilSliceAsEmpty()
+		enc.NilMapAsEmpty()
+
+		err = enc.Encode(nullFields{})
+		require.NoError(t, err, "Encode error")
+		for _, key := range []string{"slice", "map"} {
+			typ := Raw(buf.Bytes()).Lookup(key).Type
+			assert.Equal(t, bsontype.Null, typ, "expected %q to be null, got %v", key, Raw(buf.Bytes()))
+		}
+	})
+	t.Run("omitempty and null", func(t *testing.T) {
+		type invalid struct {
+			A int32 `bson:"a,omitempty,null"`
+		}
+		_, err := Marshal(invalid{})
+		assert.ErrorContains(t, err, `struct tag for field "a" cannot contain both omitempty and null`)
+	})
+}
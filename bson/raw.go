@@ -7,9 +7,12 @@
 package bson
 
 import (
+	"bytes"
 	"errors"
 	"io"
+	"math"
 
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
@@ -90,5 +93,165 @@ func (r Raw) IndexErr(index uint) (RawElement, error) {
 	return RawElement(elem), err
 }
 
+// Equal compares r and other structurally and returns true if they represent the same document. Unlike a byte
+// comparison, the order of the fields in a document (including embedded documents) does not matter, while the order
+// of the values in an array does. Numeric values of type int32, int64, and double are equal if they represent the
+// same number, regardless of their BSON type. All other values must have the same type and the same bytes to be
+// equal. If either document is invalid, Equal returns false.
+func (r Raw) Equal(other Raw) bool {
+	return documentsEqual(bsoncore.Document(r), bsoncore.Document(other))
+}
+
+func documentsEqual(a, b bsoncore.Document) bool {
+	aElems, err := a.Elements()
+	if err != nil {
+		return false
+	}
+	bElems, err := b.Elements()
+	if err != nil || len(aElems) != len(bElems) {
+		return false
+	}
+
+	bValues := make(map[string]bsoncore.Value, len(bElems))
+	for _, elem := range bElems {
+		bValues[elem.Key()] = elem.Value()
+	}
+	if len(bValues) != len(bElems) {
+		// Duplicate keys can't be matched reliably, so fall back to an exact comparison.
+		return bytes.Equal(a, b)
+	}
+
+	for _, elem := range aElems {
+		bVal, ok := bValues[elem.Key()]
+		if !ok || !coreValuesEqual(elem.Value(), bVal) {
+			return false
+		}
+	}
+	return true
+}
+
+func arraysEqual(a, b bsoncore.Array) bool {
+	aVals, err := a.Values()
+	if err != nil {
+		return false
+	}
+	bVals, err := b.Values()
+	if err != nil || len(aVals) != len(bVals) {
+		return false
+	}
+
+	for i := range aVals {
+		if !coreValuesEqual(aVals[i], bVals[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func coreValuesEqual(a, b bsoncore.Value) bool {
+	if isEqualityNumber(a.Type) && isEqualityNumber(b.Type) {
+		return numbersEqual(a, b)
+	}
+	if a.Type != b.Type {
+		return false
+	}
+
+	switch a.Type {
+	case bsontype.EmbeddedDocument:
+		return documentsEqual(a.Document(), b.Document())
+	case bsontype.Array:
+		return arraysEqual(a.Array(), b.Array())
+	default:
+		return bytes.Equal(a.Data, b.Data)
+	}
+}
+
+func isEqualityNumber(t bsontype.Type) bool {
+	return t == bsontype.Int32 || t == bsontype.Int64 || t == bsontype.Double
+}
+
+func numbersEqual(a, b bsoncore.Value) bool {
+	if a.Type == bsontype.Double && b.Type == bsontype.Double {
+		af, bf := a.Double(), b.Double()
+		return af == bf || (math.IsNaN(af) && math.IsNaN(bf))
+	}
+	if a.Type == bsontype.Double {
+		return doubleEqualsInt(a.Double(), b.AsInt64())
+	}
+	if b.Type == bsontype.Double {
+		return doubleEqualsInt(b.Double(), a.AsInt64())
+	}
+	return a.AsInt64() == b.AsInt64()
+}
+
+// doubleEqualsInt returns true if f is an integral value that is exactly equal to i.
+func doubleEqualsInt(f float64, i int64) bool {
+	if math.Trunc(f) != f || f < math.MinInt64 || f >= math.MaxInt64 {
+		return false
+	}
+	return int64(f) == i
+}
+
+// Walk calls fn for each value in the document that is not an embedded document or array, descending into embedded
+// documents and arrays in order. The path passed to fn contains the keys from the top-level document to the value,
+// with array elements identified by their index (e.g. ["a", "0", "b"] for {a: [{b: 1}]}). Empty embedded documents and
+// arrays do not cause fn to be called.
+//
+// Walk does not decode values or build intermediate maps. To avoid allocations, the path slice is reused between
+// calls and the value refers to the bytes of r, so both are only valid until fn returns; a copy must be made if they
+// need to be retained. If fn returns an error, Walk stops and returns that error. If the document is invalid, Walk
+// returns an error after calling fn for the values that precede the invalid point.
+func (r Raw) Walk(fn func(path []string, v RawValue) error) error {
+	_, err := walkDocument(bsoncore.Document(r), make([]string, 0, 8), fn)
+	return err
+}
+
+// walkDocument walks the elements of doc, which can be a document or an array. It returns the path slice so that
+// any growth of its backing array is reused by the caller.
+func walkDocument(
+	doc bsoncore.Document,
+	path []string,
+	fn func(path []string, v RawValue) error,
+) ([]string, error) {
+	length, rem, ok := bsoncore.ReadLength(doc)
+	if !ok {
+		return path, bsoncore.NewInsufficientBytesError(doc, rem)
+	}
+	if int(length) > len(doc) {
+		return path, bsoncore.NewDocumentLengthError(int(length), len(doc))
+	}
+
+	length -= 4
+	var elem bsoncore.Element
+	for length > 1 {
+		elem, rem, ok = bsoncore.ReadElement(rem)
+		length -= int32(len(elem))
+		if !ok {
+			return path, bsoncore.NewInsufficientBytesError(doc, rem)
+		}
+
+		key, err := elem.KeyErr()
+		if err != nil {
+			return path, err
+		}
+		val := elem.Value()
+		path = append(path, key)
+
+		switch val.Type {
+		case bsontype.EmbeddedDocument, bsontype.Array:
+			path, err = walkDocument(val.Data, path, fn)
+		default:
+			if err = val.Validate(); err == nil {
+				err = fn(path, convertFromCoreValue(val))
+			}
+		}
+		if err != nil {
+			return path, err
+		}
+		path = path[:len(path)-1]
+	}
+	return path, nil
+}
+
 // String returns the BSON document encoded as Extended JSON.
 func (r Raw) String() string { return bsoncore.Document(r).String() }
@@ -9,6 +9,7 @@ package bson
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/require"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
@@ -427,3 +429,201 @@ func BenchmarkRawString(b *testing.B) {
 		})
 	}
 }
+
+func TestRawEqual(t *testing.T) {
+	t.Parallel()
+
+	marshal := func(t *testing.T, val interface{}) Raw {
+		t.Helper()
+
+		b, err := Marshal(val)
+		require.NoError(t, err, "Marshal error")
+		return b
+	}
+
+	testCases := []struct {
+		name string
+		a    interface{}
+		b    interface{}
+		want bool
+	}{
+		{
+			name: "identical",
+			a:    D{{"x", 1}, {"y", "foo"}},
+			b:    D{{"x", 1}, {"y", "foo"}},
+			want: true,
+		},
+		{
+			name: "reordered fields",
+			a:    D{{"x", 1}, {"y", "foo"}},
+			b:    D{{"y", "foo"}, {"x", 1}},
+			want: true,
+		},
+		{
+			name: "different values",
+			a:    D{{"x", 1}, {"y", "foo"}},
+			b:    D{{"x", 1}, {"y", "bar"}},
+			want: false,
+		},
+		{
+			name: "missing field",
+			a:    D{{"x", 1}, {"y", "foo"}},
+			b:    D{{"x", 1}},
+			want: false,
+		},
+		{
+			name: "different field names",
+			a:    D{{"x", 1}},
+			b:    D{{"y", 1}},
+			want: false,
+		},
+		{
+			name: "int32 and int64",
+			a:    D{{"x", int32(1)}},
+			b:    D{{"x", int64(1)}},
+			want: true,
+		},
+		{
+			name: "int64 and double",
+			a:    D{{"x", int64(42)}},
+			b:    D{{"x", 42.0}},
+			want: true,
+		},
+		{
+			name: "int32 and non-integral double",
+			a:    D{{"x", int32(1)}},
+			b:    D{{"x", 1.5}},
+			want: false,
+		},
+		{
+			name: "number and string",
+			a:    D{{"x", int32(1)}},
+			b:    D{{"x", "1"}},
+			want: false,
+		},
+		{
+			name: "reordered fields in nested document",
+			a:    D{{"x", D{{"a", 1}, {"b", D{{"c", 2}, {"d", 3}}}}}},
+			b:    D{{"x", D{{"b", D{{"d", int64(3)}, {"c", 2.0}}}, {"a", 1}}}},
+			want: true,
+		},
+		{
+			name: "different nested values",
+			a:    D{{"x", D{{"a", 1}, {"b", 2}}}},
+			b:    D{{"x", D{{"a", 1}, {"b", 3}}}},
+			want: false,
+		},
+		{
+			name: "arrays with documents",
+			a:    D{{"x", A{D{{"a", 1}, {"b", 2}}, int32(3)}}},
+			b:    D{{"x", A{D{{"b", 2}, {"a", 1}}, int64(3)}}},
+			want: true,
+		},
+		{
+			name: "reordered arrays",
+			a:    D{{"x", A{1, 2}}},
+			b:    D{{"x", A{2, 1}}},
+			want: false,
+		},
+		{
+			name: "document and array",
+			a:    D{{"x", D{{"0", 1}}}},
+			b:    D{{"x", A{1}}},
+			want: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // Capture range variable.
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			a, b := marshal(t, tc.a), marshal(t, tc.b)
+			assert.Equal(t, tc.want, a.Equal(b), "expected Equal(%v, %v) to be %v", a, b, tc.want)
+			assert.Equal(t, tc.want, b.Equal(a), "expected Equal(%v, %v) to be %v", b, a, tc.want)
+		})
+	}
+
+	t.Run("invalid document", func(t *testing.T) {
+		t.Parallel()
+
+		valid := marshal(t, D{{"x", 1}})
+		assert.False(t, valid.Equal(Raw{0x05}), "expected invalid document to not be equal")
+	})
+}
+
+func TestRawWalk(t *testing.T) {
+	t.Parallel()
+
+	doc, err := Marshal(D{
+		{"a", 1},
+		{"b", D{
+			{"c", "foo"},
+			{"d", A{true, D{{"e", 2.5}}}},
+		}},
+		{"f", D{}},
+		{"g", A{}},
+		{"h", nil},
+	})
+	require.NoError(t, err, "Marshal error")
+
+	t.Run("visits leaf values", func(t *testing.T) {
+		t.Parallel()
+
+		var paths []string
+		var values []RawValue
+		err := Raw(doc).Walk(func(path []string, v RawValue) error {
+			paths = append(paths, strings.Join(path, "."))
+			values = append(values, v)
+			return nil
+		})
+		require.NoError(t, err, "Walk error")
+
+		wantPaths := []string{"a", "b.c", "b.d.0", "b.d.1.e", "h"}
+		assert.Equal(t, wantPaths, paths, "expected and actual paths are different")
+		wantTypes := []bsontype.Type{bsontype.Int32, bsontype.String, bsontype.Boolean, bsontype.Double, bsontype.Null}
+		for i, v := range values {
+			assert.Equal(t, wantTypes[i], v.Type, "expected type %v for path %q, got %v", wantTypes[i], paths[i], v.Type)
+		}
+		assert.Equal(t, 2.5, values[3].Double(), "expected value 2.5 for path %q", paths[3])
+	})
+	t.Run("stops on error", func(t *testing.T) {
+		t.Parallel()
+
+		stopErr := errors.New("stop")
+		var visited int
+		err := Raw(doc).Walk(func(path []string, _ RawValue) error {
+			visited++
+			if strings.Join(path, ".") == "b.c" {
+				return stopErr
+			}
+			return nil
+		})
+		assert.Equal(t, stopErr, err, "expected error %v, got %v", stopErr, err)
+		assert.Equal(t, 2, visited, "expected 2 values to be visited, got %d", visited)
+	})
+	t.Run("invalid document", func(t *testing.T) {
+		t.Parallel()
+
+		err := Raw(doc[:len(doc)-8]).Walk(func([]string, RawValue) error { return nil })
+		assert.NotNil(t, err, "expected error for invalid document, got nil")
+	})
+}
+
+func BenchmarkRawWalk(b *testing.B) {
+	doc, err := Marshal(D{
+		{"a", 1},
+		{"b", D{
+			{"c", "foo"},
+			{"d", A{true, D{{"e", 2.5}}}},
+		}},
+	})
+	require.NoError(b, err, "Marshal error")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = Raw(doc).Walk(func([]string, RawValue) error { return nil })
+	}
+}
@@ -113,6 +113,85 @@ func (rv RawValue) Validate() error { return convertToCoreValue(rv).Validate() }
 // IsNumber returns true if the type of v is a numeric BSON type.
 func (rv RawValue) IsNumber() bool { return convertToCoreValue(rv).IsNumber() }
 
+// IsNullOrAbsent returns true if rv is the zero RawValue, which is what lookups return for keys that do not exist,
+// or if rv is a BSON null or undefined value.
+func (rv RawValue) IsNullOrAbsent() bool {
+	switch rv.Type {
+	case 0x00, bsontype.Null, bsontype.Undefined:
+		return true
+	}
+	return false
+}
+
+// IsZero returns true if rv is null or absent (see IsNullOrAbsent) or holds the zero value of its BSON type: an empty
+// string, JavaScript code, or symbol, a numeric zero, false, an empty document or array, binary data with no bytes,
+// the zero ObjectID, a zero datetime or timestamp, or an empty regular expression. MinKey, MaxKey, and values that
+// are not valid for their type are never zero.
+func (rv RawValue) IsZero() bool {
+	if rv.IsNullOrAbsent() {
+		return true
+	}
+
+	switch rv.Type {
+	case bsontype.String:
+		s, ok := rv.StringValueOK()
+		return ok && s == ""
+	case bsontype.JavaScript:
+		s, ok := rv.JavaScriptOK()
+		return ok && s == ""
+	case bsontype.Symbol:
+		s, ok := rv.SymbolOK()
+		return ok && s == ""
+	case bsontype.Double:
+		f, ok := rv.DoubleOK()
+		return ok && f == 0
+	case bsontype.Int32:
+		i, ok := rv.Int32OK()
+		return ok && i == 0
+	case bsontype.Int64:
+		i, ok := rv.Int64OK()
+		return ok && i == 0
+	case bsontype.Decimal128:
+		d, ok := rv.Decimal128OK()
+		if !ok {
+			return false
+		}
+		bi, _, err := d.BigInt()
+		return err == nil && bi.Sign() == 0
+	case bsontype.Boolean:
+		b, ok := rv.BooleanOK()
+		return ok && !b
+	case bsontype.EmbeddedDocument:
+		doc, ok := rv.DocumentOK()
+		return ok && len(doc) == 5
+	case bsontype.Array:
+		arr, ok := rv.ArrayOK()
+		return ok && len(arr) == 5
+	case bsontype.Binary:
+		_, data, ok := rv.BinaryOK()
+		return ok && len(data) == 0
+	case bsontype.ObjectID:
+		oid, ok := rv.ObjectIDOK()
+		return ok && oid.IsZero()
+	case bsontype.DateTime:
+		dt, ok := rv.DateTimeOK()
+		return ok && dt == 0
+	case bsontype.Timestamp:
+		t, i, ok := rv.TimestampOK()
+		return ok && t == 0 && i == 0
+	case bsontype.Regex:
+		pattern, options, ok := rv.RegexOK()
+		return ok && pattern == "" && options == ""
+	case bsontype.DBPointer:
+		ns, oid, ok := rv.DBPointerOK()
+		return ok && ns == "" && oid.IsZero()
+	case bsontype.CodeWithScope:
+		code, scope, ok := rv.CodeWithScopeOK()
+		return ok && code == "" && len(scope) == 5
+	}
+	return false
+}
+
 // String implements the fmt.String interface. This method will return values in extended JSON
 // format. If the value is not valid, this returns an empty string
 func (rv RawValue) String() string { return convertToCoreValue(rv).String() }
@@ -13,6 +13,8 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
@@ -121,4 +123,58 @@ func TestRawValue(t *testing.T) {
 			}
 		})
 	})
+	t.Run("IsZero and IsNullOrAbsent", func(t *testing.T) {
+		doc := Raw(bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendNullElement(nil, "null"),
+			bsoncore.AppendStringElement(nil, "emptyString", ""),
+			bsoncore.AppendStringElement(nil, "string", "foo"),
+			bsoncore.AppendInt32Element(nil, "zeroInt32", 0),
+			bsoncore.AppendInt32Element(nil, "int32", 1),
+			bsoncore.AppendInt64Element(nil, "zeroInt64", 0),
+			bsoncore.AppendDoubleElement(nil, "zeroDouble", 0),
+			bsoncore.AppendDecimal128Element(nil, "zeroDecimal", primitive.NewDecimal128(0x3040000000000000, 0)),
+			bsoncore.AppendDecimal128Element(nil, "decimal", primitive.NewDecimal128(0x3040000000000000, 1)),
+			bsoncore.AppendBooleanElement(nil, "false", false),
+			bsoncore.AppendBooleanElement(nil, "true", true),
+			bsoncore.AppendDocumentElement(nil, "emptyDocument", bsoncore.BuildDocument(nil)),
+			bsoncore.AppendArrayElement(nil, "emptyArray", bsoncore.BuildArray(nil)),
+			bsoncore.AppendObjectIDElement(nil, "zeroObjectID", primitive.NilObjectID),
+			bsoncore.AppendObjectIDElement(nil, "objectID", primitive.NewObjectID()),
+			bsoncore.AppendMinKeyElement(nil, "minKey"),
+		))
+
+		testCases := []struct {
+			key            string
+			isZero         bool
+			isNullOrAbsent bool
+		}{
+			{"absent", true, true},
+			{"null", true, true},
+			{"emptyString", true, false},
+			{"string", false, false},
+			{"zeroInt32", true, false},
+			{"int32", false, false},
+			{"zeroInt64", true, false},
+			{"zeroDouble", true, false},
+			{"zeroDecimal", true, false},
+			{"decimal", false, false},
+			{"false", true, false},
+			{"true", false, false},
+			{"emptyDocument", true, false},
+			{"emptyArray", true, false},
+			{"zeroObjectID", true, false},
+			{"objectID", false, false},
+			{"minKey", false, false},
+		}
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.key, func(t *testing.T) {
+				val := doc.Lookup(tc.key)
+				assert.Equal(t, tc.isZero, val.IsZero(), "expected IsZero %v for %v", tc.isZero, val)
+				assert.Equal(t, tc.isNullOrAbsent, val.IsNullOrAbsent(),
+					"expected IsNullOrAbsent %v for %v", tc.isNullOrAbsent, val)
+			})
+		}
+	})
 }
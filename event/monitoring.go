@@ -35,17 +35,22 @@ type CommandStartedEvent struct {
 	// ServiceID contains the ID of the server to which the command was sent if it is running behind a load balancer.
 	// Otherwise, it is unset.
 	ServiceID *primitive.ObjectID
+	// Metadata contains the map stored with mongo.WithCommandMetadata in the context used to run the command. If the
+	// context has no metadata, it is nil. The map is not copied, so it must not be modified while commands are running.
+	Metadata map[string]interface{}
 }
 
 // CommandFinishedEvent represents a generic command finishing.
 type CommandFinishedEvent struct {
 	// Deprecated: Use Duration instead.
 	DurationNanos int64
-	Duration      time.Duration
-	CommandName   string
-	DatabaseName  string
-	RequestID     int64
-	ConnectionID  string
+	// Duration is the time between writing the command to the connection and receiving its reply (or error). It is
+	// measured using the monotonic clock, so it is not affected by changes to the system's wall clock.
+	Duration     time.Duration
+	CommandName  string
+	DatabaseName string
+	RequestID    int64
+	ConnectionID string
 	// ServerConnectionID contains the connection ID from the server of the operation. If the server does not return
 	// this value (e.g. on MDB < 4.2), it is unset.If the server connection ID would cause an int32 overflow, then
 	// this field will be nil.
@@ -122,6 +127,12 @@ type PoolEvent struct {
 	// can be used to distinguish between individual servers in a load balanced deployment.
 	ServiceID *primitive.ObjectID `json:"serviceId"`
 	Error     error               `json:"error"`
+	// Generation is the generation of the pool. It is only set if the Type is PoolCleared or ConnectionCreated. For
+	// PoolCleared, it is the generation after the clear: connections from earlier generations are stale and will be
+	// closed instead of being reused. For ConnectionCreated, it is the generation that the new connection belongs to.
+	// It is not set for ConnectionCreated events in load balanced deployments, because the generation depends on the
+	// service ID of the connection, which is not known until the connection handshake completes.
+	Generation *uint64 `json:"generation"`
 }
 
 // PoolMonitor is a function that allows the user to gain access to events occurring in the pool
@@ -178,8 +189,9 @@ type ServerHeartbeatSucceededEvent struct {
 	DurationNanos int64
 	Duration      time.Duration
 	Reply         description.Server
-	ConnectionID  string // The address this heartbeat was sent to with a unique identifier
-	Awaited       bool   // If this heartbeat was awaitable
+	ConnectionID  string        // The address this heartbeat was sent to with a unique identifier
+	Awaited       bool          // If this heartbeat was awaitable
+	AverageRTT    time.Duration // The server's average round-trip time as measured by the heartbeat monitor
 }
 
 // ServerHeartbeatFailedEvent is an event generated when the heartbeat fails.
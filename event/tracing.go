@@ -0,0 +1,55 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package event
+
+import "context"
+
+// TracerProvider provides the Tracer used by a Client to create spans. Its methods mirror the subset of the
+// OpenTelemetry tracing API used by the driver, so an OpenTelemetry TracerProvider can be adapted to it with a small
+// wrapper type without the driver depending on OpenTelemetry.
+type TracerProvider interface {
+	// Tracer returns the Tracer with the given instrumentation name.
+	Tracer(name string) Tracer
+}
+
+// Tracer creates spans.
+type Tracer interface {
+	// Start creates a span with the given name and attributes and returns it along with a context containing it.
+	Start(ctx context.Context, name string, attrs ...SpanAttribute) (context.Context, Span)
+}
+
+// Span is a single operation traced by a Tracer.
+type Span interface {
+	// SetAttributes sets attributes on the span.
+	SetAttributes(attrs ...SpanAttribute)
+
+	// RecordError records an error that caused the operation to fail.
+	RecordError(err error)
+
+	// End completes the span.
+	End()
+}
+
+// SpanAttribute is a key/value pair describing a span. Value is a string, an int64, or a bool.
+type SpanAttribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Keys of the attributes set on the spans created by the driver.
+const (
+	SpanAttributeDBName         = "db.name"
+	SpanAttributeCollectionName = "db.mongodb.collection"
+	SpanAttributeOperation      = "db.operation"
+
+	// SpanAttributeEventCount is the number of change events returned by a change stream operation.
+	SpanAttributeEventCount = "db.mongodb.change_stream.event_count"
+
+	// SpanAttributeOperationTypePrefix is followed by an operation type (e.g. "insert") to form the key of the
+	// attribute holding the number of change events of that type returned by a change stream operation.
+	SpanAttributeOperationTypePrefix = "db.mongodb.change_stream.operation_type."
+)
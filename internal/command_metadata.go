@@ -0,0 +1,23 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package internal
+
+import "context"
+
+type commandMetadataKey struct{}
+
+// WithCommandMetadata returns a copy of ctx that carries metadata to be published on the CommandStartedEvent of every
+// command run with the returned context.
+func WithCommandMetadata(ctx context.Context, metadata map[string]interface{}) context.Context {
+	return context.WithValue(ctx, commandMetadataKey{}, metadata)
+}
+
+// CommandMetadata returns the metadata stored in ctx by WithCommandMetadata, or nil if there is none.
+func CommandMetadata(ctx context.Context) map[string]interface{} {
+	metadata, _ := ctx.Value(commandMetadataKey{}).(map[string]interface{})
+	return metadata
+}
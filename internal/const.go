@@ -6,6 +6,8 @@
 
 package internal // import "go.mongodb.org/mongo-driver/internal"
 
+import "time"
+
 // Version is the current version of the driver.
 var Version = "local build"
 
@@ -17,3 +19,11 @@ var LegacyHelloLowercase = "ismaster"
 
 // LegacyNotPrimary is the legacy version of the "not primary" server error message.
 var LegacyNotPrimary = "not master"
+
+// MinHeartbeatInterval is the smallest amount of time that server monitors wait between checks of a server. It is also
+// the smallest heartbeat interval that can be set on a Client.
+const MinHeartbeatInterval = 500 * time.Millisecond
+
+// AllowLowHeartbeatIntervals disables the MinHeartbeatInterval check when validating client options. It must only be
+// set by tests that need RTT samples or SRV polling more often than MinHeartbeatInterval allows.
+var AllowLowHeartbeatIntervals = false
@@ -0,0 +1,95 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package logger
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TextSink writes human-readable log lines to an io.Writer. Each line has the
+// form:
+//
+//	<RFC 3339 timestamp> <LEVEL> <message> key1=value1 key2=value2 ...
+//
+// where LEVEL is one of INFO, DEBUG, or ERROR. String values that contain
+// whitespace, quotes, or an equals sign are quoted.
+type TextSink struct {
+	out io.Writer
+
+	// outMu protects the writer from concurrent writes. While the logger
+	// itself does not concurrently write to the sink, the sink may be used
+	// concurrently within the driver.
+	outMu sync.Mutex
+
+	// now returns the current time and can be overridden in tests.
+	now func() time.Time
+}
+
+// Compile-time check to ensure TextSink implements the LogSink interface.
+var _ LogSink = &TextSink{}
+
+// NewTextSink will create a TextSink object that writes text lines to the
+// provided io.Writer.
+func NewTextSink(out io.Writer) *TextSink {
+	return &TextSink{
+		out: out,
+		now: time.Now,
+	}
+}
+
+// Info will write a text line for the message to the io.Writer.
+func (sink *TextSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	levelName := "INFO"
+	if Level(level+DiffToInfo) >= LevelDebug {
+		levelName = "DEBUG"
+	}
+
+	sink.write(levelName, msg, keysAndValues)
+}
+
+// Error will write a text line for the error message to the io.Writer.
+func (sink *TextSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	keysAndValues = append(keysAndValues, KeyError, err.Error())
+	sink.write("ERROR", msg, keysAndValues)
+}
+
+func (sink *TextSink) write(levelName, msg string, keysAndValues []interface{}) {
+	var b strings.Builder
+
+	b.WriteString(sink.now().UTC().Format(time.RFC3339Nano))
+	b.WriteByte(' ')
+	b.WriteString(levelName)
+	b.WriteByte(' ')
+	b.WriteString(msg)
+
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		b.WriteByte(' ')
+		b.WriteString(fmt.Sprint(keysAndValues[i]))
+		b.WriteByte('=')
+		b.WriteString(formatTextValue(keysAndValues[i+1]))
+	}
+	b.WriteByte('\n')
+
+	sink.outMu.Lock()
+	defer sink.outMu.Unlock()
+
+	_, _ = io.WriteString(sink.out, b.String())
+}
+
+func formatTextValue(val interface{}) string {
+	str := fmt.Sprint(val)
+	if str == "" || strings.ContainsAny(str, " \t\n\"=") {
+		return strconv.Quote(str)
+	}
+
+	return str
+}
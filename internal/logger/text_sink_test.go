@@ -0,0 +1,72 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTextSink(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2023, time.March, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		write func(sink *TextSink)
+		want  string
+	}{
+		{
+			name: "info",
+			write: func(sink *TextSink) {
+				sink.Info(int(LevelInfo)-DiffToInfo, CommandStarted, KeyCommandName, "ping", KeyRequestID, 5)
+			},
+			want: "2023-03-01T12:30:00Z INFO Command started commandName=ping requestId=5\n",
+		},
+		{
+			name: "debug",
+			write: func(sink *TextSink) {
+				sink.Info(int(LevelDebug)-DiffToInfo, ConnectionCreated, KeyDriverConnectionID, 1)
+			},
+			want: "2023-03-01T12:30:00Z DEBUG Connection created driverConnectionId=1\n",
+		},
+		{
+			name: "quoted values",
+			write: func(sink *TextSink) {
+				sink.Info(0, CommandStarted, KeyCommand, `{"ping": 1}`, KeyDatabaseName, "")
+			},
+			want: `2023-03-01T12:30:00Z INFO Command started command="{\"ping\": 1}" databaseName=""` + "\n",
+		},
+		{
+			name: "error",
+			write: func(sink *TextSink) {
+				sink.Error(errors.New("boom"), CommandFailed, KeyCommandName, "ping")
+			},
+			want: "2023-03-01T12:30:00Z ERROR Command failed commandName=ping error=boom\n",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // Capture the range variable.
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+			sink := NewTextSink(buf)
+			sink.now = func() time.Time { return now }
+
+			tt.write(sink)
+			if got := buf.String(); got != tt.want {
+				t.Errorf("expected line %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
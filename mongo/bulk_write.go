@@ -117,6 +117,7 @@ func (bw *bulkWrite) runBatch(ctx context.Context, batch bulkWriteBatch) (BulkWr
 			batchErr.WriteConcernError = convertDriverWriteConcernError(writeErr.WriteConcernError)
 		}
 		batchRes.InsertedCount = res.N
+		batchRes.OperationTime = res.OperationTime
 	case *DeleteOneModel, *DeleteManyModel:
 		res, err := bw.runDelete(ctx, batch)
 		if err != nil {
@@ -129,6 +130,7 @@ func (bw *bulkWrite) runBatch(ctx context.Context, batch bulkWriteBatch) (BulkWr
 			batchErr.WriteConcernError = convertDriverWriteConcernError(writeErr.WriteConcernError)
 		}
 		batchRes.DeletedCount = res.N
+		batchRes.OperationTime = res.OperationTime
 	case *ReplaceOneModel, *UpdateOneModel, *UpdateManyModel:
 		res, err := bw.runUpdate(ctx, batch)
 		if err != nil {
@@ -143,6 +145,7 @@ func (bw *bulkWrite) runBatch(ctx context.Context, batch bulkWriteBatch) (BulkWr
 		batchRes.MatchedCount = res.N
 		batchRes.ModifiedCount = res.NModified
 		batchRes.UpsertedCount = int64(len(res.Upserted))
+		batchRes.OperationTime = res.OperationTime
 		for _, upsert := range res.Upserted {
 			batchRes.UpsertedIDs[int64(batch.indexes[upsert.Index])] = upsert.ID
 		}
@@ -184,7 +187,8 @@ func (bw *bulkWrite) runInsert(ctx context.Context, batch bulkWriteBatch) (opera
 		ServerSelector(bw.selector).ClusterClock(bw.collection.client.clock).
 		Database(bw.collection.db.name).Collection(bw.collection.name).
 		Deployment(bw.collection.client.deployment).Crypt(bw.collection.client.cryptFLE).
-		ServerAPI(bw.collection.client.serverAPI).Timeout(bw.collection.client.timeout).
+		ServerAPI(bw.collection.client.serverAPI).
+		RequestIDFn(bw.collection.client.requestIDFn).Timeout(bw.collection.client.timeout).
 		Logger(bw.collection.client.logger)
 	if bw.comment != nil {
 		comment, err := marshalValue(bw.comment, bw.collection.bsonOpts, bw.collection.registry)
@@ -204,7 +208,7 @@ func (bw *bulkWrite) runInsert(ctx context.Context, batch bulkWriteBatch) (opera
 	if bw.collection.client.retryWrites && batch.canRetry {
 		retry = driver.RetryOncePerCommand
 	}
-	op = op.Retry(retry)
+	op = op.Retry(retry).MaxRetryDuration(bw.collection.client.maxRetryDuration)
 
 	err := op.Execute(ctx)
 
@@ -254,7 +258,8 @@ func (bw *bulkWrite) runDelete(ctx context.Context, batch bulkWriteBatch) (opera
 		ServerSelector(bw.selector).ClusterClock(bw.collection.client.clock).
 		Database(bw.collection.db.name).Collection(bw.collection.name).
 		Deployment(bw.collection.client.deployment).Crypt(bw.collection.client.cryptFLE).Hint(hasHint).
-		ServerAPI(bw.collection.client.serverAPI).Timeout(bw.collection.client.timeout).
+		ServerAPI(bw.collection.client.serverAPI).
+		RequestIDFn(bw.collection.client.requestIDFn).Timeout(bw.collection.client.timeout).
 		Logger(bw.collection.client.logger)
 	if bw.comment != nil {
 		comment, err := marshalValue(bw.comment, bw.collection.bsonOpts, bw.collection.registry)
@@ -277,7 +282,7 @@ func (bw *bulkWrite) runDelete(ctx context.Context, batch bulkWriteBatch) (opera
 	if bw.collection.client.retryWrites && batch.canRetry {
 		retry = driver.RetryOncePerCommand
 	}
-	op = op.Retry(retry)
+	op = op.Retry(retry).MaxRetryDuration(bw.collection.client.maxRetryDuration)
 
 	err := op.Execute(ctx)
 
@@ -386,6 +391,7 @@ func (bw *bulkWrite) runUpdate(ctx context.Context, batch bulkWriteBatch) (opera
 		Database(bw.collection.db.name).Collection(bw.collection.name).
 		Deployment(bw.collection.client.deployment).Crypt(bw.collection.client.cryptFLE).Hint(hasHint).
 		ArrayFilters(hasArrayFilters).ServerAPI(bw.collection.client.serverAPI).
+		RequestIDFn(bw.collection.client.requestIDFn).
 		Timeout(bw.collection.client.timeout).Logger(bw.collection.client.logger)
 	if bw.comment != nil {
 		comment, err := marshalValue(bw.comment, bw.collection.bsonOpts, bw.collection.registry)
@@ -411,7 +417,7 @@ func (bw *bulkWrite) runUpdate(ctx context.Context, batch bulkWriteBatch) (opera
 	if bw.collection.client.retryWrites && batch.canRetry {
 		retry = driver.RetryOncePerCommand
 	}
-	op = op.Retry(retry)
+	op = op.Retry(retry).MaxRetryDuration(bw.collection.client.maxRetryDuration)
 
 	err := op.Execute(ctx)
 
@@ -577,6 +583,9 @@ func (bw *bulkWrite) mergeResults(newResult BulkWriteResult) {
 	bw.result.ModifiedCount += newResult.ModifiedCount
 	bw.result.DeletedCount += newResult.DeletedCount
 	bw.result.UpsertedCount += newResult.UpsertedCount
+	if newResult.OperationTime != nil {
+		bw.result.OperationTime = newResult.OperationTime
+	}
 
 	for index, upsertID := range newResult.UpsertedIDs {
 		bw.result.UpsertedIDs[index] = upsertID
@@ -0,0 +1,71 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ChangeEvent is a change stream event. It contains the fields common to the change events returned by the server.
+// Fields that are not present in an event are left as their zero values. See
+// https://www.mongodb.com/docs/manual/reference/change-events/ for a description of each field.
+//
+// The bson.Raw fields of a ChangeEvent are copies and remain valid after the next call to ChangeStream.Next or
+// ChangeStream.TryNext.
+type ChangeEvent struct {
+	// ID is the resume token for the event.
+	ID bson.Raw `bson:"_id"`
+
+	// OperationType is the type of operation that caused the event (e.g. "insert", "update", or "delete").
+	OperationType string `bson:"operationType"`
+
+	// FullDocument is the document created or modified by the operation. It is only present for insert and
+	// replace events, and for update events if the FullDocument option is set.
+	FullDocument bson.Raw `bson:"fullDocument,omitempty"`
+
+	// Ns is the namespace affected by the event.
+	Ns ChangeEventNamespace `bson:"ns"`
+
+	// DocumentKey contains the _id, and the shard key for sharded collections, of the document affected by the
+	// operation.
+	DocumentKey bson.Raw `bson:"documentKey,omitempty"`
+
+	// ClusterTime is the timestamp from the oplog entry associated with the event.
+	ClusterTime primitive.Timestamp `bson:"clusterTime"`
+
+	// UpdateDescription describes the fields changed by an update operation. It is nil for all other events.
+	UpdateDescription *ChangeEventUpdateDescription `bson:"updateDescription,omitempty"`
+}
+
+// ChangeEventNamespace is the namespace of a change event.
+type ChangeEventNamespace struct {
+	Db   string `bson:"db"`
+	Coll string `bson:"coll,omitempty"`
+}
+
+// ChangeEventUpdateDescription describes the fields changed by an update operation.
+type ChangeEventUpdateDescription struct {
+	// UpdatedFields is a document whose keys are the updated fields and whose values are the new values.
+	UpdatedFields bson.Raw `bson:"updatedFields"`
+
+	// RemovedFields contains the names of the fields that were removed.
+	RemovedFields []string `bson:"removedFields"`
+
+	// TruncatedArrays contains the array fields that were truncated by a pipeline-based update. It is only reported
+	// by server versions 5.0 and higher and is nil for events from older servers.
+	TruncatedArrays []ChangeEventTruncatedArray `bson:"truncatedArrays,omitempty"`
+}
+
+// ChangeEventTruncatedArray describes an array field that was truncated by an update operation.
+type ChangeEventTruncatedArray struct {
+	// Field is the name of the truncated array field.
+	Field string `bson:"field"`
+
+	// NewSize is the number of elements in the array after it was truncated.
+	NewSize int32 `bson:"newSize"`
+}
@@ -7,17 +7,24 @@
 package mongo
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -33,11 +40,23 @@ var (
 	ErrMissingResumeToken = errors.New("cannot provide resume functionality when the resume token is missing")
 	// ErrNilCursor indicates that the underlying cursor for the change stream is nil.
 	ErrNilCursor = errors.New("cursor is nil")
-
-	minResumableLabelWireVersion int32 = 9 // Wire version at which the server includes the resumable error label
-	networkErrorLabel                  = "NetworkError"
-	resumableErrorLabel                = "ResumableChangeStreamError"
-	errorCursorNotFound          int32 = 43 // CursorNotFound error code
+	// ErrInvalidResumeToken indicates that a resume token provided via the ResumeAfter or StartAfter options is not a
+	// well-formed BSON document.
+	ErrInvalidResumeToken = errors.New("resume token is not a valid BSON document")
+	// ErrIncompleteSplitEvent is wrapped by the error returned by ChangeStream.Err if NextReassembled receives an
+	// event that does not continue the fragment sequence of the split event being reassembled, which means that a
+	// fragment was dropped.
+	ErrIncompleteSplitEvent = errors.New("split event is incomplete")
+	// ErrMissingDocumentKey is returned by ChangeStream.DecodeDocumentKey if the current event does not have a
+	// documentKey field, e.g. because it is not a DML event.
+	ErrMissingDocumentKey = errors.New("the current change stream event does not have a documentKey")
+
+	minResumableLabelWireVersion  int32 = 9  // Wire version at which the server includes the resumable error label
+	minSplitLargeEventWireVersion int32 = 17 // Wire version of 6.0, where $changeStreamSplitLargeEvent was backported
+	networkErrorLabel                   = "NetworkError"
+	resumableErrorLabel                 = "ResumableChangeStreamError"
+	nonResumableErrorLabel              = "NonResumableChangeStreamError"
+	errorCursorNotFound           int32 = 43 // CursorNotFound error code
 
 	// Allowlist of error codes that are considered resumable.
 	resumableChangeStreamErrors = map[int32]struct{}{
@@ -59,8 +78,133 @@ var (
 		234:   {}, // RetryChangeStream
 		133:   {}, // FailedToSatisfyReadPreference
 	}
+
+	// Error codes returned when the server a change stream is reading from changes role, such as a replica set member
+	// stepping down or a shard's routing metadata changing. They do not affect whether an error is resumable, but the
+	// OnTopologyChange callback is called after resuming from one of them.
+	topologyChangeErrors = map[int32]struct{}{
+		63:    {}, // StaleShardVersion
+		150:   {}, // StaleEpoch
+		189:   {}, // PrimarySteppedDown
+		10058: {}, // LegacyNotPrimary
+		10107: {}, // NotPrimary
+		11602: {}, // InterruptedDueToReplStateChange
+		13388: {}, // StaleConfig
+		13435: {}, // NotPrimaryNoSecondaryOK
+		13436: {}, // NotPrimaryOrSecondary
+	}
 )
 
+// TopologyChange describes a change in the kind of server or deployment that a ChangeStream reads from. It is passed
+// to the callback registered with ChangeStream.OnTopologyChange.
+type TopologyChange struct {
+	// PreviousServerKind and CurrentServerKind are the kinds of the servers that ran the aggregate before and after
+	// the change stream resumed.
+	PreviousServerKind description.ServerKind
+	CurrentServerKind  description.ServerKind
+
+	// PreviousTopologyKind and CurrentTopologyKind are the kinds of the deployment before and after the change stream
+	// resumed.
+	PreviousTopologyKind description.TopologyKind
+	CurrentTopologyKind  description.TopologyKind
+
+	// Err is the error that caused the change stream to resume. It is nil if the change stream was resumed by a call
+	// to ChangeStream.Resume.
+	Err error
+}
+
+// ChangeStreamStalenessError is returned by ChangeStream.Err if the MaxStaleness option is set and an event's wallTime
+// is further behind the local clock than MaxStaleness.
+type ChangeStreamStalenessError struct {
+	// WallTime is the wallTime of the stale event.
+	WallTime time.Time
+
+	// Staleness is how far WallTime was behind the local clock when the event was checked.
+	Staleness time.Duration
+
+	// MaxStaleness is the configured MaxStaleness option.
+	MaxStaleness time.Duration
+}
+
+// Error implements the error interface.
+func (e ChangeStreamStalenessError) Error() string {
+	return fmt.Sprintf("change stream event with wallTime %v is %v behind the local clock, exceeding the max "+
+		"staleness of %v", e.WallTime, e.Staleness, e.MaxStaleness)
+}
+
+// ChangeStreamWaitTimeoutError is returned by ChangeStream.WaitForEvent if no event arrives within the timeout. The
+// change stream can still be used after this error.
+type ChangeStreamWaitTimeoutError struct {
+	// Timeout is the timeout passed to WaitForEvent.
+	Timeout time.Duration
+}
+
+// Error implements the error interface.
+func (e ChangeStreamWaitTimeoutError) Error() string {
+	return fmt.Sprintf("no change stream event arrived within %v", e.Timeout)
+}
+
+// ChangeStreamError is returned by ChangeStream.ErrDetails. It wraps the error returned by ChangeStream.Err, which may
+// be a CommandError, a network error, or a driver error, and records the state of the change stream when the error
+// occurred. The cause can be inspected with errors.Is and errors.As.
+type ChangeStreamError struct {
+	// StreamID is the ID of the change stream's cursor when the error occurred, or 0 if the change stream had no open
+	// cursor.
+	StreamID int64
+
+	// ResumeToken is the resume token cached by the change stream when the error occurred. It can be passed to the
+	// ResumeAfter or StartAfter option to open a new change stream that continues after the last event returned. It is
+	// nil if no resume token was cached.
+	ResumeToken bson.Raw
+
+	// ResumeAttempted is true if the change stream tried to resume after a resumable error and Wrapped is the error
+	// returned by that resume attempt.
+	ResumeAttempted bool
+
+	// Wrapped is the underlying error.
+	Wrapped error
+}
+
+// Error implements the error interface.
+func (e ChangeStreamError) Error() string {
+	return e.Wrapped.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e ChangeStreamError) Unwrap() error {
+	return e.Wrapped
+}
+
+// EventStream is the iteration API of a change stream. It is implemented by *ChangeStream and can be used to
+// substitute a test double or another implementation for a change stream in code that consumes events. The methods
+// have the same semantics as the ChangeStream methods with the same names. Because the Current field cannot be part of
+// an interface, the current event can be read as raw BSON by decoding it into a bson.Raw.
+type EventStream interface {
+	// Next blocks until an event is available, an error occurs, or ctx expires, and reports whether an event is
+	// available.
+	Next(ctx context.Context) bool
+
+	// TryNext reports whether an event is available without blocking for new events.
+	TryNext(ctx context.Context) bool
+
+	// Decode unmarshals the current event into val.
+	Decode(val interface{}) error
+
+	// ResumeToken returns the cached resume token, or nil if no resume token has been stored.
+	ResumeToken() bson.Raw
+
+	// Err returns the last error seen by the stream, or nil if no error has occurred.
+	Err() error
+
+	// Close closes the stream.
+	Close(ctx context.Context) error
+
+	// ID returns the ID of the stream's server-side cursor, or 0 if the stream is closed or exhausted.
+	ID() int64
+}
+
+var _ EventStream = (*ChangeStream)(nil)
+
 // ChangeStream is used to iterate over a stream of events. Each event can be decoded into a Go type via the Decode
 // method or accessed as raw BSON via the Current field. This type is not goroutine safe and must not be used
 // concurrently by multiple goroutines. For more information about change streams, see
@@ -72,6 +216,7 @@ type ChangeStream struct {
 
 	aggregate       *operation.Aggregate
 	pipelineSlice   []bsoncore.Document
+	pipeline        bsoncore.Document
 	pipelineOptions map[string]bsoncore.Value
 	cursor          changeStreamCursor
 	cursorOptions   driver.CursorOptions
@@ -87,6 +232,76 @@ type ChangeStream struct {
 	selector        description.ServerSelector
 	operationTime   *primitive.Timestamp
 	wireVersion     *description.VersionRange
+	readPreference  *readpref.ReadPref
+
+	// rawStageOptions holds the options document of a user-supplied $changeStream stage. It is only set for streams
+	// created with WatchRaw.
+	rawStageOptions bsoncore.Document
+
+	eventsSinceCheckpoint int
+	lastCheckpoint        bson.Raw
+	highWaterMark         *primitive.Timestamp
+	lastClusterTime       *primitive.Timestamp
+
+	// serverAddr is the address of the server that ran the most recent aggregate.
+	serverAddr address.Address
+
+	// lastRawError is the server reply of the most recent command run by the change stream that failed.
+	lastRawError bson.Raw
+
+	// spanAttrs holds the attributes set on every span created for the change stream. It is only set if the Client
+	// has a Tracer.
+	spanAttrs []event.SpanAttribute
+
+	// resumeReadPref and resumeSelector are used for the aggregate run when the change stream resumes. They are only
+	// set if the GetMoreReadPreference option is set.
+	resumeReadPref *readpref.ReadPref
+	resumeSelector description.ServerSelector
+
+	// aggregatePending is true if the change stream was created by NewChangeStreamFromRaw and the aggregate that opens
+	// it has not succeeded yet. The aggregate is run by the first call to Next, TryNext, NextBatch, or Resume.
+	aggregatePending bool
+
+	// firstBatchPending is true if the first batch of the cursor created by the most recent aggregate has not been
+	// returned by the cursor yet, in which case the next call to the cursor's Next method does not run a getMore.
+	firstBatchPending bool
+
+	// lastGetMore is the time at which the most recent getMore was started. now returns the current time and can be
+	// replaced in tests.
+	lastGetMore time.Time
+	now         func() time.Time
+
+	// resumeFailed is true if the most recent resume attempt failed, in which case the stored error is the error from
+	// that attempt.
+	resumeFailed bool
+
+	// coalesced holds the events buffered by NextCoalesced that have not been returned yet.
+	coalesced []bson.Raw
+
+	// serverKind and topologyKind are the kinds of the server that ran the most recent aggregate and of the
+	// deployment at that time. onTopologyChange is called if either kind is different after a resume.
+	serverKind       description.ServerKind
+	topologyKind     description.TopologyKind
+	onTopologyChange func(TopologyChange)
+
+	// expiresAt is the time at which the MaxStreamDuration option ends the change stream. It is zero if the option is
+	// not set. lifetimeEnded is true once the change stream has been closed because expiresAt passed.
+	expiresAt     time.Time
+	lifetimeEnded bool
+
+	// createdAt is the time at which the change stream was created. timeToFirstEvent is the time between createdAt
+	// and the return of the first event, and is only valid if firstEventSeen is true.
+	createdAt        time.Time
+	timeToFirstEvent time.Duration
+	firstEventSeen   bool
+
+	// resumePending is true if the stored error is a timeout error from a getMore that was aborted by the client
+	// Timeout. The next call to Next, TryNext, or NextBatch resumes the change stream instead of returning the error
+	// again.
+	resumePending bool
+
+	// getMoreTimedOut is true if the stored error is a timeout error from a getMore that was sent to the server.
+	getMoreTimedOut bool
 }
 
 type changeStreamConfig struct {
@@ -99,6 +314,10 @@ type changeStreamConfig struct {
 	collectionName string
 	databaseName   string
 	crypt          driver.Crypt
+	rawPipeline    bool
+
+	// lazy defers the aggregate that opens the change stream until the change stream is first iterated.
+	lazy bool
 }
 
 func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline interface{},
@@ -107,6 +326,10 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 		ctx = context.Background()
 	}
 
+	if err := validateChangeStreamReadConcern(config.readConcern); err != nil {
+		return nil, err
+	}
+
 	cursorOpts := config.client.createBaseCursorOptions()
 
 	cursorOpts.MarshalValueEncoderFn = newEncoderFn(config.bsonOpts, config.registry)
@@ -121,24 +344,52 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 			description.ReadPrefSelector(config.readPreference),
 			description.LatencySelector(config.client.localThreshold),
 		}),
-		cursorOptions: cursorOpts,
+		cursorOptions:  cursorOpts,
+		readPreference: config.readPreference,
+		now:            time.Now,
 	}
 
 	cs.sess = sessionFromContext(ctx)
 	if cs.sess == nil && cs.client.sessionPool != nil {
 		cs.sess = session.NewImplicitClientSession(cs.client.sessionPool, cs.client.id)
+		if cs.client.watchConsistent {
+			makeCausallyConsistent(cs.sess, cs.client.clock)
+		}
 	}
 	if cs.err = cs.client.validSession(cs.sess); cs.err != nil {
 		closeImplicitSession(cs.sess)
 		return nil, cs.Err()
 	}
 
+	if rp := cs.options.GetMoreReadPreference; rp != nil {
+		if !rp.Mode().IsValid() {
+			closeImplicitSession(cs.sess)
+			return nil, fmt.Errorf("invalid GetMoreReadPreference option: read preference mode %v is not valid", rp.Mode())
+		}
+		cs.resumeReadPref = rp
+		cs.resumeSelector = description.CompositeSelector([]description.ServerSelector{
+			description.ReadPrefSelector(rp),
+			description.LatencySelector(config.client.localThreshold),
+		})
+	}
+	cs.createdAt = cs.now()
+	if d := cs.options.MaxStreamDuration; d != nil {
+		cs.expiresAt = cs.createdAt.Add(*d)
+	}
+
 	cs.aggregate = operation.NewAggregate(nil).
 		ReadPreference(config.readPreference).ReadConcern(config.readConcern).
 		Deployment(cs.client.deployment).ClusterClock(cs.client.clock).
 		CommandMonitor(cs.client.monitor).Session(cs.sess).ServerSelector(cs.selector).Retry(driver.RetryNone).
-		ServerAPI(cs.client.serverAPI).Crypt(config.crypt).Timeout(cs.client.timeout)
+		ServerAPI(cs.client.serverAPI).RequestIDFn(cs.client.requestIDFn).Crypt(config.crypt).Timeout(cs.client.timeout)
 
+	if cs.options.AllowDiskUse != nil {
+		if _, ok := cs.options.Custom["allowDiskUse"]; ok {
+			closeImplicitSession(cs.sess)
+			return nil, errors.New("the AllowDiskUse option cannot be set if allowDiskUse is also set using Custom")
+		}
+		cs.aggregate.AllowDiskUse(*cs.options.AllowDiskUse)
+	}
 	if cs.options.Collation != nil {
 		cs.aggregate.Collation(bsoncore.Document(cs.options.Collation.ToDocument()))
 	}
@@ -151,9 +402,28 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 		}
 		cs.cursorOptions.Comment = commentVal
 	}
-	if cs.options.BatchSize != nil {
-		cs.aggregate.BatchSize(*cs.options.BatchSize)
-		cs.cursorOptions.BatchSize = *cs.options.BatchSize
+	if cs.options.Hint != nil {
+		if isUnorderedMap(cs.options.Hint) {
+			closeImplicitSession(cs.sess)
+			return nil, ErrMapForOrderedArgument{"hint"}
+		}
+		hintVal, err := marshalValue(cs.options.Hint, cs.bsonOpts, cs.registry)
+		if err != nil {
+			closeImplicitSession(cs.sess)
+			return nil, err
+		}
+		cs.aggregate.Hint(hintVal)
+	}
+	if bs := cs.options.BatchSize; bs != nil {
+		if *bs < 0 {
+			closeImplicitSession(cs.sess)
+			return nil, fmt.Errorf("invalid BatchSize option: batch size must not be negative, but was %d", *bs)
+		}
+		// A batch size of 0 means that the server default is used, so batchSize is omitted.
+		if *bs > 0 {
+			cs.aggregate.BatchSize(*bs)
+			cs.cursorOptions.BatchSize = *bs
+		}
 	}
 	if cs.options.MaxAwaitTime != nil {
 		cs.cursorOptions.MaxTimeMS = int64(*cs.options.MaxAwaitTime / time.Millisecond)
@@ -202,6 +472,34 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 		return nil, fmt.Errorf("must supply a valid StreamType in config, instead of %v", cs.streamType)
 	}
 
+	if cs.client.tracer != nil {
+		dbName := config.databaseName
+		if cs.streamType == ClientStream {
+			dbName = "admin"
+		}
+		cs.spanAttrs = []event.SpanAttribute{{Key: event.SpanAttributeDBName, Value: dbName}}
+		if cs.streamType == CollectionStream {
+			cs.spanAttrs = append(cs.spanAttrs,
+				event.SpanAttribute{Key: event.SpanAttributeCollectionName, Value: config.collectionName})
+		}
+	}
+
+	if config.rawPipeline {
+		if cs.err = cs.buildRawPipelineSlice(pipeline); cs.err != nil {
+			closeImplicitSession(cs.sess)
+			return nil, cs.Err()
+		}
+	}
+
+	if cs.err = validateResumeToken("ResumeAfter", cs.options.ResumeAfter); cs.err != nil {
+		closeImplicitSession(cs.sess)
+		return nil, cs.Err()
+	}
+	if cs.err = validateResumeToken("StartAfter", cs.options.StartAfter); cs.err != nil {
+		closeImplicitSession(cs.sess)
+		return nil, cs.Err()
+	}
+
 	// When starting a change stream, cache startAfter as the first resume token if it is set. If not, cache
 	// resumeAfter. If neither is set, do not cache a resume token.
 	resumeToken := cs.options.StartAfter
@@ -217,13 +515,25 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 	}
 	cs.resumeToken = marshaledToken
 
-	if cs.err = cs.buildPipelineSlice(pipeline); cs.err != nil {
-		closeImplicitSession(cs.sess)
-		return nil, cs.Err()
+	if !config.rawPipeline {
+		if cs.err = cs.buildPipelineSlice(pipeline); cs.err != nil {
+			closeImplicitSession(cs.sess)
+			return nil, cs.Err()
+		}
 	}
 	var pipelineArr bsoncore.Document
 	pipelineArr, cs.err = cs.pipelineToBSON()
 	cs.aggregate.Pipeline(pipelineArr)
+	cs.pipeline = pipelineArr
+
+	if config.lazy {
+		if cs.err != nil {
+			closeImplicitSession(cs.sess)
+			return nil, cs.Err()
+		}
+		cs.aggregatePending = true
+		return cs, nil
+	}
 
 	if cs.err = cs.executeOperation(ctx, false); cs.err != nil {
 		closeImplicitSession(cs.sess)
@@ -233,6 +543,95 @@ func newChangeStream(ctx context.Context, config changeStreamConfig, pipeline in
 	return cs, cs.Err()
 }
 
+// NewChangeStreamFromRaw returns a change stream on coll that resumes after resumeToken, which is usually a token
+// persisted from ResumeToken by a previous change stream with the same pipeline. Unlike Collection.Watch, it does not
+// contact the server: the aggregate that opens the change stream is run by the first call to Next, TryNext, NextBatch,
+// or Resume, and any error from it is returned by Err. This avoids a round trip per stream when restoring many change
+// streams at once. Until then, ID returns 0 and Close does not run any commands.
+//
+// The pipeline and opts parameters are the same as for Collection.Watch, except that resumeToken replaces the
+// ResumeAfter, StartAfter, and StartAtOperationTime options and the EnsurePreAndPostImages option is ignored.
+// NewChangeStreamFromRaw returns an error wrapping ErrInvalidResumeToken if resumeToken is not a well-formed
+// document.
+func NewChangeStreamFromRaw(ctx context.Context, coll *Collection, resumeToken bson.Raw, pipeline interface{},
+	opts ...*options.ChangeStreamOptions) (*ChangeStream, error) {
+
+	if len(resumeToken) == 0 {
+		return nil, fmt.Errorf("invalid resume token: %w", ErrInvalidResumeToken)
+	}
+
+	cso := options.MergeChangeStreamOptions(opts...)
+	cso.ResumeAfter = resumeToken
+	cso.StartAfter = nil
+	cso.StartAtOperationTime = nil
+
+	csConfig := changeStreamConfig{
+		readConcern:    coll.readConcern,
+		readPreference: coll.readPreference,
+		client:         coll.client,
+		bsonOpts:       coll.bsonOpts,
+		registry:       coll.registry,
+		streamType:     CollectionStream,
+		collectionName: coll.Name(),
+		databaseName:   coll.db.Name(),
+		crypt:          coll.client.cryptFLE,
+		lazy:           true,
+	}
+	return newChangeStream(ctx, csConfig, pipeline, cso)
+}
+
+// validateChangeStreamReadConcern returns an error if rc has a read concern level that the server never accepts for
+// the aggregate command that opens a change stream. Majority is recommended because it guarantees that events are
+// not rolled back.
+func validateChangeStreamReadConcern(rc *readconcern.ReadConcern) error {
+	if rc == nil {
+		return nil
+	}
+	switch rc.Level {
+	case "available", "linearizable", "snapshot":
+		return fmt.Errorf("read concern level %q is not supported by change streams; use %q or no read concern",
+			rc.Level, "majority")
+	}
+	return nil
+}
+
+// validateResumeToken returns an error wrapping ErrInvalidResumeToken if token is a raw BSON type that is not a
+// well-formed document. Raw tokens are sent to the server without being re-encoded, so a truncated token read from
+// storage would otherwise produce an opaque server error.
+func validateResumeToken(optName string, token interface{}) error {
+	var doc bsoncore.Document
+	switch t := token.(type) {
+	case bson.Raw:
+		doc = bsoncore.Document(t)
+	case bsoncore.Document:
+		doc = t
+	case []byte:
+		doc = bsoncore.Document(t)
+	default:
+		return nil
+	}
+
+	if err := doc.Validate(); err != nil {
+		return fmt.Errorf("invalid %s option: %w: %v", optName, ErrInvalidResumeToken, err)
+	}
+	return nil
+}
+
+// makeCausallyConsistent makes an implicit session causally consistent with the operations previously run by the
+// client by advancing its cluster time and operation time to the latest cluster time seen by the client.
+func makeCausallyConsistent(sess *session.Client, clock *session.ClusterClock) {
+	sess.Consistent = true
+
+	clusterTime := clock.GetClusterTime()
+	if clusterTime == nil {
+		return
+	}
+	_ = sess.AdvanceClusterTime(clusterTime)
+	if t, i, ok := clusterTime.Lookup("$clusterTime", "clusterTime").TimestampOK(); ok {
+		_ = sess.AdvanceOperationTime(&primitive.Timestamp{T: t, I: i})
+	}
+}
+
 func (cs *ChangeStream) createOperationDeployment(server driver.Server, connection driver.Connection) driver.Deployment {
 	return &changeStreamDeployment{
 		topologyKind: cs.client.deployment.Kind(),
@@ -244,8 +643,30 @@ func (cs *ChangeStream) createOperationDeployment(server driver.Server, connecti
 func (cs *ChangeStream) executeOperation(ctx context.Context, resuming bool) error {
 	var server driver.Server
 	var conn driver.Connection
+	resumeErr := cs.err
+
+	if cs.client.tracer != nil {
+		spanName := "aggregate"
+		if resuming {
+			spanName = "resume"
+		}
+		var span event.Span
+		ctx, span = cs.startSpan(ctx, spanName)
+		defer func() {
+			var batch *bsoncore.DocumentSequence
+			if cs.err == nil {
+				batch = cs.cursor.Batch()
+			}
+			endSpan(span, batch, cs.err)
+		}()
+	}
 
-	if server, cs.err = cs.client.deployment.SelectServer(ctx, cs.selector); cs.err != nil {
+	selector := cs.selector
+	if resuming && cs.resumeSelector != nil {
+		selector = cs.resumeSelector
+		cs.aggregate.ReadPreference(cs.resumeReadPref).ServerSelector(selector)
+	}
+	if server, cs.err = cs.client.deployment.SelectServer(ctx, selector); cs.err != nil {
 		return cs.Err()
 	}
 	if conn, cs.err = server.Connection(ctx); cs.err != nil {
@@ -254,6 +675,10 @@ func (cs *ChangeStream) executeOperation(ctx context.Context, resuming bool) err
 	defer conn.Close()
 	cs.wireVersion = conn.Description().WireVersion
 
+	if cs.err = cs.checkSplitLargeEventSupport(); cs.err != nil {
+		return cs.Err()
+	}
+
 	cs.aggregate.Deployment(cs.createOperationDeployment(server, conn))
 
 	if resuming {
@@ -275,6 +700,7 @@ func (cs *ChangeStream) executeOperation(ctx context.Context, resuming bool) err
 			return cs.Err()
 		}
 		cs.aggregate.Pipeline(plArr)
+		cs.pipeline = plArr
 	}
 
 	// If no deadline is set on the passed-in context, cs.client.timeout is set, and context is not already
@@ -317,7 +743,7 @@ AggregateExecuteLoop:
 			// If error is retryable: subtract 1 from retries, redo server selection, checkout
 			// a connection, and restart loop.
 			retries--
-			server, err = cs.client.deployment.SelectServer(ctx, cs.selector)
+			server, err = cs.client.deployment.SelectServer(ctx, selector)
 			if err != nil {
 				break AggregateExecuteLoop
 			}
@@ -341,18 +767,24 @@ AggregateExecuteLoop:
 	}
 	if err != nil {
 		cs.err = replaceErrors(err)
+		cs.recordRawError(cs.err)
 		return cs.err
 	}
 
 	cr := cs.aggregate.ResultCursorResponse()
 	cr.Server = server
+	cs.serverAddr = conn.Description().Addr
+	cs.recordTopology(conn.Description().Kind, resuming, resumeErr)
 
 	cs.cursor, cs.err = driver.NewBatchCursor(cr, cs.sess, cs.client.clock, cs.cursorOptions)
 	if cs.err = replaceErrors(cs.err); cs.err != nil {
 		return cs.Err()
 	}
+	cs.firstBatchPending = true
+	cs.runPostBatchHook()
 
 	cs.updatePbrtFromCommand()
+	cs.updateLastClusterTime()
 	if cs.options.StartAtOperationTime == nil && cs.options.ResumeAfter == nil &&
 		cs.options.StartAfter == nil && cs.wireVersion.Max >= 7 &&
 		cs.emptyBatch() && cs.resumeToken == nil {
@@ -362,6 +794,40 @@ AggregateExecuteLoop:
 	return cs.Err()
 }
 
+// recordTopology stores the kinds of the server that ran the most recent aggregate and of the deployment. If the
+// aggregate resumed the change stream and either kind changed, the OnTopologyChange callback is called.
+func (cs *ChangeStream) recordTopology(serverKind description.ServerKind, resuming bool, resumeErr error) {
+	topologyKind := cs.client.deployment.Kind()
+	change := TopologyChange{
+		PreviousServerKind:   cs.serverKind,
+		CurrentServerKind:    serverKind,
+		PreviousTopologyKind: cs.topologyKind,
+		CurrentTopologyKind:  topologyKind,
+		Err:                  resumeErr,
+	}
+	cs.serverKind = serverKind
+	cs.topologyKind = topologyKind
+
+	if !resuming || cs.onTopologyChange == nil {
+		return
+	}
+	kindChanged := change.PreviousServerKind != change.CurrentServerKind ||
+		change.PreviousTopologyKind != change.CurrentTopologyKind
+	if kindChanged || isTopologyChangeError(resumeErr) {
+		cs.onTopologyChange(change)
+	}
+}
+
+// isTopologyChangeError returns true if err is a server error caused by the server changing role.
+func isTopologyChangeError(err error) bool {
+	var ce CommandError
+	if !errors.As(err, &ce) {
+		return false
+	}
+	_, ok := topologyChangeErrors[ce.Code]
+	return ok
+}
+
 // Updates the post batch resume token after a successful aggregate or getMore operation.
 func (cs *ChangeStream) updatePbrtFromCommand() {
 	// Only cache the pbrt if an empty batch was returned and a pbrt was included
@@ -415,32 +881,133 @@ func (cs *ChangeStream) buildPipelineSlice(pipeline interface{}) error {
 	}
 	cs.pipelineSlice = append(cs.pipelineSlice, csDoc)
 
+	if cs.options.ExcludeSystemNamespaces != nil && *cs.options.ExcludeSystemNamespaces {
+		cs.pipelineSlice = append(cs.pipelineSlice, excludeSystemNamespacesStage())
+	}
+
+	if nsStage, err := namespaceRegexStage(cs.options); err != nil {
+		cs.err = err
+		return cs.err
+	} else if nsStage != nil {
+		cs.pipelineSlice = append(cs.pipelineSlice, nsStage)
+	}
+
+	if collStage, err := singleCollectionStage(cs.streamType, cs.options.SingleCollection); err != nil {
+		cs.err = err
+		return cs.err
+	} else if collStage != nil {
+		cs.pipelineSlice = append(cs.pipelineSlice, collStage)
+	}
+
+	if cs.options.Match != nil {
+		var filter bsoncore.Document
+		if filter, cs.err = marshal(cs.options.Match, cs.bsonOpts, cs.registry); cs.err != nil {
+			return cs.err
+		}
+		if cs.err = validateEnvelopeFilter(filter); cs.err != nil {
+			return cs.err
+		}
+		cs.pipelineSlice = append(cs.pipelineSlice, bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "$match", filter),
+		))
+	}
+
 	for i := 0; i < val.Len(); i++ {
 		var elem []byte
 		elem, cs.err = marshal(val.Index(i).Interface(), cs.bsonOpts, cs.registry)
 		if cs.err != nil {
 			return cs.err
 		}
+		if cs.options.ValidatePipeline != nil && *cs.options.ValidatePipeline {
+			if cs.err = validateStageKeepsID(i, elem); cs.err != nil {
+				return cs.err
+			}
+		}
 
 		cs.pipelineSlice = append(cs.pipelineSlice, elem)
 	}
 
+	if projectStage, err := fullDocumentProjectionStage(cs.options.ProjectFullDocumentFields); err != nil {
+		cs.err = err
+		return cs.err
+	} else if projectStage != nil {
+		cs.pipelineSlice = append(cs.pipelineSlice, projectStage)
+	}
+
 	return cs.err
 }
 
-func (cs *ChangeStream) createPipelineOptionsDoc() (bsoncore.Document, error) {
-	plDocIdx, plDoc := bsoncore.AppendDocumentStart(nil)
+// buildRawPipelineSlice validates a complete pipeline supplied to WatchRaw and uses its stages verbatim. The first
+// stage must be a $changeStream stage. Any resume options in that stage replace the ones from ChangeStreamOptions so
+// the stream caches the correct initial resume token and can rewrite them when resuming.
+func (cs *ChangeStream) buildRawPipelineSlice(pipeline interface{}) error {
+	raw, ok := pipeline.(bson.Raw)
+	if !ok {
+		return fmt.Errorf("raw change stream pipeline must be a bson.Raw, but got %T", pipeline)
+	}
+	if err := bsoncore.Array(raw).Validate(); err != nil {
+		return fmt.Errorf("raw change stream pipeline is not a valid BSON array: %w", err)
+	}
 
-	if cs.streamType == ClientStream {
-		plDoc = bsoncore.AppendBooleanElement(plDoc, "allChangesForCluster", true)
+	vals, err := bsoncore.Array(raw).Values()
+	if err != nil {
+		return err
+	}
+	if len(vals) == 0 {
+		return errors.New("raw change stream pipeline must contain a $changeStream stage")
 	}
 
-	if cs.options.FullDocument != nil && *cs.options.FullDocument != options.Default {
-		plDoc = bsoncore.AppendStringElement(plDoc, "fullDocument", string(*cs.options.FullDocument))
+	cs.pipelineSlice = make([]bsoncore.Document, 0, len(vals))
+	for i, val := range vals {
+		stage, ok := val.DocumentOK()
+		if !ok {
+			return fmt.Errorf("raw change stream pipeline stage %d must be a document, but was %s", i, val.Type)
+		}
+		cs.pipelineSlice = append(cs.pipelineSlice, stage)
 	}
 
-	if cs.options.FullDocumentBeforeChange != nil {
-		plDoc = bsoncore.AppendStringElement(plDoc, "fullDocumentBeforeChange", string(*cs.options.FullDocumentBeforeChange))
+	first, err := cs.pipelineSlice[0].IndexErr(0)
+	if err != nil || first.Key() != "$changeStream" {
+		return errors.New("the first stage of a raw change stream pipeline must be $changeStream")
+	}
+	stageOpts, ok := first.Value().DocumentOK()
+	if !ok {
+		return fmt.Errorf("the $changeStream stage must be a document, but was %s", first.Value().Type)
+	}
+	cs.rawStageOptions = stageOpts
+
+	cs.options.SetResumeAfter(nil)
+	cs.options.SetStartAfter(nil)
+	cs.options.SetStartAtOperationTime(nil)
+	if ra, ok := stageOpts.Lookup("resumeAfter").DocumentOK(); ok {
+		cs.options.SetResumeAfter(bson.Raw(ra))
+	}
+	if sa, ok := stageOpts.Lookup("startAfter").DocumentOK(); ok {
+		cs.options.SetStartAfter(bson.Raw(sa))
+	}
+	if t, i, ok := stageOpts.Lookup("startAtOperationTime").TimestampOK(); ok {
+		cs.options.SetStartAtOperationTime(&primitive.Timestamp{T: t, I: i})
+	}
+
+	return nil
+}
+
+// createRawPipelineOptionsDoc rebuilds the options of a user-supplied $changeStream stage for a resume attempt. All
+// options are copied from the original stage except the resume options, which are replaced by the ones chosen by
+// replaceOptions.
+func (cs *ChangeStream) createRawPipelineOptionsDoc() (bsoncore.Document, error) {
+	elems, err := cs.rawStageOptions.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	plDocIdx, plDoc := bsoncore.AppendDocumentStart(nil)
+	for _, elem := range elems {
+		switch elem.Key() {
+		case "resumeAfter", "startAfter", "startAtOperationTime":
+			continue
+		}
+		plDoc = append(plDoc, elem...)
 	}
 
 	if cs.options.ResumeAfter != nil {
@@ -453,10 +1020,6 @@ func (cs *ChangeStream) createPipelineOptionsDoc() (bsoncore.Document, error) {
 		plDoc = bsoncore.AppendDocumentElement(plDoc, "resumeAfter", raDoc)
 	}
 
-	if cs.options.ShowExpandedEvents != nil {
-		plDoc = bsoncore.AppendBooleanElement(plDoc, "showExpandedEvents", *cs.options.ShowExpandedEvents)
-	}
-
 	if cs.options.StartAfter != nil {
 		var saDoc bsoncore.Document
 		saDoc, cs.err = marshal(cs.options.StartAfter, cs.bsonOpts, cs.registry)
@@ -471,11 +1034,6 @@ func (cs *ChangeStream) createPipelineOptionsDoc() (bsoncore.Document, error) {
 		plDoc = bsoncore.AppendTimestampElement(plDoc, "startAtOperationTime", cs.options.StartAtOperationTime.T, cs.options.StartAtOperationTime.I)
 	}
 
-	// Append custom pipeline options.
-	for optionName, optionValue := range cs.pipelineOptions {
-		plDoc = bsoncore.AppendValueElement(plDoc, optionName, optionValue)
-	}
-
 	if plDoc, cs.err = bsoncore.AppendDocumentEnd(plDoc, plDocIdx); cs.err != nil {
 		return nil, cs.err
 	}
@@ -483,70 +1041,544 @@ func (cs *ChangeStream) createPipelineOptionsDoc() (bsoncore.Document, error) {
 	return plDoc, nil
 }
 
-func (cs *ChangeStream) pipelineToBSON() (bsoncore.Document, error) {
-	pipelineDocIdx, pipelineArr := bsoncore.AppendArrayStart(nil)
-	for i, doc := range cs.pipelineSlice {
-		pipelineArr = bsoncore.AppendDocumentElement(pipelineArr, strconv.Itoa(i), doc)
+// checkSplitLargeEventSupport returns an error if the user pipeline contains a $changeStreamSplitLargeEvent stage but
+// the selected server is too old to support it. Older servers reject the stage with an unhelpful pipeline error, so
+// this check is a best effort to tell users which server version is required. The stage was backported to 6.0.9, but
+// patch releases share a wire version, so servers from 6.0.0 to 6.0.8 are not detected and return the server error.
+func (cs *ChangeStream) checkSplitLargeEventSupport() error {
+	if cs.wireVersion == nil || cs.wireVersion.Max >= minSplitLargeEventWireVersion {
+		return nil
 	}
-	if pipelineArr, cs.err = bsoncore.AppendArrayEnd(pipelineArr, pipelineDocIdx); cs.err != nil {
-		return nil, cs.err
+
+	for _, stage := range cs.pipelineSlice {
+		elem, err := stage.IndexErr(0)
+		if err != nil {
+			continue
+		}
+		if elem.Key() == "$changeStreamSplitLargeEvent" {
+			return fmt.Errorf("the $changeStreamSplitLargeEvent stage requires a minimum server version of 6.0.9 "+
+				"(maxWireVersion %d), but the selected server has maxWireVersion %d",
+				minSplitLargeEventWireVersion, cs.wireVersion.Max)
+		}
 	}
-	return pipelineArr, cs.err
+	return nil
 }
 
-func (cs *ChangeStream) replaceOptions(wireVersion *description.VersionRange) {
-	// Cached resume token: use the resume token as the resumeAfter option and set no other resume options
-	if cs.resumeToken != nil {
-		cs.options.SetResumeAfter(cs.resumeToken)
-		cs.options.SetStartAfter(nil)
-		cs.options.SetStartAtOperationTime(nil)
-		return
-	}
+// excludeSystemNamespacesStage returns a $match stage that filters out events for the admin, config, and local
+// databases and for collections whose names start with "system.".
+func excludeSystemNamespacesStage() bsoncore.Document {
+	systemDBs := bsoncore.BuildArray(nil,
+		bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, "admin")},
+		bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, "config")},
+		bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, "local")},
+	)
+
+	return bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDocumentElement(nil, "$match", bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "ns.db", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendArrayElement(nil, "$nin", systemDBs),
+			)),
+			bsoncore.AppendDocumentElement(nil, "ns.coll", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendRegexElement(nil, "$not", `^system\.`, ""),
+			)),
+		)),
+	)
+}
 
-	// No cached resume token but cached operation time: use the operation time as the startAtOperationTime option and
-	// set no other resume options
-	if (cs.sess.OperationTime != nil || cs.options.StartAtOperationTime != nil) && wireVersion.Max >= 7 {
-		opTime := cs.options.StartAtOperationTime
-		if cs.operationTime != nil {
-			opTime = cs.sess.OperationTime
+// namespaceRegexStage returns a $match stage that filters events by the NamespaceDBRegex and NamespaceCollRegex
+// options, or nil if neither is set to a non-empty pattern. It returns an error if either pattern does not compile.
+func namespaceRegexStage(opts *options.ChangeStreamOptions) (bsoncore.Document, error) {
+	var conds []bsoncore.Value
+	for _, ns := range []struct {
+		field   string
+		pattern *string
+	}{
+		{"$ns.db", opts.NamespaceDBRegex},
+		{"$ns.coll", opts.NamespaceCollRegex},
+	} {
+		if ns.pattern == nil || *ns.pattern == "" {
+			continue
+		}
+		if _, err := regexp.Compile(*ns.pattern); err != nil {
+			return nil, fmt.Errorf("invalid namespace regex %q: %w", *ns.pattern, err)
 		}
 
-		cs.options.SetStartAtOperationTime(opTime)
-		cs.options.SetResumeAfter(nil)
-		cs.options.SetStartAfter(nil)
-		return
+		regexMatch := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "$regexMatch", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendStringElement(nil, "input", ns.field),
+				bsoncore.AppendStringElement(nil, "regex", *ns.pattern),
+			)),
+		)
+		conds = append(conds, bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: regexMatch})
+	}
+	if len(conds) == 0 {
+		return nil, nil
 	}
 
-	// No cached resume token or operation time: set none of the resume options
-	cs.options.SetResumeAfter(nil)
-	cs.options.SetStartAfter(nil)
-	cs.options.SetStartAtOperationTime(nil)
+	return bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDocumentElement(nil, "$match", bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "$expr", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendArrayElement(nil, "$and", bsoncore.BuildArray(nil, conds...)),
+			)),
+		)),
+	), nil
 }
 
-// ID returns the ID for this change stream, or 0 if the cursor has been closed or exhausted.
-func (cs *ChangeStream) ID() int64 {
-	if cs.cursor == nil {
-		return 0
+// singleCollectionStage returns a $match stage that filters the events of a database change stream to those for the
+// named collection and those without a collection name, or nil if name is nil. It returns an error if name is empty or
+// the change stream is not a database change stream.
+func singleCollectionStage(streamType StreamType, name *string) (bsoncore.Document, error) {
+	if name == nil {
+		return nil, nil
 	}
-	return cs.cursor.ID()
+	if streamType != DatabaseStream {
+		return nil, errors.New("the SingleCollection option can only be used with Database.Watch")
+	}
+	if *name == "" {
+		return nil, errors.New("the SingleCollection option must be a non-empty collection name")
+	}
+
+	return bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDocumentElement(nil, "$match", bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendArrayElement(nil, "$or", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendDocumentElement(nil, "0", bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendStringElement(nil, "ns.coll", *name),
+				)),
+				bsoncore.AppendDocumentElement(nil, "1", bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendDocumentElement(nil, "ns.coll", bsoncore.BuildDocumentFromElements(nil,
+						bsoncore.AppendBooleanElement(nil, "$exists", false),
+					)),
+				)),
+			)),
+		)),
+	), nil
 }
 
-// Decode will unmarshal the current event document into val and return any errors from the unmarshalling process
-// without any modification. If val is nil or is a typed nil, an error will be returned.
-func (cs *ChangeStream) Decode(val interface{}) error {
-	if cs.cursor == nil {
-		return ErrNilCursor
-	}
+// changeEventEnvelopeFields are the top-level fields of a change event that the Match option may filter on. The _id,
+// fullDocument, and fullDocumentBeforeChange fields are deliberately excluded.
+var changeEventEnvelopeFields = map[string]bool{
+	"operationType":        true,
+	"ns":                   true,
+	"to":                   true,
+	"documentKey":          true,
+	"updateDescription":    true,
+	"clusterTime":          true,
+	"wallTime":             true,
+	"txnNumber":            true,
+	"lsid":                 true,
+	"collectionUUID":       true,
+	"operationDescription": true,
+}
 
-	dec, err := getDecoder(cs.Current, cs.bsonOpts, cs.registry)
+// validateEnvelopeFilter returns an error if the Match filter references a field that is not a change event envelope
+// field or uses a top-level operator other than $and, $or, and $nor.
+func validateEnvelopeFilter(filter bsoncore.Document) error {
+	elems, err := filter.Elements()
 	if err != nil {
-		return fmt.Errorf("error configuring BSON decoder: %w", err)
+		return err
 	}
-	return dec.Decode(val)
-}
 
-// Err returns the last error seen by the change stream, or nil if no errors has occurred.
-func (cs *ChangeStream) Err() error {
+	for _, elem := range elems {
+		key := elem.Key()
+		switch key {
+		case "$and", "$or", "$nor":
+			arr, ok := elem.Value().ArrayOK()
+			if !ok {
+				return fmt.Errorf("invalid Match filter: %s must be an array, but was %s", key, elem.Value().Type)
+			}
+			vals, err := arr.Values()
+			if err != nil {
+				return err
+			}
+			for _, val := range vals {
+				doc, ok := val.DocumentOK()
+				if !ok {
+					return fmt.Errorf("invalid Match filter: %s elements must be documents, but found %s", key, val.Type)
+				}
+				if err := validateEnvelopeFilter(doc); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if strings.HasPrefix(key, "$") {
+			return fmt.Errorf("invalid Match filter: operator %q is not supported", key)
+		}
+		if field := strings.SplitN(key, ".", 2)[0]; !changeEventEnvelopeFields[field] {
+			return fmt.Errorf("invalid Match filter: %q is not a change event envelope field", key)
+		}
+	}
+	return nil
+}
+
+// validateStageKeepsID returns an error wrapping ErrMissingResumeToken if the user pipeline stage at index i removes or
+// modifies the _id field of change events, which holds the resume token. Only the common cases are detected: $project
+// and $unset stages that remove _id, $project, $addFields, and $set stages that overwrite it, and $replaceRoot and
+// $replaceWith stages whose new root is a field path other than $$ROOT, a document without `_id: "$_id"`, or a
+// $mergeObjects expression whose result does not keep _id. Other expressions are accepted.
+func validateStageKeepsID(i int, stage bsoncore.Document) error {
+	elem, err := stage.IndexErr(0)
+	if err != nil {
+		return nil
+	}
+
+	var ok bool
+	switch name, spec := elem.Key(), elem.Value(); name {
+	case "$project":
+		ok = projectKeepsID(spec)
+	case "$unset":
+		fields := []bsoncore.Value{spec}
+		if arr, isArr := spec.ArrayOK(); isArr {
+			fields, _ = arr.Values()
+		}
+		ok = true
+		for _, field := range fields {
+			// Non-string entries are invalid $unset specs, which the server rejects.
+			if str, isStr := field.StringValueOK(); isStr && isIDPath(str) {
+				ok = false
+			}
+		}
+	case "$addFields", "$set":
+		ok = !setsID(spec)
+	case "$replaceRoot":
+		doc, _ := spec.DocumentOK()
+		ok = rootKeepsID(doc.Lookup("newRoot"))
+	case "$replaceWith":
+		ok = rootKeepsID(spec)
+	default:
+		return nil
+	}
+	if ok {
+		return nil
+	}
+	return fmt.Errorf("pipeline stage %d (%s) removes or modifies the change event _id: %w", i, elem.Key(),
+		ErrMissingResumeToken)
+}
+
+// isIDPath returns true if path is _id or a field inside it.
+func isIDPath(path string) bool {
+	return path == "_id" || strings.HasPrefix(path, "_id.")
+}
+
+// projectKeepsID returns true if the $project specification spec keeps the _id field unchanged.
+func projectKeepsID(spec bsoncore.Value) bool {
+	doc, ok := spec.DocumentOK()
+	if !ok {
+		return true
+	}
+	elems, _ := doc.Elements()
+	for _, elem := range elems {
+		if !isIDPath(elem.Key()) {
+			continue
+		}
+		switch val := elem.Value(); val.Type {
+		case bsontype.Boolean:
+			if !val.Boolean() {
+				return false
+			}
+		case bsontype.Int32, bsontype.Int64, bsontype.Double, bsontype.Decimal128:
+			if i, ok := val.AsInt64OK(); ok && i == 0 {
+				return false
+			}
+		default:
+			// Any other value is an expression that replaces the field.
+			return false
+		}
+	}
+	return true
+}
+
+// setsID returns true if the $addFields or $set specification spec assigns the _id field.
+func setsID(spec bsoncore.Value) bool {
+	doc, ok := spec.DocumentOK()
+	if !ok {
+		return false
+	}
+	elems, _ := doc.Elements()
+	for _, elem := range elems {
+		if isIDPath(elem.Key()) {
+			return true
+		}
+	}
+	return false
+}
+
+// rootKeepsID returns true if the new root of a $replaceRoot or $replaceWith stage may keep the _id field.
+func rootKeepsID(root bsoncore.Value) bool {
+	switch root.Type {
+	case bsontype.String:
+		return root.StringValue() == "$$ROOT"
+	case bsontype.EmbeddedDocument:
+		doc := root.Document()
+		first, err := doc.IndexErr(0)
+		switch {
+		case err != nil || !strings.HasPrefix(first.Key(), "$"):
+			id, ok := doc.Lookup("_id").StringValueOK()
+			return ok && id == "$_id"
+		case first.Key() != "$mergeObjects":
+			return true
+		}
+
+		args := []bsoncore.Value{first.Value()}
+		if arr, ok := first.Value().ArrayOK(); ok {
+			args, _ = arr.Values()
+		}
+		// Later arguments override the fields of earlier ones, so _id is kept if the last argument that may set it
+		// keeps it. Field paths other than $$ROOT, such as $fullDocument, usually refer to documents with their own
+		// _id.
+		keeps := false
+		for _, arg := range args {
+			switch arg.Type {
+			case bsontype.String:
+				keeps = arg.StringValue() == "$$ROOT"
+			case bsontype.EmbeddedDocument:
+				if id, err := arg.Document().LookupErr("_id"); err == nil {
+					str, ok := id.StringValueOK()
+					keeps = ok && str == "$_id"
+				}
+			}
+		}
+		return keeps
+	default:
+		return false
+	}
+}
+
+// fullDocumentProjectionStage returns an $addFields stage that replaces the fullDocument field of each event with a
+// document containing only its _id and the given top-level fields, or nil if fields is nil. Only fullDocument is
+// rewritten so the event _id, which is the resume token, is preserved. Events whose fullDocument is missing or is not
+// a document are left unchanged.
+func fullDocumentProjectionStage(fields []string) (bsoncore.Document, error) {
+	if fields == nil {
+		return nil, nil
+	}
+
+	keys := []bsoncore.Value{{Type: bsontype.String, Data: bsoncore.AppendString(nil, "_id")}}
+	for _, field := range fields {
+		if field == "" || strings.Contains(field, ".") || strings.HasPrefix(field, "$") {
+			return nil, fmt.Errorf("invalid fullDocument projection field %q: only top-level field names are supported",
+				field)
+		}
+		if field == "_id" {
+			continue
+		}
+		keys = append(keys, bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, field)})
+	}
+
+	isDocument := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendArrayElement(nil, "$eq", bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "0", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendStringElement(nil, "$type", "$fullDocument"),
+			)),
+			bsoncore.AppendStringElement(nil, "1", "object"),
+		)),
+	)
+	projected := bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDocumentElement(nil, "$arrayToObject", bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "$filter", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendDocumentElement(nil, "input", bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendStringElement(nil, "$objectToArray", "$fullDocument"),
+				)),
+				bsoncore.AppendDocumentElement(nil, "cond", bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendArrayElement(nil, "$in", bsoncore.BuildDocumentFromElements(nil,
+						bsoncore.AppendStringElement(nil, "0", "$$this.k"),
+						bsoncore.AppendArrayElement(nil, "1", bsoncore.BuildArray(nil, keys...)),
+					)),
+				)),
+			)),
+		)),
+	)
+
+	return bsoncore.BuildDocumentFromElements(nil,
+		bsoncore.AppendDocumentElement(nil, "$addFields", bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendDocumentElement(nil, "fullDocument", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendDocumentElement(nil, "$cond", bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendDocumentElement(nil, "if", isDocument),
+					bsoncore.AppendDocumentElement(nil, "then", projected),
+					bsoncore.AppendStringElement(nil, "else", "$fullDocument"),
+				)),
+			)),
+		)),
+	), nil
+}
+
+func (cs *ChangeStream) createPipelineOptionsDoc() (bsoncore.Document, error) {
+	if cs.rawStageOptions != nil {
+		return cs.createRawPipelineOptionsDoc()
+	}
+
+	plDocIdx, plDoc := bsoncore.AppendDocumentStart(nil)
+
+	if acfc := cs.options.AllChangesForCluster; acfc != nil {
+		switch {
+		case cs.streamType != ClientStream:
+			cs.err = errors.New("the AllChangesForCluster option can only be used with Client.Watch")
+			return nil, cs.err
+		case !*acfc:
+			cs.err = errors.New("the AllChangesForCluster option must be true for change streams created with " +
+				"Client.Watch")
+			return nil, cs.err
+		}
+	}
+	if cs.streamType == ClientStream {
+		plDoc = bsoncore.AppendBooleanElement(plDoc, "allChangesForCluster", true)
+	}
+
+	if cs.options.FullDocument != nil && *cs.options.FullDocument != options.Default {
+		plDoc = bsoncore.AppendStringElement(plDoc, "fullDocument", string(*cs.options.FullDocument))
+	}
+
+	if cs.options.FullDocumentBeforeChange != nil {
+		plDoc = bsoncore.AppendStringElement(plDoc, "fullDocumentBeforeChange", string(*cs.options.FullDocumentBeforeChange))
+	}
+
+	if cs.options.ResumeAfter != nil {
+		var raDoc bsoncore.Document
+		raDoc, cs.err = marshal(cs.options.ResumeAfter, cs.bsonOpts, cs.registry)
+		if cs.err != nil {
+			return nil, cs.err
+		}
+
+		plDoc = bsoncore.AppendDocumentElement(plDoc, "resumeAfter", raDoc)
+	}
+
+	if cs.options.ShowExpandedEvents != nil {
+		plDoc = bsoncore.AppendBooleanElement(plDoc, "showExpandedEvents", *cs.options.ShowExpandedEvents)
+	}
+
+	if cs.options.StartAfter != nil {
+		var saDoc bsoncore.Document
+		saDoc, cs.err = marshal(cs.options.StartAfter, cs.bsonOpts, cs.registry)
+		if cs.err != nil {
+			return nil, cs.err
+		}
+
+		plDoc = bsoncore.AppendDocumentElement(plDoc, "startAfter", saDoc)
+	}
+
+	if cs.options.StartAtOperationTime != nil {
+		plDoc = bsoncore.AppendTimestampElement(plDoc, "startAtOperationTime", cs.options.StartAtOperationTime.T, cs.options.StartAtOperationTime.I)
+	}
+
+	// Append custom pipeline options.
+	for optionName, optionValue := range cs.pipelineOptions {
+		plDoc = bsoncore.AppendValueElement(plDoc, optionName, optionValue)
+	}
+
+	if plDoc, cs.err = bsoncore.AppendDocumentEnd(plDoc, plDocIdx); cs.err != nil {
+		return nil, cs.err
+	}
+
+	return plDoc, nil
+}
+
+func (cs *ChangeStream) pipelineToBSON() (bsoncore.Document, error) {
+	pipelineDocIdx, pipelineArr := bsoncore.AppendArrayStart(nil)
+	for i, doc := range cs.pipelineSlice {
+		pipelineArr = bsoncore.AppendDocumentElement(pipelineArr, strconv.Itoa(i), doc)
+	}
+	if pipelineArr, cs.err = bsoncore.AppendArrayEnd(pipelineArr, pipelineDocIdx); cs.err != nil {
+		return nil, cs.err
+	}
+	return pipelineArr, cs.err
+}
+
+func (cs *ChangeStream) replaceOptions(wireVersion *description.VersionRange) {
+	// Cached resume token: use the resume token as the resumeAfter option and set no other resume options
+	if cs.resumeToken != nil {
+		cs.options.SetResumeAfter(cs.resumeToken)
+		cs.options.SetStartAfter(nil)
+		cs.options.SetStartAtOperationTime(nil)
+		return
+	}
+
+	// No cached resume token but cached operation time: use the operation time as the startAtOperationTime option and
+	// set no other resume options
+	if (cs.sess.OperationTime != nil || cs.options.StartAtOperationTime != nil) && wireVersion.Max >= 7 {
+		opTime := cs.options.StartAtOperationTime
+		if cs.operationTime != nil {
+			opTime = cs.sess.OperationTime
+		}
+
+		cs.options.SetStartAtOperationTime(opTime)
+		cs.options.SetResumeAfter(nil)
+		cs.options.SetStartAfter(nil)
+		return
+	}
+
+	// No cached resume token or operation time: set none of the resume options
+	cs.options.SetResumeAfter(nil)
+	cs.options.SetStartAfter(nil)
+	cs.options.SetStartAtOperationTime(nil)
+}
+
+// ID returns the ID for this change stream, or 0 if the cursor has been closed or exhausted.
+func (cs *ChangeStream) ID() int64 {
+	if cs.cursor == nil {
+		return 0
+	}
+	return cs.cursor.ID()
+}
+
+// Decode will unmarshal the current event document into val and return any errors from the unmarshalling process
+// without any modification. If val is nil or is a typed nil, an error will be returned.
+func (cs *ChangeStream) Decode(val interface{}) error {
+	if cs.cursor == nil {
+		return ErrNilCursor
+	}
+
+	dec, err := getDecoder(cs.Current, cs.bsonOpts, cs.registry)
+	if err != nil {
+		return fmt.Errorf("error configuring BSON decoder: %w", err)
+	}
+	return dec.Decode(val)
+}
+
+// DecodeEvent will unmarshal the current event document into ev, which is reset before decoding. It uses the same
+// BSON options and registry as Decode.
+func (cs *ChangeStream) DecodeEvent(ev *ChangeEvent) error {
+	if ev == nil {
+		return ErrNilDocument
+	}
+
+	*ev = ChangeEvent{}
+	return cs.Decode(ev)
+}
+
+// DocumentKey returns the documentKey field of the current event, or nil if the current event does not have one. For
+// sharded collections, the documentKey contains the shard key fields in addition to _id, so it identifies the changed
+// document even if _id is not unique across shards. Like Current, the returned document is only valid until the next
+// call to Next or TryNext.
+func (cs *ChangeStream) DocumentKey() bson.Raw {
+	documentKey, ok := cs.Current.Lookup("documentKey").DocumentOK()
+	if !ok {
+		return nil
+	}
+	return documentKey
+}
+
+// DecodeDocumentKey will unmarshal the documentKey field of the current event into val, which is typically a struct
+// with fields for _id and the shard key. It uses the same BSON options and registry as Decode. If the current event
+// does not have a documentKey, ErrMissingDocumentKey is returned.
+func (cs *ChangeStream) DecodeDocumentKey(val interface{}) error {
+	if cs.cursor == nil {
+		return ErrNilCursor
+	}
+
+	documentKey := cs.DocumentKey()
+	if documentKey == nil {
+		return ErrMissingDocumentKey
+	}
+	dec, err := getDecoder(documentKey, cs.bsonOpts, cs.registry)
+	if err != nil {
+		return fmt.Errorf("error configuring BSON decoder: %w", err)
+	}
+	return dec.Decode(val)
+}
+
+// Err returns the last error seen by the change stream, or nil if no errors has occurred.
+func (cs *ChangeStream) Err() error {
 	if cs.err != nil {
 		return replaceErrors(cs.err)
 	}
@@ -554,102 +1586,907 @@ func (cs *ChangeStream) Err() error {
 		return nil
 	}
 
-	return replaceErrors(cs.cursor.Err())
+	return replaceErrors(cs.cursor.Err())
+}
+
+// ErrDetails returns the error returned by Err wrapped in a ChangeStreamError that records the cursor ID, the cached
+// resume token, and whether the error came from a resume attempt, or nil if no error has occurred.
+func (cs *ChangeStream) ErrDetails() error {
+	err := cs.Err()
+	if err == nil {
+		return nil
+	}
+
+	var token bson.Raw
+	if cs.resumeToken != nil {
+		token = append(bson.Raw{}, cs.resumeToken...)
+	}
+	return ChangeStreamError{
+		StreamID:        cs.ID(),
+		ResumeToken:     token,
+		ResumeAttempted: cs.resumeFailed,
+		Wrapped:         err,
+	}
+}
+
+// LastRawError returns the raw server reply of the most recent aggregate, getMore, or killCursors command run by the
+// change stream that failed with a server error, or nil if no command has failed. This includes errors that the
+// change stream resumed from automatically, so the reply is available even if Err returns nil. The reply may contain
+// fields that are not exposed by the returned error, which can be useful for debugging. The returned document is a
+// copy and may be retained.
+func (cs *ChangeStream) LastRawError() bson.Raw {
+	return cs.lastRawError
+}
+
+// recordRawError stores the server reply of err for LastRawError if err is a server error.
+func (cs *ChangeStream) recordRawError(err error) {
+	var ce CommandError
+	if errors.As(err, &ce) && ce.Raw != nil {
+		cs.lastRawError = append(bson.Raw(nil), ce.Raw...)
+	}
+}
+
+// Close closes this change stream and the underlying cursor. Next and TryNext must not be called after Close has been
+// called. Close is idempotent. After the first call, any subsequent calls will not change the state. If the CloseTimeout
+// option was set, the server-side cursor is killed even if ctx is already done.
+func (cs *ChangeStream) Close(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	defer closeImplicitSession(cs.sess)
+
+	cs.aggregatePending = false
+	if cs.cursor == nil {
+		return nil // cursor is already closed
+	}
+
+	var checkpointErr error
+	if cs.options.CheckpointFunc != nil && cs.resumeToken != nil && !bytes.Equal(cs.lastCheckpoint, cs.resumeToken) {
+		checkpointErr = cs.checkpoint()
+	}
+
+	if cs.options.CloseTimeout != nil {
+		// Bound the killCursors command by CloseTimeout. If ctx is already done, use a fresh context so the
+		// server-side cursor can still be killed.
+		parent := ctx
+		if parent.Err() != nil {
+			parent = context.Background()
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(parent, *cs.options.CloseTimeout)
+		defer cancel()
+	}
+
+	cs.err = replaceErrors(cs.cursor.Close(ctx))
+	cs.recordRawError(cs.err)
+	cs.cursor = nil
+	if cs.err == nil {
+		cs.err = checkpointErr
+	}
+	return cs.Err()
+}
+
+// Resume runs a new aggregate to re-create the change stream's server-side cursor, resuming from the cached resume
+// token (see ResumeToken). It can be used to continue a change stream after Next or TryNext returned false because of
+// an error that the change stream did not automatically resume from, such as an error from the resume attempt itself.
+// Any events remaining in the current batch are discarded and will be returned again by the new cursor.
+//
+// If the aggregate succeeds, the stored error is reset and Err returns nil until a new error occurs. If it fails, the
+// new error is stored and returned. Resume returns ErrNilCursor if the change stream has been closed.
+func (cs *ChangeStream) Resume(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if cs.aggregatePending {
+		if err := cs.executeOperation(ctx, false); err != nil {
+			cs.err = replaceErrors(err)
+			return cs.err
+		}
+		cs.aggregatePending = false
+		cs.err = nil
+		return nil
+	}
+
+	if cs.cursor == nil {
+		return ErrNilCursor
+	}
+
+	// Ignore the error from closing the cursor because it may have already been closed or killed by the server.
+	_ = cs.cursor.Close(ctx)
+	cs.batch = nil
+	cs.err = nil
+	cs.resumePending = false
+	cs.getMoreTimedOut = false
+	err := cs.executeOperation(ctx, true)
+	cs.resumeFailed = err != nil
+	if err != nil {
+		cs.err = replaceErrors(err)
+		return cs.err
+	}
+	return nil
+}
+
+// checkpointIfDue calls the checkpoint function if CheckpointInterval events have been returned since the last
+// checkpoint.
+func (cs *ChangeStream) checkpointIfDue() error {
+	if cs.options == nil || cs.options.CheckpointFunc == nil || cs.options.CheckpointInterval == nil {
+		return nil
+	}
+	if cs.eventsSinceCheckpoint < *cs.options.CheckpointInterval || cs.resumeToken == nil {
+		return nil
+	}
+	return cs.checkpoint()
+}
+
+// checkpoint calls the checkpoint function with a copy of the current resume token.
+func (cs *ChangeStream) checkpoint() error {
+	token := make(bson.Raw, len(cs.resumeToken))
+	copy(token, cs.resumeToken)
+
+	cs.eventsSinceCheckpoint = 0
+	cs.lastCheckpoint = token
+	return cs.options.CheckpointFunc(token)
+}
+
+// CurrentServerAddress returns the address of the server that the change stream is currently reading from, or an
+// empty string if the change stream has not been started. When the change stream resumes after an error, a new server
+// is selected using the read preference and localThresholdMS latency window of the collection, database, or client
+// that created the change stream, so the returned address may change after a resume.
+func (cs *ChangeStream) CurrentServerAddress() string {
+	return cs.serverAddr.String()
+}
+
+// OnTopologyChange registers a callback that is called when the change stream resumes against a different kind of
+// server or deployment than the one it was reading from, such as after a standalone is restarted as a replica set
+// member or a replica set member changes role. It is also called when the change stream resumes after an error caused
+// by a server changing role, such as a NotPrimary or StaleConfig error, even if the kinds did not change. The callback
+// is called synchronously by Next, TryNext, NextBatch, or Resume after the new aggregate succeeds. Passing nil removes
+// the callback.
+//
+// The callback is only for observability: whether an error is resumed follows the usual rules and does not depend on
+// whether a callback is registered.
+func (cs *ChangeStream) OnTopologyChange(fn func(TopologyChange)) {
+	cs.onTopologyChange = fn
+}
+
+// ResumeToken returns the last cached resume token for this change stream, or nil if a resume token has not been
+// stored.
+func (cs *ChangeStream) ResumeToken() bson.Raw {
+	return cs.resumeToken
+}
+
+// HighWaterMark returns an approximation of the cluster time up to which this change stream has observed events, or
+// nil if it is not known yet. The value is the cluster time encoded in the cached resume token (see ResumeToken),
+// which is the postBatchResumeToken after a batch has been fully iterated. If the token's cluster time cannot be
+// decoded, the operationTime of the most recent command run by the change stream's session is used instead.
+//
+// For a sharded cluster, the postBatchResumeToken reflects the point up to which events from all shards have been
+// merged, so events with an earlier cluster time are not expected to be returned afterwards. This is an
+// approximation: the resume token format is not part of the public server API, events from a single transaction
+// share a cluster time, and the operationTime fallback may be later than the last event returned. The returned value
+// never decreases over the life of the change stream.
+func (cs *ChangeStream) HighWaterMark() *primitive.Timestamp {
+	ts := resumeTokenClusterTime(cs.resumeToken)
+	if ts == nil && cs.sess != nil && cs.sess.OperationTime != nil {
+		opTime := *cs.sess.OperationTime
+		ts = &opTime
+	}
+	if ts != nil && (cs.highWaterMark == nil || cs.highWaterMark.Before(*ts)) {
+		cs.highWaterMark = ts
+	}
+	if cs.highWaterMark == nil {
+		return nil
+	}
+	hwm := *cs.highWaterMark
+	return &hwm
+}
+
+// LastClusterTime returns the cluster time that the server reported for the most recent aggregate or getMore run by
+// this change stream, or nil if it is not known. The value is decoded from the postBatchResumeToken of the response,
+// which the server advances even when the batch is empty, or the operationTime of the response if the
+// postBatchResumeToken is absent or cannot be decoded. This allows an idle consumer that has not received an event to
+// persist how far the change stream has progressed.
+//
+// Unlike HighWaterMark, which is based on the cached resume token, LastClusterTime is updated by every response,
+// including responses with events that have not been returned by Next or TryNext yet. The returned value never
+// decreases over the life of the change stream.
+func (cs *ChangeStream) LastClusterTime() *primitive.Timestamp {
+	if cs.lastClusterTime == nil {
+		return nil
+	}
+	lct := *cs.lastClusterTime
+	return &lct
+}
+
+// updateLastClusterTime updates the cluster time returned by LastClusterTime after a successful aggregate or getMore.
+func (cs *ChangeStream) updateLastClusterTime() {
+	ts := resumeTokenClusterTime(bson.Raw(cs.cursor.PostBatchResumeToken()))
+	if ts == nil && cs.sess != nil && cs.sess.OperationTime != nil {
+		opTime := *cs.sess.OperationTime
+		ts = &opTime
+	}
+	if ts != nil && (cs.lastClusterTime == nil || cs.lastClusterTime.Before(*ts)) {
+		cs.lastClusterTime = ts
+	}
+}
+
+// resumeTokenClusterTime returns the cluster time decoded from a resume token by DecodeResumeToken, or nil if the
+// token cannot be decoded.
+func resumeTokenClusterTime(token bson.Raw) *primitive.Timestamp {
+	info, err := DecodeResumeToken(token)
+	if err != nil {
+		return nil
+	}
+	return &info.ClusterTime
+}
+
+// Pipeline returns the aggregation pipeline sent to the server for the most recent aggregate command run by this change
+// stream, encoded as a BSON array. This includes the $changeStream stage, with any options such as resumeAfter or
+// those set using ChangeStreamOptions.SetCustomPipeline, and any stages injected by the driver. The pipeline is
+// updated each time the change stream resumes.
+func (cs *ChangeStream) Pipeline() bson.Raw {
+	return bson.Raw(cs.pipeline)
+}
+
+// Next gets the next event for this change stream. It returns true if there were no errors and the next event document
+// is available.
+//
+// Next blocks until an event is available, an error occurs, or ctx expires. If ctx expires, the error
+// will be set to ctx.Err(). In an error case, Next will return false. If ctx is cancelled or expires while a getMore
+// is waiting for events on the server, the connection running the getMore is closed so that Next returns promptly.
+//
+// If the client Timeout is set and ctx does not have a deadline, each call to Next, TryNext, or NextBatch is limited
+// to Timeout, rather than the lifetime of the change stream. If a getMore times out, Err returns a timeout error (see
+// IsTimeout), but the change stream is not invalidated: the next call resumes it from the cached resume token.
+//
+// Otherwise, if Next returns false, subsequent calls will also return false.
+func (cs *ChangeStream) Next(ctx context.Context) bool {
+	return cs.next(ctx, false)
+}
+
+// TryNext attempts to get the next event for this change stream. It returns true if there were no errors and the next
+// event document is available.
+//
+// TryNext returns false if the change stream is closed by the server, an error occurs when getting changes from the
+// server, the next change is not yet available, or ctx expires. If ctx expires, the error will be set to ctx.Err().
+//
+// If TryNext returns false and an error occurred or the change stream was closed
+// (i.e. cs.Err() != nil || cs.ID() == 0), subsequent attempts will also return false. Otherwise, it is safe to call
+// TryNext again until a change is available.
+//
+// This method requires driver version >= 1.2.0.
+func (cs *ChangeStream) TryNext(ctx context.Context) bool {
+	return cs.next(ctx, true)
+}
+
+// resumeTokenKey is the context key for the resume token stored by NextWithContext.
+type resumeTokenKey struct{}
+
+// NextWithContext is like Next, except that if an event is available, it also returns a child of ctx that holds a
+// copy of the change stream's resume token after the event (see ResumeToken). The token can be retrieved from the
+// returned context, or any context derived from it, with ResumeTokenFromContext. This allows the handlers that
+// process the event to checkpoint it without passing the change stream to them. If no event is available, ctx is
+// returned unchanged along with false.
+func (cs *ChangeStream) NextWithContext(ctx context.Context) (context.Context, bool) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !cs.Next(ctx) {
+		return ctx, false
+	}
+
+	token := make(bson.Raw, len(cs.resumeToken))
+	copy(token, cs.resumeToken)
+	return context.WithValue(ctx, resumeTokenKey{}, token), true
+}
+
+// ResumeTokenFromContext returns the change stream resume token stored in ctx by ChangeStream.NextWithContext, or nil
+// if ctx does not hold one.
+func ResumeTokenFromContext(ctx context.Context) bson.Raw {
+	if ctx == nil {
+		return nil
+	}
+	token, _ := ctx.Value(resumeTokenKey{}).(bson.Raw)
+	return token
+}
+
+// NextBatch returns all of the remaining events in the change stream's current batch, or the events in the next batch
+// if the current batch has been fully iterated. It returns false if an error occurred or no events are available, in
+// which case cs.Err() and cs.ID() should be checked in the same way as for TryNext.
+//
+// NextBatch does not block waiting for events: if the current batch is empty, at most one getMore is issued (or one
+// aggregate if the change stream has to resume). Current is set to the last returned event, and the cached resume
+// token is updated as if every returned event had been iterated with Next. The returned documents are only valid
+// until the next call to Next, TryNext, or NextBatch.
+func (cs *ChangeStream) NextBatch(ctx context.Context) ([]bson.Raw, bool) {
+	if cs.err != nil && !cs.resumePending {
+		return nil, false
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := cs.iterationContext(ctx)
+	defer cancel()
+
+	if !cs.resumeIfPending(ctx) {
+		return nil, false
+	}
+
+	if cs.endLifetimeIfDue(ctx) {
+		return nil, false
+	}
+
+	if cs.err = cs.checkpointIfDue(); cs.err != nil {
+		return nil, false
+	}
+
+	if len(cs.batch) == 0 {
+		cs.loopNext(ctx, true)
+		if cs.err != nil {
+			cs.setIterationError(cs.err)
+			return nil, false
+		}
+		if len(cs.batch) == 0 {
+			cs.endLifetimeIfDue(ctx)
+			return nil, false
+		}
+	}
+
+	events := make([]bson.Raw, 0, len(cs.batch))
+	for _, doc := range cs.batch {
+		event := bson.Raw(doc)
+		if _, ok := event.Lookup("_id").DocumentOK(); !ok {
+			_ = cs.Close(context.Background())
+			cs.err = ErrMissingResumeToken
+			return nil, false
+		}
+		if cs.err = cs.checkStaleness(event); cs.err != nil {
+			return nil, false
+		}
+		events = append(events, event)
+	}
+	for i, event := range events {
+		if events[i], cs.err = cs.bestEffortLookup(ctx, event); cs.err != nil {
+			return nil, false
+		}
+	}
+
+	cs.Current = events[len(events)-1]
+	cs.batch = nil
+	if cs.err = cs.storeResumeToken(); cs.err != nil {
+		return nil, false
+	}
+	cs.eventsSinceCheckpoint += len(events)
+	cs.recordFirstEvent()
+	return events, true
+}
+
+// FragmentInfo returns the fragment number and total number of fragments for the current event if it is a fragment of
+// a large event split by the $changeStreamSplitLargeEvent stage. If the current event is not a fragment, ok will be
+// false.
+func (cs *ChangeStream) FragmentInfo() (fragment, of int32, ok bool) {
+	splitEvent, ok := cs.Current.Lookup("splitEvent").DocumentOK()
+	if !ok {
+		return 0, 0, false
+	}
+
+	fragment, ok = splitEvent.Lookup("fragment").Int32OK()
+	if !ok {
+		return 0, 0, false
+	}
+	of, ok = splitEvent.Lookup("of").Int32OK()
+	if !ok {
+		return 0, 0, false
+	}
+	return fragment, of, true
+}
+
+// CurrentWallTime returns the wallTime of the current event, which is the server's wall clock time when the operation
+// that caused the event ran. Events from MongoDB versions < 6.0 do not have a wallTime, in which case ok will be false.
+func (cs *ChangeStream) CurrentWallTime() (wallTime time.Time, ok bool) {
+	return cs.Current.Lookup("wallTime").TimeOK()
+}
+
+// NextReassembled behaves like Next, but if the next event was split into fragments by the
+// $changeStreamSplitLargeEvent stage, it iterates all fragments of that event and sets Current to the recombined
+// event. The recombined event contains the fields of every fragment except splitEvent, and its _id is the resume token
+// of the last fragment so that resuming after the recombined event does not redeliver any of its fragments. Events
+// that were not split are returned unchanged.
+//
+// The server delivers the fragments of an event consecutively and in order. If a fragment is missing or arrives out
+// of order, or an event that is not the next fragment arrives before all fragments of the current event, the fragments
+// are not merged. Instead, NextReassembled returns false and Err returns an error wrapping ErrIncompleteSplitEvent.
+func (cs *ChangeStream) NextReassembled(ctx context.Context) bool {
+	if !cs.Next(ctx) {
+		return false
+	}
+
+	fragment, of, ok := cs.FragmentInfo()
+	if !ok {
+		return true
+	}
+	if fragment != 1 {
+		cs.err = fmt.Errorf("%w: expected the first fragment of a split event, got fragment %d of %d",
+			ErrIncompleteSplitEvent, fragment, of)
+		return false
+	}
+
+	fragments := make([]bson.Raw, 0, of)
+	fragments = append(fragments, cs.Current)
+	for fragment < of {
+		if !cs.Next(ctx) {
+			return false
+		}
+
+		next, nextOf, ok := cs.FragmentInfo()
+		if !ok || next != fragment+1 || nextOf != of {
+			cs.err = fmt.Errorf("%w: expected fragment %d of %d, got %s; a fragment was dropped",
+				ErrIncompleteSplitEvent, fragment+1, of, describeFragment(next, nextOf, ok))
+			return false
+		}
+		if !sameSplitEvent(fragments[0], cs.Current) {
+			cs.err = fmt.Errorf("%w: fragment %d of %d belongs to a different event; a fragment was dropped",
+				ErrIncompleteSplitEvent, next, of)
+			return false
+		}
+
+		fragment = next
+		fragments = append(fragments, cs.Current)
+	}
+
+	var reassembled bsoncore.Document
+	if reassembled, cs.err = reassembleFragments(fragments); cs.err != nil {
+		return false
+	}
+	cs.Current = bson.Raw(reassembled)
+	return true
 }
 
-// Close closes this change stream and the underlying cursor. Next and TryNext must not be called after Close has been
-// called. Close is idempotent. After the first call, any subsequent calls will not change the state.
-func (cs *ChangeStream) Close(ctx context.Context) error {
-	if ctx == nil {
-		ctx = context.Background()
+// NextCoalesced behaves like Next, but if the CoalesceWindow option is set, it collapses multiple events for the same
+// document that are received within the window into the most recent one. This is useful for consumers such as cache
+// invalidators that only need to know that a document changed, not every intermediate change. Coalescing is done by
+// the driver and does not reduce the number of events sent by the server.
+//
+// When no events are buffered, NextCoalesced waits for the next event like Next and then keeps receiving events until
+// CoalesceWindow has elapsed since that event was received. Because events are received with TryNext, the window can
+// be exceeded by up to the MaxAwaitTime option, or the server's default await time if MaxAwaitTime is not set.
+// Events are considered to be for the same document if they have the same ns and documentKey fields. Events without
+// a documentKey, such as drop and invalidate events, are never coalesced and end the window early.
+//
+// The buffered events are then returned one per call, in the order in which the most recent event for each document
+// was received. As a result, events for different documents may be returned in a different order than they were
+// received, but an event is never returned before an earlier event for the same document, and an event without a
+// documentKey is never returned before any event that was received before it. If an error occurs while receiving
+// events, the events already buffered are returned before NextCoalesced returns false, but Err reports the error
+// immediately.
+//
+// ResumeToken, HighWaterMark, and the CheckpointFunc option reflect the most recent event received from the server,
+// which may be ahead of the events that NextCoalesced has not returned yet. To resume without missing changes, resume
+// after the _id of the last event returned by NextCoalesced. Next, TryNext, and NextBatch do not return buffered
+// events, so they should not be called until NextCoalesced has returned all of them.
+//
+// If CoalesceWindow is not set, NextCoalesced is equivalent to Next.
+func (cs *ChangeStream) NextCoalesced(ctx context.Context) bool {
+	if cs.options == nil || cs.options.CoalesceWindow == nil || *cs.options.CoalesceWindow <= 0 {
+		return cs.Next(ctx)
 	}
 
-	defer closeImplicitSession(cs.sess)
+	if len(cs.coalesced) == 0 {
+		if !cs.Next(ctx) {
+			return false
+		}
+		cs.coalesced = coalesceEvent(cs.coalesced, cs.Current)
 
-	if cs.cursor == nil {
-		return nil // cursor is already closed
+		deadline := cs.now().Add(*cs.options.CoalesceWindow)
+		for coalesceKey(cs.Current) != "" && cs.now().Before(deadline) {
+			if cs.TryNext(ctx) {
+				cs.coalesced = coalesceEvent(cs.coalesced, cs.Current)
+				continue
+			}
+			if cs.err != nil || cs.ID() == 0 || !cs.waitForGetMore(ctx, deadline) {
+				break
+			}
+		}
 	}
 
-	cs.err = replaceErrors(cs.cursor.Close(ctx))
-	cs.cursor = nil
-	return cs.Err()
+	cs.Current = cs.coalesced[0]
+	cs.coalesced = cs.coalesced[1:]
+	return true
 }
 
-// ResumeToken returns the last cached resume token for this change stream, or nil if a resume token has not been
-// stored.
-func (cs *ChangeStream) ResumeToken() bson.Raw {
-	return cs.resumeToken
+// waitForGetMore blocks until the MinGetMoreInterval option allows the next getMore, deadline passes, or ctx is done.
+// It returns false if ctx is done.
+func (cs *ChangeStream) waitForGetMore(ctx context.Context, deadline time.Time) bool {
+	if !cs.getMoreThrottled() {
+		return true
+	}
+	wait := cs.lastGetMore.Add(*cs.options.MinGetMoreInterval).Sub(cs.now())
+	if remaining := deadline.Sub(cs.now()); remaining < wait {
+		wait = remaining
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
 }
 
-// Next gets the next event for this change stream. It returns true if there were no errors and the next event document
-// is available.
-//
-// Next blocks until an event is available, an error occurs, or ctx expires. If ctx expires, the error
-// will be set to ctx.Err(). In an error case, Next will return false.
-//
-// If Next returns false, subsequent calls will also return false.
-func (cs *ChangeStream) Next(ctx context.Context) bool {
-	return cs.next(ctx, false)
+// coalesceEvent appends a copy of event to events after removing any earlier event for the same document.
+func coalesceEvent(events []bson.Raw, event bson.Raw) []bson.Raw {
+	if key := coalesceKey(event); key != "" {
+		kept := events[:0]
+		for _, buffered := range events {
+			if coalesceKey(buffered) != key {
+				kept = append(kept, buffered)
+			}
+		}
+		events = kept
+	}
+	return append(events, append(bson.Raw(nil), event...))
 }
 
-// TryNext attempts to get the next event for this change stream. It returns true if there were no errors and the next
-// event document is available.
-//
-// TryNext returns false if the change stream is closed by the server, an error occurs when getting changes from the
-// server, the next change is not yet available, or ctx expires. If ctx expires, the error will be set to ctx.Err().
-//
-// If TryNext returns false and an error occurred or the change stream was closed
-// (i.e. cs.Err() != nil || cs.ID() == 0), subsequent attempts will also return false. Otherwise, it is safe to call
-// TryNext again until a change is available.
-//
-// This method requires driver version >= 1.2.0.
-func (cs *ChangeStream) TryNext(ctx context.Context) bool {
-	return cs.next(ctx, true)
+// coalesceKey returns a key that identifies the document affected by event, or an empty string if the event does not
+// have a documentKey.
+func coalesceKey(event bson.Raw) string {
+	docKey, ok := event.Lookup("documentKey").DocumentOK()
+	if !ok {
+		return ""
+	}
+	return string(event.Lookup("ns").Value) + string(docKey)
+}
+
+// sameSplitEvent reports whether the fragments first and next can belong to the same split event. All fragments of an
+// event have the cluster time and transaction operation index of the event in their resume tokens. If either resume
+// token cannot be decoded, sameSplitEvent returns true.
+func sameSplitEvent(first, next bson.Raw) bool {
+	firstToken, ok := first.Lookup("_id").DocumentOK()
+	if !ok {
+		return true
+	}
+	nextToken, ok := next.Lookup("_id").DocumentOK()
+	if !ok {
+		return true
+	}
+	firstInfo, err := DecodeResumeToken(firstToken)
+	if err != nil {
+		return true
+	}
+	nextInfo, err := DecodeResumeToken(nextToken)
+	if err != nil {
+		return true
+	}
+	return firstInfo.ClusterTime.Equal(nextInfo.ClusterTime) && firstInfo.TxnOpIndex == nextInfo.TxnOpIndex
+}
+
+func describeFragment(fragment, of int32, ok bool) string {
+	if !ok {
+		return "an event that is not a fragment"
+	}
+	return fmt.Sprintf("fragment %d of %d", fragment, of)
+}
+
+// reassembleFragments combines the fragments of a split event into a single event document. The _id of the last
+// fragment is used as the _id of the combined event.
+func reassembleFragments(fragments []bson.Raw) (bsoncore.Document, error) {
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+
+	id, err := fragments[len(fragments)-1].LookupErr("_id")
+	if err != nil {
+		return nil, ErrMissingResumeToken
+	}
+	doc = bsoncore.AppendValueElement(doc, "_id", bsoncore.Value{Type: id.Type, Data: id.Value})
+
+	for _, fragment := range fragments {
+		elems, err := fragment.Elements()
+		if err != nil {
+			return nil, err
+		}
+		for _, elem := range elems {
+			switch elem.Key() {
+			case "_id", "splitEvent":
+				continue
+			}
+			doc = append(doc, elem...)
+		}
+	}
+
+	return bsoncore.AppendDocumentEnd(doc, idx)
 }
 
 func (cs *ChangeStream) next(ctx context.Context, nonBlocking bool) bool {
-	// return false right away if the change stream has already errored or if cursor is closed.
-	if cs.err != nil {
+	// return false right away if the change stream has already errored or if cursor is closed, unless the error was a
+	// timeout that the change stream can resume from.
+	if cs.err != nil && !cs.resumePending {
 		return false
 	}
 
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	ctx, cancel := cs.iterationContext(ctx)
+	defer cancel()
+
+	if !cs.resumeIfPending(ctx) {
+		return false
+	}
+
+	if cs.endLifetimeIfDue(ctx) {
+		return false
+	}
+
+	if cs.err = cs.checkpointIfDue(); cs.err != nil {
+		return false
+	}
 
 	if len(cs.batch) == 0 {
 		cs.loopNext(ctx, nonBlocking)
 		if cs.err != nil {
-			cs.err = replaceErrors(cs.err)
+			cs.setIterationError(cs.err)
 			return false
 		}
 		if len(cs.batch) == 0 {
+			cs.endLifetimeIfDue(ctx)
 			return false
 		}
 	}
 
+	if cs.err = cs.checkStaleness(bson.Raw(cs.batch[0])); cs.err != nil {
+		return false
+	}
+
+	// Look up the document before consuming the event so it is not lost if the lookup fails.
+	event, err := cs.bestEffortLookup(ctx, bson.Raw(cs.batch[0]))
+	if err != nil {
+		cs.err = err
+		return false
+	}
+
 	// successfully got non-empty batch
-	cs.Current = bson.Raw(cs.batch[0])
+	cs.Current = event
 	cs.batch = cs.batch[1:]
 	if cs.err = cs.storeResumeToken(); cs.err != nil {
 		return false
 	}
+	cs.eventsSinceCheckpoint++
+	cs.recordFirstEvent()
+	return true
+}
+
+// recordFirstEvent records the time to first event if no event has been returned before.
+func (cs *ChangeStream) recordFirstEvent() {
+	if cs.firstEventSeen {
+		return
+	}
+	cs.firstEventSeen = true
+	cs.timeToFirstEvent = cs.now().Sub(cs.createdAt)
+}
+
+// bestEffortLookup returns event with a lookedUpDocument field set to the result of a find on the event's documentKey
+// if the BestEffortPreImageLookup option is true and event is an update event without a pre-image. The find uses the
+// change stream's read preference and, if the change stream was created with an explicit session, that session. In
+// any other case, or if the find returns no document, event is returned unchanged. An error is returned if the find
+// fails.
+func (cs *ChangeStream) bestEffortLookup(ctx context.Context, event bson.Raw) (bson.Raw, error) {
+	if cs.options.BestEffortPreImageLookup == nil || !*cs.options.BestEffortPreImageLookup {
+		return event, nil
+	}
+	if opType, _ := event.Lookup("operationType").StringValueOK(); opType != "update" {
+		return event, nil
+	}
+	if val, err := event.LookupErr("fullDocumentBeforeChange"); err == nil && val.Type != bsontype.Null {
+		return event, nil
+	}
+	db, _ := event.Lookup("ns", "db").StringValueOK()
+	coll, _ := event.Lookup("ns", "coll").StringValueOK()
+	key, ok := event.Lookup("documentKey").DocumentOK()
+	if db == "" || coll == "" || !ok {
+		return event, nil
+	}
+
+	// Only pass an explicit session through. The find would end an implicit session when its cursor is closed, and
+	// the change stream still needs it.
+	if cs.sess != nil && !cs.sess.IsImplicit {
+		ctx = NewSessionContext(ctx, &sessionImpl{
+			clientSession: cs.sess,
+			client:        cs.client,
+			deployment:    cs.client.deployment,
+		})
+	}
+	collOpts := options.Collection().SetReadPreference(cs.readPreference)
+	found, err := cs.client.Database(db).Collection(coll, collOpts).FindOne(ctx, key).DecodeBytes()
+	if errors.Is(err, ErrNoDocuments) {
+		return event, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("best-effort document lookup failed: %w", err)
+	}
+	elems, err := event.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, doc := bsoncore.AppendDocumentStart(make([]byte, 0, len(event)+len(found)+32))
+	for _, elem := range elems {
+		if elem.Key() == "lookedUpDocument" {
+			continue
+		}
+		doc = append(doc, elem...)
+	}
+	doc = bsoncore.AppendDocumentElement(doc, "lookedUpDocument", found)
+	doc, err = bsoncore.AppendDocumentEnd(doc, idx)
+	if err != nil {
+		return nil, err
+	}
+	return bson.Raw(doc), nil
+}
+
+// lifetimeExpired returns true if the MaxStreamDuration option is set and has elapsed since the change stream was
+// created.
+func (cs *ChangeStream) lifetimeExpired() bool {
+	return !cs.expiresAt.IsZero() && !cs.now().Before(cs.expiresAt)
+}
+
+// endLifetimeIfDue closes the change stream and returns true if its MaxStreamDuration has elapsed. Reaching the end of
+// the lifetime is not an error, so Err only returns an error if closing the change stream failed.
+func (cs *ChangeStream) endLifetimeIfDue(ctx context.Context) bool {
+	if cs.lifetimeEnded {
+		return true
+	}
+	if !cs.lifetimeExpired() {
+		return false
+	}
+
+	cs.lifetimeEnded = true
+	cs.batch = nil
+	_ = cs.Close(ctx)
+	return true
+}
+
+// WaitForEvent blocks until the next event is available, up to timeout, and returns a copy of it. It calls Next with a
+// context derived from ctx that expires after timeout. If no event arrives within timeout, WaitForEvent returns a
+// ChangeStreamWaitTimeoutError (see IsTimeout) and the change stream is not invalidated: the next call to
+// WaitForEvent, Next, TryNext, or NextBatch continues from the cached resume token, resuming the change stream if the
+// timeout interrupted a getMore or a resume attempt, or running the initial aggregate again if the timeout interrupted
+// it. Until then, Err returns the context error if the timeout interrupted a getMore or a resume attempt, and nil
+// otherwise.
+//
+// If ctx is done before timeout elapses, WaitForEvent returns ctx.Err(). If the change stream returns an error, it is
+// returned. If the change stream has been closed or has ended, ErrNilCursor is returned.
+func (cs *ChangeStream) WaitForEvent(ctx context.Context, timeout time.Duration) (bson.Raw, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	if cs.Next(waitCtx) {
+		return append(bson.Raw{}, cs.Current...), nil
+	}
+
+	err := cs.Err()
+	switch {
+	case err == nil:
+		return nil, ErrNilCursor
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	case waitCtx.Err() != nil && errors.Is(err, context.DeadlineExceeded):
+		cs.continueAfterWaitTimeout()
+		return nil, ChangeStreamWaitTimeoutError{Timeout: timeout}
+	}
+	return nil, err
+}
+
+// continueAfterWaitTimeout prepares the change stream to be continued after the WaitForEvent timeout, rather than the
+// caller, interrupted it. If a getMore or a resume attempt was interrupted, the next call to Next, TryNext, or NextBatch
+// resumes the change stream as if the client Timeout had aborted the getMore. Otherwise no cursor was lost, e.g.
+// because the timeout expired while waiting for a getMore slot or during the initial aggregate of a change stream
+// created by NewChangeStreamFromRaw, so the stored error is cleared.
+func (cs *ChangeStream) continueAfterWaitTimeout() {
+	if cs.getMoreTimedOut || cs.resumeFailed {
+		cs.resumePending = true
+		return
+	}
+	cs.err = nil
+}
+
+// LifetimeEnded returns true if the change stream was closed because its MaxStreamDuration elapsed. It can be used to
+// tell a change stream that ended normally from one that was closed for another reason after Next or TryNext returns
+// false with Err returning nil.
+func (cs *ChangeStream) LifetimeEnded() bool {
+	return cs.lifetimeEnded
+}
+
+// TimeToFirstEvent returns the time between the creation of the change stream and the first event returned by Next,
+// TryNext, or NextBatch. A long time to first event can indicate a slow-starting change stream, e.g. one opened while
+// there was no recent activity in the oplog. ok will be false if no event has been returned yet.
+func (cs *ChangeStream) TimeToFirstEvent() (d time.Duration, ok bool) {
+	return cs.timeToFirstEvent, cs.firstEventSeen
+}
+
+// iterationContext returns the context to use for a single call to Next, TryNext, or NextBatch. If ctx has no deadline
+// and the client Timeout is set to a non-zero value, the returned context expires after Timeout, so Timeout bounds
+// each iteration rather than the lifetime of the change stream.
+func (cs *ChangeStream) iterationContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := cs.clientTimeout()
+	if _, deadlineSet := ctx.Deadline(); deadlineSet || timeout == nil || *timeout == 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, *timeout)
+}
+
+// setIterationError stores an error from iterating the change stream. If the client Timeout is set and err is a
+// timeout error from a getMore, the change stream is resumed by the next call to Next, TryNext, or NextBatch. Any
+// other error, including one caused by the caller cancelling ctx, is returned by every later call.
+func (cs *ChangeStream) setIterationError(err error) {
+	cs.err = replaceErrors(err)
+	cs.resumePending = cs.getMoreTimedOut && cs.clientTimeout() != nil
+}
+
+// clientTimeout returns the Timeout of the client that created the change stream, or nil if it is not set.
+func (cs *ChangeStream) clientTimeout() *time.Duration {
+	if cs.client == nil {
+		return nil
+	}
+	return cs.client.timeout
+}
+
+// resumeIfPending resumes the change stream if the previous iteration failed because the client Timeout aborted a
+// getMore. It returns false if the resume attempt failed.
+func (cs *ChangeStream) resumeIfPending(ctx context.Context) bool {
+	if !cs.resumePending {
+		return true
+	}
+	if cs.Resume(ctx) != nil {
+		cs.setIterationError(cs.err)
+		return false
+	}
 	return true
 }
 
 func (cs *ChangeStream) loopNext(ctx context.Context, nonBlocking bool) {
+	cs.getMoreTimedOut = false
+	if cs.aggregatePending {
+		if cs.err = cs.executeOperation(ctx, false); cs.err != nil {
+			return
+		}
+		cs.aggregatePending = false
+	}
+
 	for {
 		if cs.cursor == nil {
 			return
 		}
 
-		if cs.cursor.Next(ctx) {
+		if nonBlocking && cs.getMoreThrottled() {
+			return
+		}
+
+		sendsGetMore := cs.sendsGetMore()
+		release := func() {}
+		if sendsGetMore {
+			var err error
+			if release, err = cs.acquireGetMore(ctx); err != nil {
+				cs.err = err
+				return
+			}
+		}
+		ok := cs.cursorNext(ctx)
+		release()
+		if ok {
 			// non-empty batch returned
 			cs.batch, cs.err = cs.cursor.Batch().Documents()
+			cs.updateLastClusterTime()
 			return
 		}
 
 		cs.err = replaceErrors(cs.cursor.Err())
+		cs.recordRawError(cs.err)
 		if cs.err == nil {
 			// Check if cursor is alive
 			if cs.ID() == 0 {
@@ -659,26 +2496,242 @@ func (cs *ChangeStream) loopNext(ctx context.Context, nonBlocking bool) {
 			// If a getMore was done but the batch was empty, the batch cursor will return false with no error.
 			// Update the tracked resume token to catch the post batch resume token from the server response.
 			cs.updatePbrtFromCommand()
-			if nonBlocking {
+			cs.updateLastClusterTime()
+			if nonBlocking || cs.lifetimeExpired() {
 				// stop after a successful getMore, even though the batch was empty
 				return
 			}
 			continue // loop getMore until a non-empty batch is returned or an error occurs
 		}
 
+		// If ctx is done, the getMore was aborted and the connection it used was closed, so Next returns the error
+		// promptly. Resuming here would fail because it uses the same context. If the client Timeout aborted the
+		// getMore, the change stream is resumed by the next call to Next, TryNext, or NextBatch instead.
+		cs.getMoreTimedOut = sendsGetMore && IsTimeout(cs.err)
+		if ctx.Err() != nil {
+			return
+		}
+
 		if !cs.isResumableError() {
 			return
 		}
 
 		// ignore error from cursor close because if the cursor is deleted or errors we tried to close it and will remake and try to get next batch
 		_ = cs.cursor.Close(ctx)
-		if cs.err = cs.executeOperation(ctx, true); cs.err != nil {
+		cs.getMoreTimedOut = false
+		cs.err = cs.executeOperation(ctx, true)
+		cs.resumeFailed = cs.err != nil
+		if cs.err != nil {
 			return
 		}
 	}
 }
 
+// cursorNext calls the cursor's Next method. If the call runs a getMore, the getMore is traced if the Client has a
+// Tracer, and the PostBatchHook option is run if the getMore succeeds.
+func (cs *ChangeStream) cursorNext(ctx context.Context) bool {
+	if !cs.sendsGetMore() {
+		cs.firstBatchPending = false
+		return cs.cursor.Next(ctx)
+	}
+
+	cs.lastGetMore = cs.now()
+	var span event.Span
+	if cs.client.tracer != nil {
+		ctx, span = cs.startSpan(ctx, "getMore")
+	}
+	ok := cs.cursor.Next(ctx)
+	if span != nil {
+		endSpan(span, cs.cursor.Batch(), cs.cursor.Err())
+	}
+	if cs.cursor.Err() == nil {
+		cs.runPostBatchHook()
+	}
+	return ok
+}
+
+// sendsGetMore returns true if the next call to the cursor's Next method runs a getMore, rather than returning the
+// first batch of the cursor or returning false because the cursor is exhausted.
+func (cs *ChangeStream) sendsGetMore() bool {
+	return !cs.firstBatchPending && cs.cursor.ID() != 0
+}
+
+// acquireGetMore waits until a getMore may be sent without exceeding the MaxConcurrentStreamingGetMores client option
+// for the server the change stream is using. It must only be called if the next call to the cursor's Next method
+// runs a getMore. It returns a function that must be called once the call to Next returns, or an error if ctx is done
+// first.
+func (cs *ChangeStream) acquireGetMore(ctx context.Context) (func(), error) {
+	if cs.client.getMoreLimiter == nil {
+		return func() {}, nil
+	}
+	return cs.client.getMoreLimiter.acquire(ctx, cs.serverAddr)
+}
+
+// getMoreLimiter limits the number of change stream getMores that run concurrently against each server. It is shared
+// by all change streams created by a Client. A server's entry is removed once no getMore is running or waiting for it,
+// so servers that are removed from the topology or that are no longer used after the Client is disconnected are not
+// retained.
+type getMoreLimiter struct {
+	max int
+
+	mu      sync.Mutex
+	servers map[address.Address]*getMoreSemaphore
+}
+
+// getMoreSemaphore holds the getMore slots for a single server.
+type getMoreSemaphore struct {
+	slots chan struct{}
+	users int // the number of getMores running or waiting for a slot, guarded by getMoreLimiter.mu
+}
+
+func newGetMoreLimiter(max int) *getMoreLimiter {
+	return &getMoreLimiter{
+		max:     max,
+		servers: make(map[address.Address]*getMoreSemaphore),
+	}
+}
+
+// acquire waits until fewer than max getMores are running against the server at addr. It returns a function that
+// releases the acquired slot, or an error if ctx is done first.
+func (l *getMoreLimiter) acquire(ctx context.Context, addr address.Address) (func(), error) {
+	l.mu.Lock()
+	sem, ok := l.servers[addr]
+	if !ok {
+		sem = &getMoreSemaphore{slots: make(chan struct{}, l.max)}
+		l.servers[addr] = sem
+	}
+	sem.users++
+	l.mu.Unlock()
+
+	select {
+	case sem.slots <- struct{}{}:
+		return func() {
+			<-sem.slots
+			l.leave(addr, sem)
+		}, nil
+	case <-ctx.Done():
+		l.leave(addr, sem)
+		return nil, ctx.Err()
+	}
+}
+
+// leave records that a getMore against the server at addr finished or stopped waiting, and removes the server's
+// entry if it was the last one.
+func (l *getMoreLimiter) leave(addr address.Address, sem *getMoreSemaphore) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	sem.users--
+	if sem.users == 0 {
+		delete(l.servers, addr)
+	}
+}
+
+// runPostBatchHook calls the PostBatchHook option, if it is set, with the batch most recently returned by the server.
+func (cs *ChangeStream) runPostBatchHook() {
+	if cs.options.PostBatchHook == nil {
+		return
+	}
+
+	var pbrt bson.Raw
+	if token := cs.cursor.PostBatchResumeToken(); token != nil {
+		pbrt = make(bson.Raw, len(token))
+		copy(pbrt, token)
+	}
+	cs.options.PostBatchHook(cs.cursor.Batch().DocumentCount(), pbrt)
+}
+
+// getMoreThrottled returns true if the next call to the cursor's Next method would run a getMore before the
+// MinGetMoreInterval has elapsed since the previous one.
+func (cs *ChangeStream) getMoreThrottled() bool {
+	interval := cs.options.MinGetMoreInterval
+	if interval == nil || cs.lastGetMore.IsZero() || !cs.sendsGetMore() {
+		return false
+	}
+	return cs.now().Sub(cs.lastGetMore) < *interval
+}
+
+// startSpan starts a span for the change stream operation with the given name. It must only be called if the Client
+// has a Tracer.
+func (cs *ChangeStream) startSpan(ctx context.Context, op string) (context.Context, event.Span) {
+	attrs := make([]event.SpanAttribute, 0, len(cs.spanAttrs)+1)
+	attrs = append(attrs, cs.spanAttrs...)
+	attrs = append(attrs, event.SpanAttribute{Key: event.SpanAttributeOperation, Value: op})
+	return cs.client.tracer.Start(ctx, "changeStream."+op, attrs...)
+}
+
+// endSpan records err on the span, or the number of events in batch in total and per operation type if err is nil,
+// and ends the span.
+func endSpan(span event.Span, batch *bsoncore.DocumentSequence, err error) {
+	defer span.End()
+
+	if err != nil {
+		span.RecordError(err)
+		return
+	}
+
+	docs, _ := batch.Documents()
+	attrs := []event.SpanAttribute{{Key: event.SpanAttributeEventCount, Value: int64(len(docs))}}
+	var opTypes []string
+	counts := make(map[string]int64)
+	for _, doc := range docs {
+		opType, ok := doc.Lookup("operationType").StringValueOK()
+		if !ok {
+			continue
+		}
+		if counts[opType] == 0 {
+			opTypes = append(opTypes, opType)
+		}
+		counts[opType]++
+	}
+	for _, opType := range opTypes {
+		attrs = append(attrs, event.SpanAttribute{
+			Key:   event.SpanAttributeOperationTypePrefix + opType,
+			Value: counts[opType],
+		})
+	}
+	span.SetAttributes(attrs...)
+}
+
+// checkStaleness returns a ChangeStreamStalenessError if the MaxStaleness option is set and the event's wallTime is
+// further behind the local clock than MaxStaleness. Events without a wallTime are not checked.
+func (cs *ChangeStream) checkStaleness(event bson.Raw) error {
+	if cs.options == nil || cs.options.MaxStaleness == nil {
+		return nil
+	}
+
+	wallTime, ok := event.Lookup("wallTime").DateTimeOK()
+	if !ok {
+		return nil
+	}
+
+	wt := time.Unix(wallTime/1000, wallTime%1000*int64(time.Millisecond))
+	if staleness := cs.now().Sub(wt); staleness > *cs.options.MaxStaleness {
+		return ChangeStreamStalenessError{
+			WallTime:     wt,
+			Staleness:    staleness,
+			MaxStaleness: *cs.options.MaxStaleness,
+		}
+	}
+	return nil
+}
+
 func (cs *ChangeStream) isResumableError() bool {
+	// Errors with the NonResumableChangeStreamError label are never resumable, even if they would be resumable
+	// otherwise.
+	if IsNonResumableChangeStreamError(cs.err) {
+		return false
+	}
+
+	if cs.options.ResumableErrorClassifier != nil {
+		if cs.options.ResumableErrorClassifier(cs.err) {
+			return true
+		}
+		if cs.options.ReplaceDefaultResumableErrors != nil && *cs.options.ReplaceDefaultResumableErrors {
+			return false
+		}
+	}
+
 	commandErr, ok := cs.err.(CommandError)
 	if !ok || commandErr.HasErrorLabel(networkErrorLabel) {
 		// All non-server errors or network errors are resumable.
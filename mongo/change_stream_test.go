@@ -7,9 +7,30 @@
 package mongo
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/uuid"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 )
 
 func TestChangeStream(t *testing.T) {
@@ -18,12 +39,2253 @@ func TestChangeStream(t *testing.T) {
 
 		id := cs.ID()
 		assert.Equal(t, int64(0), id, "expected ID 0, got %v", id)
+		addr := cs.CurrentServerAddress()
+		assert.Equal(t, "", addr, "expected empty server address, got %q", addr)
 		assert.False(t, cs.Next(bgCtx), "expected Next to return false, got true")
 		err := cs.Decode(nil)
 		assert.Equal(t, ErrNilCursor, err, "expected error %v, got %v", ErrNilCursor, err)
+		err = cs.Resume(bgCtx)
+		assert.Equal(t, ErrNilCursor, err, "expected error %v, got %v", ErrNilCursor, err)
 		err = cs.Err()
 		assert.Nil(t, err, "change stream error: %v", err)
 		err = cs.Close(bgCtx)
 		assert.Nil(t, err, "Close error: %v", err)
 	})
+	t.Run("iteration context", func(t *testing.T) {
+		timeout := time.Minute
+		zero := time.Duration(0)
+
+		testCases := []struct {
+			name         string
+			timeout      *time.Duration
+			ctxDeadline  bool
+			wantDeadline bool
+		}{
+			{"no client timeout", nil, false, false},
+			{"zero client timeout", &zero, false, false},
+			{"client timeout", &timeout, false, true},
+			{"context deadline", &timeout, true, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cs := &ChangeStream{client: &Client{timeout: tc.timeout}}
+
+				parent := bgCtx
+				var parentDeadline time.Time
+				if tc.ctxDeadline {
+					var cancel context.CancelFunc
+					parent, cancel = context.WithTimeout(bgCtx, time.Hour)
+					defer cancel()
+					parentDeadline, _ = parent.Deadline()
+				}
+
+				ctx, cancel := cs.iterationContext(parent)
+				defer cancel()
+
+				deadline, ok := ctx.Deadline()
+				assert.Equal(t, tc.wantDeadline, ok, "expected deadline set to be %v, got %v", tc.wantDeadline, ok)
+				if tc.ctxDeadline {
+					assert.Equal(t, parentDeadline, deadline, "expected the context deadline to be kept")
+				}
+			})
+		}
+	})
+	t.Run("raw pipeline", func(t *testing.T) {
+		stageDoc := func(key string, val bsoncore.Document) bsoncore.Document {
+			return bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendDocumentElement(nil, key, val))
+		}
+		stage := func(key string, val bsoncore.Document) bsoncore.Value {
+			return bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: stageDoc(key, val)}
+		}
+		csOpts := bsoncore.BuildDocumentFromElements(nil,
+			bsoncore.AppendStringElement(nil, "fullDocument", "updateLookup"),
+			bsoncore.AppendDocumentElement(nil, "startAfter", bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendStringElement(nil, "_data", "start"),
+			)),
+		)
+		match := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendStringElement(nil, "operationType", "insert"))
+
+		t.Run("invalid pipelines", func(t *testing.T) {
+			testCases := []struct {
+				name     string
+				pipeline interface{}
+				errMsg   string
+			}{
+				{"not bson.Raw", bson.A{}, "raw change stream pipeline must be a bson.Raw"},
+				{"invalid BSON", bson.Raw{0x01}, "not a valid BSON array"},
+				{"empty", bson.Raw(bsoncore.BuildArray(nil)), "must contain a $changeStream stage"},
+				{
+					"non-document stage",
+					bson.Raw(bsoncore.BuildArray(nil, bsoncore.Value{Type: bsontype.Int32, Data: bsoncore.AppendInt32(nil, 1)})),
+					"stage 0 must be a document",
+				},
+				{
+					"first stage is not $changeStream",
+					bson.Raw(bsoncore.BuildArray(nil, stage("$match", match), stage("$changeStream", csOpts))),
+					"first stage of a raw change stream pipeline must be $changeStream",
+				},
+			}
+			for _, tc := range testCases {
+				t.Run(tc.name, func(t *testing.T) {
+					cs := &ChangeStream{options: options.ChangeStream()}
+					err := cs.buildRawPipelineSlice(tc.pipeline)
+					assert.ErrorContains(t, err, tc.errMsg)
+				})
+			}
+		})
+		t.Run("stages are used verbatim", func(t *testing.T) {
+			pipeline := bson.Raw(bsoncore.BuildArray(nil, stage("$changeStream", csOpts), stage("$match", match)))
+			cs := &ChangeStream{
+				options: options.ChangeStream().SetResumeAfter(bson.D{{"_data", "ignored"}}),
+			}
+
+			err := cs.buildRawPipelineSlice(pipeline)
+			assert.Nil(t, err, "buildRawPipelineSlice error: %v", err)
+			assert.Len(t, cs.pipelineSlice, 2, "expected 2 stages, got %v", len(cs.pipelineSlice))
+			want := stageDoc("$match", match)
+			assert.Equal(t, want, cs.pipelineSlice[1], "expected stage %v, got %v", want, cs.pipelineSlice[1])
+			assert.Nil(t, cs.options.ResumeAfter, "expected ResumeAfter to be cleared, got %v", cs.options.ResumeAfter)
+			startAfter := bson.Raw(bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendStringElement(nil, "_data", "start")))
+			assert.Equal(t, startAfter, cs.options.StartAfter, "expected StartAfter %v, got %v", startAfter, cs.options.StartAfter)
+		})
+		t.Run("resume replaces resume options", func(t *testing.T) {
+			pipeline := bson.Raw(bsoncore.BuildArray(nil, stage("$changeStream", csOpts)))
+			cs := &ChangeStream{options: options.ChangeStream()}
+
+			err := cs.buildRawPipelineSlice(pipeline)
+			assert.Nil(t, err, "buildRawPipelineSlice error: %v", err)
+
+			token := bson.Raw(bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendStringElement(nil, "_data", "token")))
+			cs.options.SetResumeAfter(token)
+			cs.options.SetStartAfter(nil)
+
+			got, err := cs.createPipelineOptionsDoc()
+			assert.Nil(t, err, "createPipelineOptionsDoc error: %v", err)
+			want := bsoncore.Document(bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendStringElement(nil, "fullDocument", "updateLookup"),
+				bsoncore.AppendDocumentElement(nil, "resumeAfter", bsoncore.Document(token)),
+			))
+			assert.Equal(t, want, got, "expected options %v, got %v", want, got)
+		})
+	})
+	t.Run("resume token validation", func(t *testing.T) {
+		token := bsoncore.BuildDocumentFromElements(nil, bsoncore.AppendStringElement(nil, "_data", "token"))
+		truncated := token[:len(token)-3]
+
+		testCases := []struct {
+			name    string
+			token   interface{}
+			invalid bool
+		}{
+			{"valid bson.Raw", bson.Raw(token), false},
+			{"valid bsoncore.Document", bsoncore.Document(token), false},
+			{"valid []byte", token, false},
+			{"non-raw type", bson.D{{"_data", "token"}}, false},
+			{"truncated bson.Raw", bson.Raw(truncated), true},
+			{"truncated bsoncore.Document", bsoncore.Document(truncated), true},
+			{"truncated []byte", truncated, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := validateResumeToken("ResumeAfter", tc.token)
+				if !tc.invalid {
+					assert.Nil(t, err, "validateResumeToken error: %v", err)
+					return
+				}
+				assert.True(t, errors.Is(err, ErrInvalidResumeToken), "expected error %v, got %v",
+					ErrInvalidResumeToken, err)
+				assert.ErrorContains(t, err, "ResumeAfter")
+			})
+		}
+
+		t.Run("Watch", func(t *testing.T) {
+			coll := setupColl("foo")
+
+			opts := options.ChangeStream().SetStartAfter(bson.Raw(truncated))
+			_, err := coll.Watch(bgCtx, Pipeline{}, opts)
+			assert.True(t, errors.Is(err, ErrInvalidResumeToken), "expected error %v, got %v",
+				ErrInvalidResumeToken, err)
+			assert.ErrorContains(t, err, "StartAfter")
+		})
+	})
+	t.Run("read concern validation", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			rc      *readconcern.ReadConcern
+			invalid bool
+		}{
+			{"no read concern", nil, false},
+			{"empty read concern", &readconcern.ReadConcern{}, false},
+			{"majority", readconcern.Majority(), false},
+			{"local", readconcern.Local(), false},
+			{"available", readconcern.Available(), true},
+			{"linearizable", readconcern.Linearizable(), true},
+			{"snapshot", readconcern.Snapshot(), true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := validateChangeStreamReadConcern(tc.rc)
+				if !tc.invalid {
+					assert.Nil(t, err, "validateChangeStreamReadConcern error: %v", err)
+					return
+				}
+				assert.ErrorContains(t, err, "is not supported by change streams")
+			})
+		}
+
+		t.Run("Watch", func(t *testing.T) {
+			coll := setupColl("foo", options.Collection().SetReadConcern(readconcern.Snapshot()))
+
+			_, err := coll.Watch(bgCtx, Pipeline{})
+			assert.ErrorContains(t, err, `read concern level "snapshot" is not supported by change streams`)
+		})
+	})
+	t.Run("namespace regex stage", func(t *testing.T) {
+		regexMatch := func(input, regex string) bsoncore.Value {
+			return bsoncore.Value{
+				Type: bsontype.EmbeddedDocument,
+				Data: bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendDocumentElement(nil, "$regexMatch", bsoncore.BuildDocumentFromElements(nil,
+						bsoncore.AppendStringElement(nil, "input", input),
+						bsoncore.AppendStringElement(nil, "regex", regex),
+					)),
+				),
+			}
+		}
+		matchStage := func(conds ...bsoncore.Value) bsoncore.Document {
+			return bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendDocumentElement(nil, "$match", bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendDocumentElement(nil, "$expr", bsoncore.BuildDocumentFromElements(nil,
+						bsoncore.AppendArrayElement(nil, "$and", bsoncore.BuildArray(nil, conds...)),
+					)),
+				)),
+			)
+		}
+
+		testCases := []struct {
+			name string
+			opts *options.ChangeStreamOptions
+			want bsoncore.Document
+		}{
+			{"not set", options.ChangeStream(), nil},
+			{"empty patterns", options.ChangeStream().SetNamespaceRegex("", ""), nil},
+			{
+				"collection only",
+				options.ChangeStream().SetNamespaceRegex("", "^events_"),
+				matchStage(regexMatch("$ns.coll", "^events_")),
+			},
+			{
+				"database and collection",
+				options.ChangeStream().SetNamespaceRegex("^app", "^events_"),
+				matchStage(regexMatch("$ns.db", "^app"), regexMatch("$ns.coll", "^events_")),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got, err := namespaceRegexStage(tc.opts)
+				assert.Nil(t, err, "namespaceRegexStage error: %v", err)
+				assert.Equal(t, tc.want, got, "expected stage %v, got %v", tc.want, got)
+			})
+		}
+
+		t.Run("invalid regex", func(t *testing.T) {
+			_, err := namespaceRegexStage(options.ChangeStream().SetNamespaceRegex("", "events_("))
+			assert.ErrorContains(t, err, `invalid namespace regex "events_("`)
+		})
+	})
+	t.Run("single collection stage", func(t *testing.T) {
+		t.Run("not set", func(t *testing.T) {
+			got, err := singleCollectionStage(DatabaseStream, nil)
+			assert.Nil(t, err, "singleCollectionStage error: %v", err)
+			assert.Nil(t, got, "expected no stage, got %v", got)
+		})
+		t.Run("database stream", func(t *testing.T) {
+			name := "events"
+			got, err := singleCollectionStage(DatabaseStream, &name)
+			assert.Nil(t, err, "singleCollectionStage error: %v", err)
+
+			want, err := bson.Marshal(bson.D{{"$match", bson.D{{"$or", bson.A{
+				bson.D{{"ns.coll", "events"}},
+				bson.D{{"ns.coll", bson.D{{"$exists", false}}}},
+			}}}}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, bsoncore.Document(want), got, "expected stage %v, got %v", bson.Raw(want), bson.Raw(got))
+		})
+		t.Run("empty name", func(t *testing.T) {
+			name := ""
+			_, err := singleCollectionStage(DatabaseStream, &name)
+			assert.ErrorContains(t, err, "must be a non-empty collection name")
+		})
+		t.Run("client stream", func(t *testing.T) {
+			name := "events"
+			_, err := singleCollectionStage(ClientStream, &name)
+			assert.ErrorContains(t, err, "can only be used with Database.Watch")
+		})
+		t.Run("rejected by Collection.Watch", func(t *testing.T) {
+			client, conn := newChannelConnClient(t, options.Client())
+			opts := options.ChangeStream().SetSingleCollection("events")
+
+			_, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, opts)
+			assert.ErrorContains(t, err, "can only be used with Database.Watch")
+			assert.Equal(t, 0, len(conn.Written), "expected no commands to be sent, got %v", len(conn.Written))
+		})
+	})
+	t.Run("match stage", func(t *testing.T) {
+		filter := bson.D{{"$or", bson.A{
+			bson.D{{"operationType", "update"}},
+			bson.D{{"ns.coll", "bar"}, {"updateDescription.updatedFields.x", bson.D{{"$exists", true}}}},
+		}}}
+		wantStage, err := bson.Marshal(bson.D{{"$match", filter}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		t.Run("survives resume", func(t *testing.T) {
+			getMoreErr, err := bson.Marshal(bson.D{
+				{"ok", 0},
+				{"code", 6},
+				{"codeName", "HostUnreachable"},
+				{"errmsg", "host unreachable"},
+				{"errorLabels", bson.A{"ResumableChangeStreamError"}},
+			})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			killCursorsReply, err := bson.Marshal(bson.D{{"ok", 1}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			client, conn := newChannelConnClient(t, options.Client(),
+				changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "update")),
+				drivertest.MakeReply(getMoreErr),
+				drivertest.MakeReply(killCursorsReply),
+				changeStreamReply(t, "firstBatch", 0, testChangeEvent("2", "update")),
+			)
+
+			csOpts := options.ChangeStream().SetResumeAfter(bson.D{{"_data", "0"}}).SetMatch(filter)
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{bson.D{{"$project", bson.D{{"x", 0}}}}},
+				csOpts)
+			assert.Nil(t, err, "Watch error: %v", err)
+			assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+			assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+			assert.Equal(t, "update", cs.Current.Lookup("operationType").StringValue(), "expected update event")
+			assert.Equal(t, 4, len(conn.Written), "expected 4 commands to be sent, got %v", len(conn.Written))
+
+			// Both the original and the resumed aggregate have the $match stage between $changeStream and the user
+			// pipeline.
+			for i := 0; i < 4; i++ {
+				cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+				assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+				if cmd.Index(0).Key() != "aggregate" {
+					continue
+				}
+				stages, err := cmd.Lookup("pipeline").Array().Values()
+				assert.Nil(t, err, "Values error: %v", err)
+				assert.Equal(t, 3, len(stages), "expected 3 pipeline stages, got %v", len(stages))
+				got := stages[1].Document()
+				assert.Equal(t, bsoncore.Document(wantStage), got, "expected stage %v, got %v", bson.Raw(wantStage),
+					bson.Raw(got))
+			}
+		})
+		t.Run("invalid filters", func(t *testing.T) {
+			testCases := []struct {
+				name   string
+				filter interface{}
+				errMsg string
+			}{
+				{"resume token", bson.D{{"_id", bson.D{{"_data", "0"}}}}, `"_id" is not a change event envelope field`},
+				{"full document", bson.D{{"fullDocument.x", 1}}, `"fullDocument.x" is not a change event envelope field`},
+				{
+					"nested full document",
+					bson.D{{"$and", bson.A{bson.D{{"operationType", "insert"}}, bson.D{{"fullDocument.x", 1}}}}},
+					`"fullDocument.x" is not a change event envelope field`,
+				},
+				{"expression", bson.D{{"$expr", bson.D{{"$eq", bson.A{"$operationType", "insert"}}}}}, `operator "$expr"`},
+				{"logical operator not an array", bson.D{{"$or", bson.D{}}}, "$or must be an array"},
+				{"not a document", "operationType", "cannot marshal type string to a BSON Document"},
+			}
+			for _, tc := range testCases {
+				t.Run(tc.name, func(t *testing.T) {
+					client, conn := newChannelConnClient(t, options.Client())
+					opts := options.ChangeStream().SetMatch(tc.filter)
+
+					_, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, opts)
+					assert.ErrorContains(t, err, tc.errMsg)
+					assert.Equal(t, 0, len(conn.Written), "expected no commands to be sent, got %v", len(conn.Written))
+				})
+			}
+		})
+	})
+	t.Run("AllChangesForCluster", func(t *testing.T) {
+		rejected := []struct {
+			name  string
+			watch func(*Client, *options.ChangeStreamOptions) error
+			err   string
+		}{
+			{
+				"collection stream",
+				func(client *Client, opts *options.ChangeStreamOptions) error {
+					_, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, opts)
+					return err
+				},
+				"can only be used with Client.Watch",
+			},
+			{
+				"database stream",
+				func(client *Client, opts *options.ChangeStreamOptions) error {
+					_, err := client.Database("foo").Watch(bgCtx, Pipeline{}, opts)
+					return err
+				},
+				"can only be used with Client.Watch",
+			},
+			{
+				"client stream set to false",
+				func(client *Client, _ *options.ChangeStreamOptions) error {
+					_, err := client.Watch(bgCtx, Pipeline{}, options.ChangeStream().SetAllChangesForCluster(false))
+					return err
+				},
+				"must be true for change streams created with Client.Watch",
+			},
+		}
+		for _, tc := range rejected {
+			t.Run(tc.name, func(t *testing.T) {
+				client, conn := newChannelConnClient(t, options.Client())
+				err := client.Connect(bgCtx)
+				assert.Nil(t, err, "Connect error: %v", err)
+
+				err = tc.watch(client, options.ChangeStream().SetAllChangesForCluster(true))
+				assert.ErrorContains(t, err, tc.err)
+				assert.Equal(t, 0, len(conn.Written), "expected no commands to be sent, got %v", len(conn.Written))
+			})
+		}
+		t.Run("client stream", func(t *testing.T) {
+			client, conn := newChannelConnClient(t, options.Client(), changeStreamReply(t, "firstBatch", 0))
+			err := client.Connect(bgCtx)
+			assert.Nil(t, err, "Connect error: %v", err)
+
+			cs, err := client.Watch(bgCtx, Pipeline{}, options.ChangeStream().SetAllChangesForCluster(true))
+			assert.Nil(t, err, "Watch error: %v", err)
+			defer cs.Close(bgCtx)
+
+			cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+			assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+			acfc, ok := cmd.Lookup("pipeline", "0", "$changeStream", "allChangesForCluster").BooleanOK()
+			assert.True(t, ok && acfc, "expected allChangesForCluster to be true, got %v", cmd)
+		})
+	})
+	t.Run("validate pipeline", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			stage bson.D
+			keeps bool
+		}{
+			{"$match", bson.D{{"$match", bson.D{{"operationType", "insert"}}}}, true},
+			{"$project exclude _id", bson.D{{"$project", bson.D{{"_id", 0}}}}, false},
+			{"$project exclude _id._data", bson.D{{"$project", bson.D{{"_id._data", false}}}}, false},
+			{"$project replace _id", bson.D{{"$project", bson.D{{"_id", "$documentKey"}}}}, false},
+			{"$project include _id", bson.D{{"$project", bson.D{{"_id", 1}, {"fullDocument", 1}}}}, true},
+			{"$project exclude other field", bson.D{{"$project", bson.D{{"fullDocument", 0}}}}, true},
+			{"$unset _id", bson.D{{"$unset", "_id"}}, false},
+			{"$unset array with _id", bson.D{{"$unset", bson.A{"ns", "_id"}}}, false},
+			{"$unset other fields", bson.D{{"$unset", bson.A{"ns", "fullDocument._id"}}}, true},
+			{"$unset non-string", bson.D{{"$unset", 1}}, true},
+			{"$unset array with non-string", bson.D{{"$unset", bson.A{"a", 2}}}, true},
+			{"$set _id", bson.D{{"$set", bson.D{{"_id", "$documentKey"}}}}, false},
+			{"$addFields other field", bson.D{{"$addFields", bson.D{{"x", 1}}}}, true},
+			{"$replaceRoot fullDocument", bson.D{{"$replaceRoot", bson.D{{"newRoot", "$fullDocument"}}}}, false},
+			{"$replaceRoot $$ROOT", bson.D{{"$replaceRoot", bson.D{{"newRoot", "$$ROOT"}}}}, true},
+			{
+				"$replaceWith document keeping _id",
+				bson.D{{"$replaceWith", bson.D{{"_id", "$_id"}, {"doc", "$fullDocument"}}}},
+				true,
+			},
+			{
+				"$replaceWith document without _id",
+				bson.D{{"$replaceWith", bson.D{{"doc", "$fullDocument"}}}},
+				false,
+			},
+			{
+				"$replaceWith $mergeObjects keeping _id",
+				bson.D{{"$replaceWith", bson.D{{"$mergeObjects", bson.A{"$fullDocument", bson.D{{"_id", "$_id"}}}}}}},
+				true,
+			},
+			{
+				"$replaceWith $mergeObjects overriding _id",
+				bson.D{{"$replaceWith", bson.D{{"$mergeObjects", bson.A{"$$ROOT", "$fullDocument"}}}}},
+				false,
+			},
+			{
+				"$replaceWith other expression",
+				bson.D{{"$replaceWith", bson.D{{"$cond", bson.A{true, "$$ROOT", "$fullDocument"}}}}},
+				true,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				stage, err := bson.Marshal(tc.stage)
+				assert.Nil(t, err, "Marshal error: %v", err)
+
+				err = validateStageKeepsID(1, stage)
+				if tc.keeps {
+					assert.Nil(t, err, "validateStageKeepsID error: %v", err)
+					return
+				}
+				assert.True(t, errors.Is(err, ErrMissingResumeToken),
+					"expected error %v, got %v", ErrMissingResumeToken, err)
+				assert.ErrorContains(t, err, "pipeline stage 1 ("+tc.stage[0].Key+")")
+			})
+		}
+
+		t.Run("rejected by Watch", func(t *testing.T) {
+			client, conn := newChannelConnClient(t, options.Client())
+			pipeline := Pipeline{{{"$project", bson.D{{"_id", 0}}}}}
+
+			opts := options.ChangeStream().SetValidatePipeline(true)
+			_, err := client.Database("foo").Collection("bar").Watch(bgCtx, pipeline, opts)
+			assert.True(t, errors.Is(err, ErrMissingResumeToken),
+				"expected error %v, got %v", ErrMissingResumeToken, err)
+			assert.Equal(t, 0, len(conn.Written), "expected no commands to be sent, got %v", len(conn.Written))
+		})
+		t.Run("accepted by Watch", func(t *testing.T) {
+			client, conn := newChannelConnClient(t, options.Client(), changeStreamReply(t, "firstBatch", 0))
+			pipeline := Pipeline{{{"$project", bson.D{{"fullDocument", 0}}}}}
+
+			opts := options.ChangeStream().SetValidatePipeline(true).SetResumeAfter(bson.D{{"_data", "0"}})
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, pipeline, opts)
+			assert.Nil(t, err, "Watch error: %v", err)
+			defer cs.Close(bgCtx)
+			assert.Equal(t, 1, len(conn.Written), "expected 1 command to be sent, got %v", len(conn.Written))
+		})
+	})
+	t.Run("fullDocument projection stage", func(t *testing.T) {
+		t.Run("not set", func(t *testing.T) {
+			got, err := fullDocumentProjectionStage(nil)
+			assert.Nil(t, err, "fullDocumentProjectionStage error: %v", err)
+			assert.Nil(t, got, "expected no stage, got %v", got)
+		})
+		t.Run("fields", func(t *testing.T) {
+			got, err := fullDocumentProjectionStage([]string{"name", "_id", "size"})
+			assert.Nil(t, err, "fullDocumentProjectionStage error: %v", err)
+
+			want, err := bson.Marshal(bson.D{{"$addFields", bson.D{{"fullDocument", bson.D{{"$cond", bson.D{
+				{"if", bson.D{{"$eq", bson.A{bson.D{{"$type", "$fullDocument"}}, "object"}}}},
+				{"then", bson.D{{"$arrayToObject", bson.D{{"$filter", bson.D{
+					{"input", bson.D{{"$objectToArray", "$fullDocument"}}},
+					{"cond", bson.D{{"$in", bson.A{"$$this.k", bson.A{"_id", "name", "size"}}}}},
+				}}}}}},
+				{"else", "$fullDocument"},
+			}}}}}}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, bsoncore.Document(want), got, "expected stage %v, got %v", bson.Raw(want), bson.Raw(got))
+		})
+		t.Run("invalid fields", func(t *testing.T) {
+			for _, field := range []string{"", "a.b", "$a"} {
+				_, err := fullDocumentProjectionStage([]string{"name", field})
+				assert.ErrorContains(t, err, fmt.Sprintf("invalid fullDocument projection field %q", field))
+			}
+		})
+	})
+	t.Run("resume token cluster time", func(t *testing.T) {
+		tokenWithData := func(data interface{}) bson.Raw {
+			raw, err := bson.Marshal(bson.D{{"_data", data}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return raw
+		}
+
+		testCases := []struct {
+			name  string
+			token bson.Raw
+			want  *primitive.Timestamp
+		}{
+			{"nil token", nil, nil},
+			{"missing _data", bson.Raw(bsoncore.BuildDocument(nil)), nil},
+			{"non-string _data", tokenWithData(int32(1)), nil},
+			{"too short", tokenWithData("8263A1"), nil},
+			{"not hex", tokenWithData("82ZZZZZZZZZZZZZZZZ"), nil},
+			{"wrong type byte", tokenWithData("8163A1B2C3000000042B0229296E04"), nil},
+			{"valid", tokenWithData("8263A1B2C3000000042B0229296E04"), &primitive.Timestamp{T: 0x63A1B2C3, I: 4}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got := resumeTokenClusterTime(tc.token)
+				assert.Equal(t, tc.want, got, "expected cluster time %v, got %v", tc.want, got)
+			})
+		}
+	})
+	t.Run("record raw error", func(t *testing.T) {
+		raw := bson.Raw(bsoncore.NewDocumentBuilder().AppendInt32("ok", 0).AppendInt32("code", 2).Build())
+
+		cs := &ChangeStream{}
+		cs.recordRawError(errors.New("not a server error"))
+		assert.Nil(t, cs.LastRawError(), "expected no raw error, got %v", cs.LastRawError())
+
+		cs.recordRawError(fmt.Errorf("wrapped: %w", CommandError{Code: 2, Raw: raw}))
+		assert.Equal(t, raw, cs.LastRawError(), "expected raw error %v, got %v", raw, cs.LastRawError())
+
+		// Errors without a server reply do not replace the stored reply.
+		cs.recordRawError(ErrMissingResumeToken)
+		assert.Equal(t, raw, cs.LastRawError(), "expected raw error %v, got %v", raw, cs.LastRawError())
+	})
+	t.Run("causally consistent implicit session", func(t *testing.T) {
+		clusterTime := bson.Raw(bsoncore.NewDocumentBuilder().
+			AppendDocument("$clusterTime", bsoncore.NewDocumentBuilder().
+				AppendTimestamp("clusterTime", 10, 2).
+				Build()).
+			Build())
+
+		t.Run("no cluster time", func(t *testing.T) {
+			sess := session.NewImplicitClientSession(nil, uuid.UUID{})
+			makeCausallyConsistent(sess, &session.ClusterClock{})
+			assert.True(t, sess.Consistent, "expected session to be causally consistent")
+			assert.Nil(t, sess.OperationTime, "expected no operation time, got %v", sess.OperationTime)
+		})
+		t.Run("cluster time", func(t *testing.T) {
+			clock := &session.ClusterClock{}
+			clock.AdvanceClusterTime(clusterTime)
+
+			sess := session.NewImplicitClientSession(nil, uuid.UUID{})
+			makeCausallyConsistent(sess, clock)
+			assert.True(t, sess.Consistent, "expected session to be causally consistent")
+			want := &primitive.Timestamp{T: 10, I: 2}
+			assert.Equal(t, want, sess.OperationTime, "expected operation time %v, got %v", want, sess.OperationTime)
+			assert.Equal(t, clusterTime, sess.ClusterTime, "expected cluster time %v, got %v", clusterTime, sess.ClusterTime)
+		})
+	})
+	t.Run("coalesce events", func(t *testing.T) {
+		event := func(token, opType, coll string, id interface{}) bson.Raw {
+			doc := bson.D{
+				{"_id", bson.D{{"_data", token}}},
+				{"operationType", opType},
+				{"ns", bson.D{{"db", "db"}, {"coll", coll}}},
+			}
+			if id != nil {
+				doc = append(doc, bson.E{"documentKey", bson.D{{"_id", id}}})
+			}
+			raw, err := bson.Marshal(doc)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return raw
+		}
+		tokens := func(events []bson.Raw) []string {
+			var tokens []string
+			for _, ev := range events {
+				tokens = append(tokens, ev.Lookup("_id", "_data").StringValue())
+			}
+			return tokens
+		}
+
+		testCases := []struct {
+			name   string
+			events []bson.Raw
+			want   []string
+		}{
+			{
+				"three updates to one document",
+				[]bson.Raw{
+					event("1", "update", "coll", 1),
+					event("2", "update", "coll", 1),
+					event("3", "update", "coll", 1),
+				},
+				[]string{"3"},
+			},
+			{
+				"ordered by most recent event",
+				[]bson.Raw{
+					event("1", "insert", "coll", 1),
+					event("2", "insert", "coll", 2),
+					event("3", "update", "coll", 1),
+					event("4", "delete", "coll", 2),
+				},
+				[]string{"3", "4"},
+			},
+			{
+				"different namespaces are not coalesced",
+				[]bson.Raw{
+					event("1", "update", "coll", 1),
+					event("2", "update", "other", 1),
+				},
+				[]string{"1", "2"},
+			},
+			{
+				"events without documentKey are not coalesced",
+				[]bson.Raw{
+					event("1", "drop", "coll", nil),
+					event("2", "drop", "coll", nil),
+				},
+				[]string{"1", "2"},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				var buffered []bson.Raw
+				for _, ev := range tc.events {
+					buffered = coalesceEvent(buffered, ev)
+				}
+				got := tokens(buffered)
+				assert.Equal(t, tc.want, got, "expected events %v, got %v", tc.want, got)
+			})
+		}
+		t.Run("min getMore interval", func(t *testing.T) {
+			replies := [][]byte{
+				changeStreamReply(t, "firstBatch", 1, event("1", "update", "coll", 1)),
+				changeStreamReply(t, "nextBatch", 1, event("2", "update", "coll", 1)),
+			}
+			for i := 0; i < 10; i++ {
+				replies = append(replies, changeStreamReply(t, "nextBatch", 1))
+			}
+			client, conn := newChannelConnClient(t, options.Client(), replies...)
+
+			csOpts := options.ChangeStream().
+				SetCoalesceWindow(100 * time.Millisecond).
+				SetMinGetMoreInterval(30 * time.Millisecond)
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+			assert.Nil(t, err, "Watch error: %v", err)
+
+			var nowCalls int
+			cs.now = func() time.Time {
+				nowCalls++
+				return time.Now()
+			}
+
+			assert.True(t, cs.NextCoalesced(bgCtx), "NextCoalesced error: %v", cs.Err())
+			got := tokens([]bson.Raw{cs.Current})
+			assert.Equal(t, []string{"2"}, got, "expected events %v, got %v", []string{"2"}, got)
+
+			// While getMores are throttled, NextCoalesced waits for the interval instead of polling TryNext.
+			assert.True(t, nowCalls < 100, "expected NextCoalesced to wait for throttled getMores, got %d clock reads",
+				nowCalls)
+			assert.True(t, len(conn.Written) <= 6, "expected at most 6 commands to be sent, got %v", len(conn.Written))
+		})
+	})
+	t.Run("max staleness", func(t *testing.T) {
+		now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		cs := &ChangeStream{
+			options: options.ChangeStream().SetMaxStaleness(time.Minute),
+			now:     func() time.Time { return now },
+		}
+		event := func(wallTime time.Time) bson.Raw {
+			raw, err := bson.Marshal(bson.D{{"_id", bson.D{{"_data", "1"}}}, {"wallTime", wallTime}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return raw
+		}
+
+		err := cs.checkStaleness(event(now.Add(-30 * time.Second)))
+		assert.Nil(t, err, "checkStaleness error: %v", err)
+
+		err = cs.checkStaleness(event(now.Add(-2 * time.Minute)))
+		var se ChangeStreamStalenessError
+		assert.True(t, errors.As(err, &se), "expected error type %T, got %T", se, err)
+		assert.Equal(t, 2*time.Minute, se.Staleness, "expected staleness %v, got %v", 2*time.Minute, se.Staleness)
+	})
+	t.Run("resume token context", func(t *testing.T) {
+		client, _ := newChannelConnClient(t, options.Client(),
+			changeStreamReply(t, "firstBatch", 0, testChangeEvent("1", "insert"), testChangeEvent("2", "update")),
+		)
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{})
+		assert.Nil(t, err, "Watch error: %v", err)
+		defer cs.Close(bgCtx)
+
+		type ctxKey struct{}
+		parent := context.WithValue(bgCtx, ctxKey{}, "parent")
+		assert.Nil(t, ResumeTokenFromContext(parent), "expected no resume token, got %v", ResumeTokenFromContext(parent))
+
+		for i := 0; i < 2; i++ {
+			ctx, ok := cs.NextWithContext(parent)
+			assert.True(t, ok, "NextWithContext error: %v", cs.Err())
+			assert.Equal(t, "parent", ctx.Value(ctxKey{}), "expected context to be derived from the parent")
+
+			// The token is also available from contexts derived from the returned one.
+			derived, cancel := context.WithCancel(ctx)
+			got := ResumeTokenFromContext(derived)
+			cancel()
+			assert.Equal(t, cs.ResumeToken(), got, "expected resume token %v, got %v", cs.ResumeToken(), got)
+		}
+
+		ctx, ok := cs.NextWithContext(parent)
+		assert.False(t, ok, "expected NextWithContext to return false")
+		assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+		assert.Nil(t, ResumeTokenFromContext(ctx), "expected no resume token, got %v", ResumeTokenFromContext(ctx))
+	})
+	t.Run("min getMore interval", func(t *testing.T) {
+		killCursorsReply, err := bson.Marshal(bson.D{{"ok", 1}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		client, conn := newChannelConnClient(t, options.Client(),
+			changeStreamReply(t, "firstBatch", 1),
+			changeStreamReply(t, "nextBatch", 1),
+			changeStreamReply(t, "nextBatch", 1),
+			drivertest.MakeReply(killCursorsReply),
+		)
+
+		// Resume so that the empty first batch doesn't need an operation time from the server.
+		csOpts := options.ChangeStream().SetMinGetMoreInterval(time.Second).SetResumeAfter(bson.D{{"_data", "0"}})
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+		assert.Nil(t, err, "Watch error: %v", err)
+		defer cs.Close(bgCtx)
+
+		now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		cs.now = func() time.Time { return now }
+
+		testCases := []struct {
+			advance time.Duration
+			written int
+		}{
+			// The first batch is returned by the aggregate, so no getMore is needed.
+			{0, 1},
+			{0, 2},
+			{500 * time.Millisecond, 2},
+			{499 * time.Millisecond, 2},
+			{time.Millisecond, 3},
+		}
+		for i, tc := range testCases {
+			now = now.Add(tc.advance)
+			assert.False(t, cs.TryNext(bgCtx), "expected TryNext %d to return false", i)
+			assert.Nil(t, cs.Err(), "TryNext %d error: %v", i, cs.Err())
+			assert.Equal(t, tc.written, len(conn.Written),
+				"expected %d commands to be sent after TryNext %d, got %d", tc.written, i, len(conn.Written))
+		}
+	})
+	t.Run("non-resumable error label", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			labels  bson.A
+			resumes bool
+		}{
+			{"resumable label", bson.A{"ResumableChangeStreamError"}, true},
+			{"non-resumable label", bson.A{"ResumableChangeStreamError", "NonResumableChangeStreamError"}, false},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				getMoreErr, err := bson.Marshal(bson.D{
+					{"ok", 0},
+					{"code", 6},
+					{"codeName", "HostUnreachable"},
+					{"errmsg", "host unreachable"},
+					{"errorLabels", tc.labels},
+				})
+				assert.Nil(t, err, "Marshal error: %v", err)
+				killCursorsReply, err := bson.Marshal(bson.D{{"ok", 1}})
+				assert.Nil(t, err, "Marshal error: %v", err)
+				client, conn := newChannelConnClient(t, options.Client(),
+					changeStreamReply(t, "firstBatch", 1),
+					drivertest.MakeReply(getMoreErr),
+					drivertest.MakeReply(killCursorsReply),
+					changeStreamReply(t, "firstBatch", 0),
+				)
+
+				// Resume so that the empty first batch doesn't need an operation time from the server.
+				csOpts := options.ChangeStream().SetResumeAfter(bson.D{{"_data", "0"}})
+				cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+				assert.Nil(t, err, "Watch error: %v", err)
+
+				// The first batch is returned by the aggregate, so the second TryNext sends a getMore.
+				assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false")
+				assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false")
+				if tc.resumes {
+					// The change stream kills the cursor and runs a new aggregate.
+					assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+					assert.Equal(t, 4, len(conn.Written), "expected 4 commands to be sent, got %v", len(conn.Written))
+					return
+				}
+
+				assert.NotNil(t, cs.Err(), "expected change stream error, got nil")
+				assert.True(t, IsNonResumableChangeStreamError(cs.Err()),
+					"expected IsNonResumableChangeStreamError to be true for %v", cs.Err())
+				var ce CommandError
+				assert.True(t, errors.As(cs.Err(), &ce), "expected error type %T, got %T", ce, cs.Err())
+				assert.Equal(t, int32(6), ce.Code, "expected error code 6, got %v", ce.Code)
+				assert.Equal(t, 2, len(conn.Written), "expected 2 commands to be sent, got %v", len(conn.Written))
+			})
+		}
+	})
+	t.Run("max concurrent streaming getMores", func(t *testing.T) {
+		const numStreams, maxGetMores = 8, 3
+
+		deployment := newCountingDeployment(t)
+		clientOpts := options.Client().SetMaxConcurrentStreamingGetMores(maxGetMores)
+		clientOpts.Deployment = deployment
+		client, err := NewClient(clientOpts)
+		assert.Nil(t, err, "NewClient error: %v", err)
+
+		// Resume so that the empty first batches don't need an operation time from the server.
+		csOpts := options.ChangeStream().SetResumeAfter(bson.D{{"_data", "0"}})
+		streams := make([]*ChangeStream, numStreams)
+		for i := range streams {
+			streams[i], err = client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+			assert.Nil(t, err, "Watch error: %v", err)
+		}
+
+		var wg sync.WaitGroup
+		results := make([]bool, numStreams)
+		for i, cs := range streams {
+			wg.Add(1)
+			go func(i int, cs *ChangeStream) {
+				defer wg.Done()
+				results[i] = cs.Next(bgCtx)
+			}(i, cs)
+		}
+
+		// The getMores that are allowed to run check out a connection and block until they are released. The other
+		// change streams wait for a getMore to finish instead of checking out a connection.
+		assert.Eventually(t, func() bool { return deployment.stats().checkedOut == maxGetMores },
+			time.Second, time.Millisecond, "expected %d connections to be checked out", maxGetMores)
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, maxGetMores, deployment.stats().checkedOut,
+			"expected %d connections to be checked out, got %d", maxGetMores, deployment.stats().checkedOut)
+
+		close(deployment.release)
+		wg.Wait()
+		for i, ok := range results {
+			assert.True(t, ok, "Next error for change stream %d: %v", i, streams[i].Err())
+		}
+		stats := deployment.stats()
+		assert.Equal(t, maxGetMores, stats.maxCheckedOut,
+			"expected at most %d connections to be checked out at once, got %d", maxGetMores, stats.maxCheckedOut)
+		assert.Equal(t, numStreams, stats.getMores, "expected %d getMores, got %d", numStreams, stats.getMores)
+
+		// Once no getMore is running or waiting, the limiter does not retain any server.
+		client.getMoreLimiter.mu.Lock()
+		numServers := len(client.getMoreLimiter.servers)
+		client.getMoreLimiter.mu.Unlock()
+		assert.Equal(t, 0, numServers, "expected no servers to be retained, got %d", numServers)
+
+		t.Run("first batch", func(t *testing.T) {
+			client, _ := newChannelConnClient(t, options.Client().SetMaxConcurrentStreamingGetMores(1),
+				changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert")),
+			)
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{})
+			assert.Nil(t, err, "Watch error: %v", err)
+
+			// The only slot is taken, but the event is in the first batch, so no getMore and no slot is needed.
+			release, err := client.getMoreLimiter.acquire(bgCtx, cs.serverAddr)
+			assert.Nil(t, err, "acquire error: %v", err)
+			defer release()
+
+			ctx, cancel := context.WithTimeout(bgCtx, time.Second)
+			defer cancel()
+			assert.True(t, cs.Next(ctx), "Next error: %v", cs.Err())
+		})
+	})
+	t.Run("document key", func(t *testing.T) {
+		insertEvent := bson.D{
+			{"_id", bson.D{{"_data", "1"}}},
+			{"operationType", "insert"},
+			{"documentKey", bson.D{{"region", "us"}, {"_id", 1}}},
+		}
+		client, _ := newChannelConnClient(t, options.Client(),
+			changeStreamReply(t, "firstBatch", 1, insertEvent, testChangeEvent("2", "dropDatabase")),
+		)
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{})
+		assert.Nil(t, err, "Watch error: %v", err)
+
+		assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+		wantKey, err := bson.Marshal(bson.D{{"region", "us"}, {"_id", 1}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		assert.Equal(t, bson.Raw(wantKey), cs.DocumentKey(),
+			"expected documentKey %v, got %v", bson.Raw(wantKey), cs.DocumentKey())
+
+		var key struct {
+			ID     int32  `bson:"_id"`
+			Region string `bson:"region"`
+		}
+		err = cs.DecodeDocumentKey(&key)
+		assert.Nil(t, err, "DecodeDocumentKey error: %v", err)
+		assert.Equal(t, int32(1), key.ID, "expected _id 1, got %v", key.ID)
+		assert.Equal(t, "us", key.Region, "expected region %q, got %q", "us", key.Region)
+
+		// Events that are not about a single document do not have a documentKey.
+		assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+		assert.Nil(t, cs.DocumentKey(), "expected nil documentKey, got %v", cs.DocumentKey())
+		err = cs.DecodeDocumentKey(&key)
+		assert.Equal(t, ErrMissingDocumentKey, err, "expected error %v, got %v", ErrMissingDocumentKey, err)
+	})
+	t.Run("change stream error", func(t *testing.T) {
+		marshalReply := func(doc bson.D) []byte {
+			raw, err := bson.Marshal(doc)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			return drivertest.MakeReply(raw)
+		}
+		badValue := bson.D{{"ok", 0}, {"code", 2}, {"codeName", "BadValue"}, {"errmsg", "bad value"}}
+		wantToken, err := bson.Marshal(bson.D{{"_data", "1"}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		t.Run("command error", func(t *testing.T) {
+			client, _ := newChannelConnClient(t, options.Client(),
+				changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert")),
+				marshalReply(badValue),
+			)
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{})
+			assert.Nil(t, err, "Watch error: %v", err)
+
+			assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+			assert.Nil(t, cs.ErrDetails(), "expected no error, got %v", cs.ErrDetails())
+			assert.False(t, cs.Next(bgCtx), "expected Next to return false")
+
+			// Err returns the underlying error, so existing type assertions keep working.
+			ce, ok := cs.Err().(CommandError)
+			assert.True(t, ok, "expected error type %T, got %T", ce, cs.Err())
+			assert.Equal(t, int32(2), ce.Code, "expected error code 2, got %v", ce.Code)
+
+			var cse ChangeStreamError
+			assert.True(t, errors.As(cs.ErrDetails(), &cse), "expected error type %T, got %T", cse, cs.ErrDetails())
+			assert.Equal(t, int64(1), cse.StreamID, "expected stream ID 1, got %v", cse.StreamID)
+			assert.Equal(t, bson.Raw(wantToken), cse.ResumeToken,
+				"expected resume token %v, got %v", bson.Raw(wantToken), cse.ResumeToken)
+			assert.False(t, cse.ResumeAttempted, "expected ResumeAttempted to be false")
+			assert.Equal(t, cs.Err(), cse.Wrapped, "expected wrapped error %v, got %v", cs.Err(), cse.Wrapped)
+			assert.Equal(t, ce.Error(), cse.Error(), "expected error message %q, got %q", ce.Error(), cse.Error())
+		})
+		getMoreErr := bson.D{
+			{"ok", 0},
+			{"code", 6},
+			{"codeName", "HostUnreachable"},
+			{"errmsg", "host unreachable"},
+			{"errorLabels", bson.A{"ResumableChangeStreamError"}},
+		}
+		t.Run("failed resume in Next", func(t *testing.T) {
+			// The getMore fails with a resumable error, so Next resumes the change stream, and the aggregate fails.
+			client, _ := newChannelConnClient(t, options.Client(),
+				changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert")),
+				marshalReply(getMoreErr),
+				marshalReply(bson.D{{"ok", 1}}),
+				marshalReply(badValue),
+			)
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{})
+			assert.Nil(t, err, "Watch error: %v", err)
+
+			assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+			assert.False(t, cs.Next(bgCtx), "expected Next to return false")
+
+			var cse ChangeStreamError
+			assert.True(t, errors.As(cs.ErrDetails(), &cse), "expected error type %T, got %T", cse, cs.ErrDetails())
+			assert.True(t, cse.ResumeAttempted, "expected ResumeAttempted to be true")
+			assert.Equal(t, bson.Raw(wantToken), cse.ResumeToken,
+				"expected resume token %v, got %v", bson.Raw(wantToken), cse.ResumeToken)
+			var ce CommandError
+			assert.True(t, errors.As(cs.Err(), &ce), "expected error type %T, got %T", ce, cs.Err())
+			assert.Equal(t, int32(2), ce.Code, "expected error code 2, got %v", ce.Code)
+		})
+		t.Run("failed Resume", func(t *testing.T) {
+			client, _ := newChannelConnClient(t, options.Client(),
+				changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert")),
+				marshalReply(bson.D{{"ok", 1}}),
+				marshalReply(badValue),
+			)
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{})
+			assert.Nil(t, err, "Watch error: %v", err)
+			assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+
+			err = cs.Resume(bgCtx)
+			ce, ok := err.(CommandError)
+			assert.True(t, ok, "expected error type %T, got %T", ce, err)
+			assert.Equal(t, int32(2), ce.Code, "expected error code 2, got %v", ce.Code)
+			assert.Equal(t, cs.Err(), err, "expected Resume to return Err %v, got %v", cs.Err(), err)
+			var cse ChangeStreamError
+			assert.True(t, errors.As(cs.ErrDetails(), &cse), "expected error type %T, got %T", cse, cs.ErrDetails())
+			assert.True(t, cse.ResumeAttempted, "expected ResumeAttempted to be true")
+		})
+	})
+	t.Run("topology change", func(t *testing.T) {
+		getMoreErr, err := bson.Marshal(bson.D{
+			{"ok", 0},
+			{"code", 10107},
+			{"codeName", "NotWritablePrimary"},
+			{"errmsg", "not primary"},
+			{"errorLabels", bson.A{"ResumableChangeStreamError"}},
+		})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		killCursorsReply, err := bson.Marshal(bson.D{{"ok", 1}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		client, conn := newChannelConnClient(t, options.Client(),
+			changeStreamReply(t, "firstBatch", 1),
+			drivertest.MakeReply(getMoreErr),
+			drivertest.MakeReply(killCursorsReply),
+			changeStreamReply(t, "firstBatch", 0, testChangeEvent("1", "insert")),
+		)
+
+		csOpts := options.ChangeStream().SetResumeAfter(bson.D{{"_data", "0"}})
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+		assert.Nil(t, err, "Watch error: %v", err)
+		var changes []TopologyChange
+		cs.OnTopologyChange(func(change TopologyChange) {
+			changes = append(changes, change)
+		})
+
+		// The standalone is restarted as a replica set primary, so the getMore fails with a NotPrimary error. The
+		// change stream resumes against the new server description.
+		assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false")
+		conn.Desc.Kind = description.RSPrimary
+		assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+		assert.Equal(t, 4, len(conn.Written), "expected 4 commands to be sent, got %v", len(conn.Written))
+
+		assert.Equal(t, 1, len(changes), "expected 1 topology change, got %v", len(changes))
+		change := changes[0]
+		assert.Equal(t, description.Standalone, change.PreviousServerKind,
+			"expected previous server kind %v, got %v", description.Standalone, change.PreviousServerKind)
+		assert.Equal(t, description.RSPrimary, change.CurrentServerKind,
+			"expected current server kind %v, got %v", description.RSPrimary, change.CurrentServerKind)
+		assert.Equal(t, change.PreviousTopologyKind, change.CurrentTopologyKind, "expected topology kind to be unchanged")
+		var ce CommandError
+		assert.True(t, errors.As(change.Err, &ce), "expected error type %T, got %T", ce, change.Err)
+		assert.Equal(t, int32(10107), ce.Code, "expected error code 10107, got %v", ce.Code)
+	})
+	t.Run("topology change error", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			labels  bson.A
+			resumes bool
+		}{
+			// Servers with wire version 9 and above label the errors that change streams resume from, so an unlabeled
+			// topology change error is not resumed.
+			{"unlabeled", bson.A{}, false},
+			{"labeled", bson.A{"ResumableChangeStreamError"}, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				getMoreErr, err := bson.Marshal(bson.D{
+					{"ok", 0},
+					{"code", 13388},
+					{"codeName", "StaleConfig"},
+					{"errmsg", "stale config"},
+					{"errorLabels", tc.labels},
+				})
+				assert.Nil(t, err, "Marshal error: %v", err)
+				killCursorsReply, err := bson.Marshal(bson.D{{"ok", 1}})
+				assert.Nil(t, err, "Marshal error: %v", err)
+				client, conn := newChannelConnClient(t, options.Client(),
+					changeStreamReply(t, "firstBatch", 1),
+					drivertest.MakeReply(getMoreErr),
+					drivertest.MakeReply(killCursorsReply),
+					changeStreamReply(t, "firstBatch", 0, testChangeEvent("1", "insert")),
+				)
+
+				csOpts := options.ChangeStream().SetResumeAfter(bson.D{{"_data", "0"}})
+				cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+				assert.Nil(t, err, "Watch error: %v", err)
+				var changes []TopologyChange
+				cs.OnTopologyChange(func(change TopologyChange) {
+					changes = append(changes, change)
+				})
+
+				// The first batch is returned by the aggregate, so the second TryNext sends a getMore that fails. If the
+				// change stream resumes, the new aggregate returns an event.
+				assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false")
+				got := cs.TryNext(bgCtx)
+				assert.Equal(t, tc.resumes, got, "expected TryNext to return %v, got %v", tc.resumes, got)
+				if !tc.resumes {
+					var ce CommandError
+					assert.True(t, errors.As(cs.Err(), &ce), "expected error type %T, got %T", ce, cs.Err())
+					assert.Equal(t, int32(13388), ce.Code, "expected error code 13388, got %v", ce.Code)
+					assert.Equal(t, 2, len(conn.Written), "expected 2 commands to be sent, got %v", len(conn.Written))
+					assert.Equal(t, 0, len(changes), "expected no topology changes, got %v", len(changes))
+					return
+				}
+
+				// The server kinds did not change, but the callback is called because the error was caused by a
+				// topology change.
+				assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+				assert.Equal(t, 4, len(conn.Written), "expected 4 commands to be sent, got %v", len(conn.Written))
+				assert.Equal(t, 1, len(changes), "expected 1 topology change, got %v", len(changes))
+				assert.Equal(t, changes[0].PreviousServerKind, changes[0].CurrentServerKind,
+					"expected server kind to be unchanged")
+			})
+		}
+	})
+	t.Run("max stream duration", func(t *testing.T) {
+		killCursorsReply, err := bson.Marshal(bson.D{{"ok", 1}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		client, conn := newChannelConnClient(t, options.Client(),
+			changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert"), testChangeEvent("2", "insert")),
+			drivertest.MakeReply(killCursorsReply),
+		)
+
+		csOpts := options.ChangeStream().
+			SetResumeAfter(bson.D{{"_data", "0"}}).
+			SetMaxStreamDuration(10 * time.Millisecond)
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+		assert.Nil(t, err, "Watch error: %v", err)
+		assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+		assert.False(t, cs.LifetimeEnded(), "expected LifetimeEnded to be false")
+
+		// The remaining event is discarded once the lifetime has elapsed.
+		time.Sleep(20 * time.Millisecond)
+		assert.False(t, cs.Next(bgCtx), "expected Next to return false")
+		assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+		assert.True(t, cs.LifetimeEnded(), "expected LifetimeEnded to be true")
+		assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false")
+
+		assert.Equal(t, 2, len(conn.Written), "expected 2 commands to be sent, got %v", len(conn.Written))
+		<-conn.Written
+		cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+		assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+		assert.Equal(t, "killCursors", cmd.Index(0).Key(), "expected killCursors command, got %v", cmd)
+	})
+	t.Run("batch size", func(t *testing.T) {
+		testCases := []struct {
+			name      string
+			batchSize int32
+			want      bool
+		}{
+			{"zero uses server default", 0, false},
+			{"positive", 5, true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				client, conn := newChannelConnClient(t, options.Client(),
+					changeStreamReply(t, "firstBatch", 1),
+					changeStreamReply(t, "nextBatch", 1, testChangeEvent("1", "insert")),
+				)
+
+				csOpts := options.ChangeStream().SetResumeAfter(bson.D{{"_data", "0"}}).SetBatchSize(tc.batchSize)
+				cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+				assert.Nil(t, err, "Watch error: %v", err)
+				assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+
+				aggregate, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+				assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+				getMore, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+				assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+
+				for _, bs := range []bsoncore.Value{aggregate.Lookup("cursor", "batchSize"), getMore.Lookup("batchSize")} {
+					if !tc.want {
+						assert.Equal(t, bsontype.Type(0), bs.Type, "expected batchSize to be omitted, got %v", bs)
+						continue
+					}
+					assert.Equal(t, tc.batchSize, bs.Int32(), "expected batchSize %v, got %v", tc.batchSize, bs)
+				}
+			})
+		}
+		t.Run("negative", func(t *testing.T) {
+			client, conn := newChannelConnClient(t, options.Client())
+			csOpts := options.ChangeStream().SetBatchSize(-1)
+
+			_, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+			assert.ErrorContains(t, err, "batch size must not be negative")
+			assert.Equal(t, 0, len(conn.Written), "expected no commands to be sent, got %v", len(conn.Written))
+		})
+	})
+	t.Run("time to first event", func(t *testing.T) {
+		client, _ := newChannelConnClient(t, options.Client(),
+			changeStreamReply(t, "firstBatch", 1),
+			changeStreamReply(t, "nextBatch", 1),
+			changeStreamReply(t, "nextBatch", 1, testChangeEvent("1", "insert"), testChangeEvent("2", "insert")),
+		)
+
+		csOpts := options.ChangeStream().SetResumeAfter(bson.D{{"_data", "0"}})
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+		assert.Nil(t, err, "Watch error: %v", err)
+
+		now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		cs.createdAt = now
+		cs.now = func() time.Time { return now }
+
+		// The first getMore returns an empty batch, so no event has been delivered yet.
+		assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false")
+		assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+		_, ok := cs.TimeToFirstEvent()
+		assert.False(t, ok, "expected TimeToFirstEvent to not be recorded before the first event")
+
+		now = now.Add(3 * time.Second)
+		assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+		got, ok := cs.TimeToFirstEvent()
+		assert.True(t, ok, "expected TimeToFirstEvent to be recorded after the first event")
+		assert.Equal(t, 3*time.Second, got, "expected time to first event %v, got %v", 3*time.Second, got)
+
+		// Later events do not change the recorded duration.
+		now = now.Add(time.Minute)
+		assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+		got, _ = cs.TimeToFirstEvent()
+		assert.Equal(t, 3*time.Second, got, "expected time to first event %v, got %v", 3*time.Second, got)
+	})
+	t.Run("server selection try once", func(t *testing.T) {
+		// No server listens on port 1, so the only server in the topology stays Unknown and is never suitable.
+		clientOpts := options.Client().
+			SetHosts([]string{"localhost:1"}).
+			SetServerSelectionTimeout(30 * time.Second).
+			SetServerSelectionTryOnce(true)
+		client, err := Connect(bgCtx, clientOpts)
+		assert.Nil(t, err, "Connect error: %v", err)
+		defer func() { _ = client.Disconnect(bgCtx) }()
+
+		start := time.Now()
+		_, err = client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{})
+		elapsed := time.Since(start)
+		assert.True(t, errors.Is(err, topology.ErrNoSuitableServer),
+			"expected error %v, got %v", topology.ErrNoSuitableServer, err)
+		assert.True(t, elapsed < 5*time.Second, "expected Watch to fail immediately, took %v", elapsed)
+	})
+	t.Run("incomplete split event", func(t *testing.T) {
+		fragment := func(tokenData string, n, of int32, field string) bson.D {
+			return bson.D{
+				{"_id", bson.D{{"_data", tokenData}}},
+				{field, n},
+				{"splitEvent", bson.D{{"fragment", n}, {"of", of}}},
+			}
+		}
+		// Resume tokens for two events with different cluster times.
+		const firstEvent = "8263515A49000000012B0229296E04"
+		const secondEvent = "8263515A4A000000012B0229296E04"
+
+		testCases := []struct {
+			name   string
+			events []interface{}
+			errMsg string
+		}{
+			{
+				"first fragment missing",
+				[]interface{}{fragment(firstEvent, 2, 2, "b")},
+				"expected the first fragment of a split event, got fragment 2 of 2",
+			},
+			{
+				"unsplit event before last fragment",
+				[]interface{}{fragment(firstEvent, 1, 2, "a"), testChangeEvent(secondEvent, "insert")},
+				"expected fragment 2 of 2, got an event that is not a fragment",
+			},
+			{
+				"fragment of a different event",
+				[]interface{}{fragment(firstEvent, 1, 2, "a"), fragment(secondEvent, 2, 2, "b")},
+				"fragment 2 of 2 belongs to a different event",
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				client, _ := newChannelConnClient(t, options.Client(),
+					changeStreamReply(t, "firstBatch", 1, tc.events...),
+				)
+				cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{})
+				assert.Nil(t, err, "Watch error: %v", err)
+
+				assert.False(t, cs.NextReassembled(bgCtx), "expected NextReassembled to return false")
+				assert.True(t, errors.Is(cs.Err(), ErrIncompleteSplitEvent),
+					"expected error %v, got %v", ErrIncompleteSplitEvent, cs.Err())
+				assert.ErrorContains(t, cs.Err(), tc.errMsg)
+			})
+		}
+
+		client, _ := newChannelConnClient(t, options.Client(),
+			changeStreamReply(t, "firstBatch", 1, fragment(firstEvent, 1, 2, "a"), fragment(firstEvent, 2, 2, "b")),
+		)
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{})
+		assert.Nil(t, err, "Watch error: %v", err)
+		assert.True(t, cs.NextReassembled(bgCtx), "NextReassembled error: %v", cs.Err())
+		assert.Equal(t, int32(1), cs.Current.Lookup("a").Int32(), "expected field a from the first fragment")
+		assert.Equal(t, int32(2), cs.Current.Lookup("b").Int32(), "expected field b from the second fragment")
+	})
+	t.Run("best-effort pre-image lookup", func(t *testing.T) {
+		updateEvent := bson.D{
+			{"_id", bson.D{{"_data", "1"}}},
+			{"operationType", "update"},
+			{"ns", bson.D{{"db", "foo"}, {"coll", "bar"}}},
+			{"documentKey", bson.D{{"_id", 1}}},
+		}
+		insertEvent := bson.D{
+			{"_id", bson.D{{"_data", "2"}}},
+			{"operationType", "insert"},
+			{"ns", bson.D{{"db", "foo"}, {"coll", "bar"}}},
+			{"documentKey", bson.D{{"_id", 2}}},
+		}
+		client, conn := newChannelConnClient(t, options.Client(),
+			changeStreamReply(t, "firstBatch", 0, updateEvent, insertEvent),
+			changeStreamReply(t, "firstBatch", 0, bson.D{{"_id", 1}, {"x", "current"}}),
+		)
+
+		csOpts := options.ChangeStream().SetBestEffortPreImageLookup(true)
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+		assert.Nil(t, err, "Watch error: %v", err)
+		defer cs.Close(bgCtx)
+
+		assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+		found, ok := cs.Current.Lookup("lookedUpDocument").DocumentOK()
+		assert.True(t, ok, "expected lookedUpDocument document in %v", cs.Current)
+		assert.Equal(t, "current", found.Lookup("x").StringValue(), "expected looked up document, got %v", found)
+		_, err = cs.Current.LookupErr("fullDocumentBeforeChange")
+		assert.NotNil(t, err, "expected no fullDocumentBeforeChange field in %v", cs.Current)
+		assert.Equal(t, "1", cs.ResumeToken().Lookup("_data").StringValue(),
+			"expected resume token to be unchanged, got %v", cs.ResumeToken())
+
+		// Only update events are looked up.
+		assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+		_, err = cs.Current.LookupErr("lookedUpDocument")
+		assert.NotNil(t, err, "expected no lookedUpDocument field in %v", cs.Current)
+
+		assert.Equal(t, 2, len(conn.Written), "expected 2 commands to be sent, got %v", len(conn.Written))
+		<-conn.Written
+		cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+		assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+		assert.Equal(t, "bar", cmd.Lookup("find").StringValue(), "expected find on bar, got %v", cmd)
+		filter := cmd.Lookup("filter").Document()
+		assert.Equal(t, int32(1), filter.Lookup("_id").Int32(), "expected filter on documentKey, got %v", filter)
+	})
+	t.Run("best-effort pre-image lookup failure", func(t *testing.T) {
+		updateEvent := bson.D{
+			{"_id", bson.D{{"_data", "1"}}},
+			{"operationType", "update"},
+			{"ns", bson.D{{"db", "foo"}, {"coll", "bar"}}},
+			{"documentKey", bson.D{{"_id", 1}}},
+		}
+		findErr, err := bson.Marshal(bson.D{
+			{"ok", 0},
+			{"code", 13},
+			{"codeName", "Unauthorized"},
+			{"errmsg", "not authorized"},
+		})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		client, _ := newChannelConnClient(t, options.Client(),
+			changeStreamReply(t, "firstBatch", 0, updateEvent),
+			drivertest.MakeReply(findErr),
+		)
+
+		csOpts := options.ChangeStream().SetBestEffortPreImageLookup(true)
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+		assert.Nil(t, err, "Watch error: %v", err)
+		defer cs.Close(bgCtx)
+
+		assert.False(t, cs.Next(bgCtx), "expected Next to return false")
+		var cmdErr CommandError
+		assert.True(t, errors.As(cs.Err(), &cmdErr), "expected CommandError, got %v", cs.Err())
+		assert.Equal(t, int32(13), cmdErr.Code, "expected error code 13, got %v", cmdErr.Code)
+		assert.Nil(t, cs.ResumeToken(), "expected event not to be consumed, got resume token %v", cs.ResumeToken())
+	})
+	t.Run("decode fullDocument into json.RawMessage", func(t *testing.T) {
+		event := bson.D{
+			{"_id", bson.D{{"_data", "1"}}},
+			{"operationType", "insert"},
+			{"fullDocument", bson.D{{"_id", 1}, {"name", "widget"}, {"price", 2.5}}},
+		}
+		testCases := []struct {
+			name     string
+			bsonOpts *options.BSONOptions
+			want     string
+		}{
+			{"relaxed", nil, `{"_id":1,"name":"widget","price":2.5}`},
+			{
+				"canonical",
+				&options.BSONOptions{CanonicalJSONRawMessage: true},
+				`{"_id":{"$numberInt":"1"},"name":"widget","price":{"$numberDouble":"2.5"}}`,
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				client, _ := newChannelConnClient(t, options.Client().SetBSONOptions(tc.bsonOpts),
+					changeStreamReply(t, "firstBatch", 0, event),
+				)
+				cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{})
+				assert.Nil(t, err, "Watch error: %v", err)
+				defer cs.Close(bgCtx)
+
+				assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+				var got struct {
+					FullDocument json.RawMessage `bson:"fullDocument"`
+				}
+				err = cs.Decode(&got)
+				assert.Nil(t, err, "Decode error: %v", err)
+				assert.Equal(t, tc.want, string(got.FullDocument), "expected and actual JSON do not match")
+			})
+		}
+	})
+	t.Run("cancel in-flight getMore", func(t *testing.T) {
+		// The server never replies to the getMore, as if it were waiting for the whole maxAwaitTime. The Written
+		// channel has room for a killCursors and an aggregate so that a resume attempt would be detected.
+		client, conn := newChannelConnClient(t, options.Client(), changeStreamReply(t, "firstBatch", 1))
+		conn.Written = make(chan []byte, 4)
+
+		csOpts := options.ChangeStream().SetResumeAfter(bson.D{{"_data", "0"}}).SetMaxAwaitTime(time.Hour)
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+		assert.Nil(t, err, "Watch error: %v", err)
+
+		ctx, cancel := context.WithCancel(bgCtx)
+		time.AfterFunc(50*time.Millisecond, cancel)
+		start := time.Now()
+		assert.False(t, cs.Next(ctx), "expected Next to return false")
+		elapsed := time.Since(start)
+
+		assert.True(t, elapsed < 5*time.Second, "expected Next to return promptly, took %v", elapsed)
+		assert.True(t, errors.Is(cs.Err(), context.Canceled), "expected error %v, got %v", context.Canceled, cs.Err())
+		assert.Equal(t, 2, len(conn.Written), "expected 2 commands to be sent, got %v", len(conn.Written))
+
+		// The client Timeout is not set, so cancelling ctx does not make the next call resume the change stream.
+		assert.False(t, cs.Next(bgCtx), "expected Next to return false")
+		assert.True(t, errors.Is(cs.Err(), context.Canceled), "expected error %v, got %v", context.Canceled, cs.Err())
+		assert.Equal(t, 2, len(conn.Written), "expected 2 commands to be sent, got %v", len(conn.Written))
+	})
+	t.Run("client timeout aborts in-flight getMore", func(t *testing.T) {
+		// The server never replies to the getMore, so it is aborted by the client Timeout. The Written channel has
+		// room for the killCursors and aggregate sent when the change stream resumes.
+		clientOpts := options.Client().SetTimeout(50 * time.Millisecond)
+		client, conn := newChannelConnClient(t, clientOpts, changeStreamReply(t, "firstBatch", 1))
+		conn.Written = make(chan []byte, 4)
+
+		csOpts := options.ChangeStream().SetResumeAfter(bson.D{{"_data", "0"}})
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+		assert.Nil(t, err, "Watch error: %v", err)
+
+		assert.False(t, cs.Next(bgCtx), "expected Next to return false")
+		assert.True(t, IsTimeout(cs.Err()), "expected timeout error, got %v", cs.Err())
+		assert.Equal(t, 2, len(conn.Written), "expected 2 commands to be sent, got %v", len(conn.Written))
+
+		// The batch of the aborted getMore may have been lost, so the next call resumes the change stream.
+		killCursorsReply, err := bson.Marshal(bson.D{{"ok", 1}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		conn.ReadResp = make(chan []byte, 2)
+		conn.ReadResp <- drivertest.MakeReply(killCursorsReply)
+		conn.ReadResp <- changeStreamReply(t, "firstBatch", 0, testChangeEvent("1", "insert"))
+		assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+		assert.Equal(t, 4, len(conn.Written), "expected 4 commands to be sent, got %v", len(conn.Written))
+	})
+	t.Run("WatchChan", func(t *testing.T) {
+		type event struct {
+			ID struct {
+				Data string `bson:"_data"`
+			} `bson:"_id"`
+		}
+		// The server never replies to the killCursors sent after ctx is cancelled, so bound it by CloseTimeout.
+		csOpts := options.ChangeStream().SetResumeAfter(bson.D{{"_data", "0"}}).SetCloseTimeout(10 * time.Millisecond)
+
+		t.Run("delivery order", func(t *testing.T) {
+			client, conn := newChannelConnClient(t, options.Client(),
+				changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert"), testChangeEvent("2", "insert")),
+				changeStreamReply(t, "nextBatch", 1, testChangeEvent("3", "insert")),
+			)
+			// Leave room for the getMore that waits for a reply until ctx is cancelled.
+			conn.Written = make(chan []byte, 10)
+
+			ctx, cancel := context.WithCancel(bgCtx)
+			defer cancel()
+			results := make(chan *event, 10)
+			errs := client.Database("foo").Collection("bar").WatchChan(ctx, results, Pipeline{}, csOpts)
+
+			for _, want := range []string{"1", "2", "3"} {
+				got := <-results
+				assert.Equal(t, want, got.ID.Data, "expected event %v, got %v", want, got.ID.Data)
+			}
+			cancel()
+			for range results {
+			}
+			err := <-errs
+			assert.True(t, errors.Is(err, context.Canceled), "expected error %v, got %v", context.Canceled, err)
+		})
+		t.Run("backpressure", func(t *testing.T) {
+			client, conn := newChannelConnClient(t, options.Client(),
+				changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert")),
+				changeStreamReply(t, "nextBatch", 1, testChangeEvent("2", "insert")),
+				changeStreamReply(t, "nextBatch", 1, testChangeEvent("3", "insert")),
+			)
+			conn.Written = make(chan []byte, 10)
+
+			ctx, cancel := context.WithCancel(bgCtx)
+			defer cancel()
+			results := make(chan event, 1)
+			errs := client.Database("foo").Collection("bar").WatchChan(ctx, results, Pipeline{}, csOpts)
+
+			// The first event fills the buffer and sending the second blocks, so no further getMore is sent until the
+			// slow consumer receives an event.
+			time.Sleep(50 * time.Millisecond)
+			assert.Equal(t, 2, len(conn.Written), "expected 2 commands to be sent, got %v", len(conn.Written))
+			assert.Equal(t, "1", (<-results).ID.Data, "expected event 1")
+			time.Sleep(50 * time.Millisecond)
+			assert.Equal(t, 3, len(conn.Written), "expected 3 commands to be sent, got %v", len(conn.Written))
+			assert.Equal(t, "2", (<-results).ID.Data, "expected event 2")
+			assert.Equal(t, "3", (<-results).ID.Data, "expected event 3")
+
+			cancel()
+			for range results {
+			}
+			<-errs
+		})
+		t.Run("error propagation", func(t *testing.T) {
+			getMoreErr, err := bson.Marshal(bson.D{
+				{"ok", 0},
+				{"code", 2},
+				{"codeName", "BadValue"},
+				{"errmsg", "bad value"},
+			})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			killCursorsReply, err := bson.Marshal(bson.D{{"ok", 1}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			client, _ := newChannelConnClient(t, options.Client(),
+				changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert")),
+				drivertest.MakeReply(getMoreErr),
+				drivertest.MakeReply(killCursorsReply),
+			)
+
+			results := make(chan event, 10)
+			errs := client.Database("foo").Collection("bar").WatchChan(bgCtx, results, Pipeline{}, csOpts)
+
+			var got []string
+			for evt := range results {
+				got = append(got, evt.ID.Data)
+			}
+			assert.Equal(t, []string{"1"}, got, "expected events %v, got %v", []string{"1"}, got)
+			err = <-errs
+			var ce CommandError
+			assert.True(t, errors.As(err, &ce), "expected error type %T, got %T", ce, err)
+			assert.Equal(t, int32(2), ce.Code, "expected error code 2, got %v", ce.Code)
+			_, ok := <-errs
+			assert.False(t, ok, "expected error channel to be closed")
+		})
+		t.Run("results is not a channel", func(t *testing.T) {
+			client, _ := newChannelConnClient(t, options.Client())
+			err := <-client.Database("foo").Collection("bar").WatchChan(bgCtx, []event{}, Pipeline{})
+			assert.NotNil(t, err, "expected error, got nil")
+		})
+	})
+	t.Run("WaitForEvent", func(t *testing.T) {
+		csOpts := options.ChangeStream().SetResumeAfter(bson.D{{"_data", "0"}})
+
+		t.Run("event arrives", func(t *testing.T) {
+			client, _ := newChannelConnClient(t, options.Client(),
+				changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert")),
+			)
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+			assert.Nil(t, err, "Watch error: %v", err)
+
+			event, err := cs.WaitForEvent(bgCtx, time.Second)
+			assert.Nil(t, err, "WaitForEvent error: %v", err)
+			data := event.Lookup("_id", "_data").StringValue()
+			assert.Equal(t, "1", data, "expected event with resume token data 1, got %v", data)
+		})
+		t.Run("timeout", func(t *testing.T) {
+			// The server never replies to the getMore, so the wait times out. The Written channel has room for the
+			// killCursors and aggregate sent when the change stream resumes.
+			client, conn := newChannelConnClient(t, options.Client(), changeStreamReply(t, "firstBatch", 1))
+			conn.Written = make(chan []byte, 4)
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+			assert.Nil(t, err, "Watch error: %v", err)
+
+			start := time.Now()
+			event, err := cs.WaitForEvent(bgCtx, 50*time.Millisecond)
+			elapsed := time.Since(start)
+			assert.Nil(t, event, "expected no event, got %v", event)
+			var wte ChangeStreamWaitTimeoutError
+			assert.True(t, errors.As(err, &wte), "expected error type %T, got %T", wte, err)
+			assert.Equal(t, 50*time.Millisecond, wte.Timeout, "expected timeout %v, got %v", 50*time.Millisecond,
+				wte.Timeout)
+			assert.True(t, IsTimeout(err), "expected IsTimeout to be true for %v", err)
+			assert.True(t, elapsed < 5*time.Second, "expected WaitForEvent to return promptly, took %v", elapsed)
+
+			// The change stream is still usable.
+			killCursorsReply, err := bson.Marshal(bson.D{{"ok", 1}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			conn.ReadResp = make(chan []byte, 2)
+			conn.ReadResp <- drivertest.MakeReply(killCursorsReply)
+			conn.ReadResp <- changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert"))
+			event, err = cs.WaitForEvent(bgCtx, time.Second)
+			assert.Nil(t, err, "WaitForEvent error: %v", err)
+			assert.NotNil(t, event, "expected event, got nil")
+		})
+		t.Run("timeout waiting for getMore slot", func(t *testing.T) {
+			client, conn := newChannelConnClient(t, options.Client().SetMaxConcurrentStreamingGetMores(1),
+				changeStreamReply(t, "firstBatch", 1),
+			)
+			conn.Written = make(chan []byte, 2)
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+			assert.Nil(t, err, "Watch error: %v", err)
+			<-conn.Written // aggregate
+
+			// The only slot is taken, so the wait times out before the getMore is sent.
+			release, err := client.getMoreLimiter.acquire(bgCtx, cs.serverAddr)
+			assert.Nil(t, err, "acquire error: %v", err)
+			_, err = cs.WaitForEvent(bgCtx, 50*time.Millisecond)
+			var wte ChangeStreamWaitTimeoutError
+			assert.True(t, errors.As(err, &wte), "expected error type %T, got %T", wte, err)
+			assert.Nil(t, cs.Err(), "expected no stored error, got %v", cs.Err())
+			release()
+
+			// The change stream continues with a getMore on the same cursor instead of resuming.
+			conn.ReadResp <- changeStreamReply(t, "nextBatch", 1, testChangeEvent("1", "insert"))
+			event, err := cs.WaitForEvent(bgCtx, time.Second)
+			assert.Nil(t, err, "WaitForEvent error: %v", err)
+			assert.NotNil(t, event, "expected event, got nil")
+			assert.Equal(t, 1, len(conn.Written), "expected 1 command to be sent, got %v", len(conn.Written))
+			for len(conn.Written) > 0 {
+				cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+				assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+				assert.Equal(t, "getMore", cmd.Index(0).Key(), "expected getMore, got %v", cmd)
+			}
+		})
+		t.Run("timeout during initial aggregate", func(t *testing.T) {
+			token, err := bson.Marshal(bson.D{{"_data", "0"}})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			client, conn := newChannelConnClient(t, options.Client())
+			conn.Written = make(chan []byte, 2)
+			conn.ReadResp = make(chan []byte, 1)
+			cs, err := NewChangeStreamFromRaw(bgCtx, client.Database("foo").Collection("bar"), token, Pipeline{})
+			assert.Nil(t, err, "NewChangeStreamFromRaw error: %v", err)
+
+			// The server never replies to the aggregate, so the wait times out.
+			_, err = cs.WaitForEvent(bgCtx, 50*time.Millisecond)
+			var wte ChangeStreamWaitTimeoutError
+			assert.True(t, errors.As(err, &wte), "expected error type %T, got %T", wte, err)
+
+			// The aggregate is run again by the next call.
+			conn.ReadResp <- changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert"))
+			event, err := cs.WaitForEvent(bgCtx, time.Second)
+			assert.Nil(t, err, "WaitForEvent error: %v", err)
+			assert.NotNil(t, event, "expected event, got nil")
+			assert.Equal(t, 2, len(conn.Written), "expected 2 commands to be sent, got %v", len(conn.Written))
+			for len(conn.Written) > 0 {
+				cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+				assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+				assert.Equal(t, "aggregate", cmd.Index(0).Key(), "expected aggregate, got %v", cmd)
+			}
+		})
+		t.Run("context cancelled", func(t *testing.T) {
+			client, conn := newChannelConnClient(t, options.Client(), changeStreamReply(t, "firstBatch", 1))
+			conn.Written = make(chan []byte, 2)
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+			assert.Nil(t, err, "Watch error: %v", err)
+
+			ctx, cancel := context.WithCancel(bgCtx)
+			time.AfterFunc(20*time.Millisecond, cancel)
+			_, err = cs.WaitForEvent(ctx, time.Hour)
+			assert.Equal(t, context.Canceled, err, "expected error %v, got %v", context.Canceled, err)
+		})
+		t.Run("closed", func(t *testing.T) {
+			client, _ := newChannelConnClient(t, options.Client(), changeStreamReply(t, "firstBatch", 0))
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+			assert.Nil(t, err, "Watch error: %v", err)
+			assert.Nil(t, cs.Close(bgCtx), "Close error")
+
+			_, err = cs.WaitForEvent(bgCtx, time.Second)
+			assert.Equal(t, ErrNilCursor, err, "expected error %v, got %v", ErrNilCursor, err)
+		})
+	})
+	t.Run("NewChangeStreamFromRaw", func(t *testing.T) {
+		token, err := bson.Marshal(bson.D{{"_data", "0"}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		t.Run("aggregate deferred until Next", func(t *testing.T) {
+			client, conn := newChannelConnClient(t, options.Client(),
+				changeStreamReply(t, "firstBatch", 0, testChangeEvent("1", "insert")),
+			)
+			cs, err := NewChangeStreamFromRaw(bgCtx, client.Database("foo").Collection("bar"), token, Pipeline{})
+			assert.Nil(t, err, "NewChangeStreamFromRaw error: %v", err)
+			defer cs.Close(bgCtx)
+			assert.Equal(t, 0, len(conn.Written), "expected no commands to be sent, got %v", len(conn.Written))
+
+			assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+			assert.Equal(t, 1, len(conn.Written), "expected 1 command to be sent, got %v", len(conn.Written))
+			cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+			assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+			got, err := cmd.LookupErr("pipeline", "0", "$changeStream", "resumeAfter")
+			assert.Nil(t, err, "expected resumeAfter in aggregate %v", cmd)
+			assert.Equal(t, bson.Raw(token), bson.Raw(got.Document()), "expected resumeAfter %v, got %v", bson.Raw(token), got)
+		})
+		t.Run("close before Next", func(t *testing.T) {
+			client, conn := newChannelConnClient(t, options.Client())
+			cs, err := NewChangeStreamFromRaw(bgCtx, client.Database("foo").Collection("bar"), token, Pipeline{})
+			assert.Nil(t, err, "NewChangeStreamFromRaw error: %v", err)
+
+			err = cs.Close(bgCtx)
+			assert.Nil(t, err, "Close error: %v", err)
+			assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false")
+			assert.Equal(t, 0, len(conn.Written), "expected no commands to be sent, got %v", len(conn.Written))
+		})
+		t.Run("invalid token", func(t *testing.T) {
+			client, _ := newChannelConnClient(t, options.Client())
+			coll := client.Database("foo").Collection("bar")
+			for _, tok := range []bson.Raw{nil, token[:len(token)-1]} {
+				_, err := NewChangeStreamFromRaw(bgCtx, coll, tok, Pipeline{})
+				assert.True(t, errors.Is(err, ErrInvalidResumeToken), "expected error %v, got %v", ErrInvalidResumeToken, err)
+			}
+		})
+	})
+	t.Run("getMore read preference", func(t *testing.T) {
+		getMoreErr, err := bson.Marshal(bson.D{
+			{"ok", 0},
+			{"code", 6},
+			{"errmsg", "host unreachable"},
+			{"errorLabels", bson.A{"ResumableChangeStreamError"}},
+		})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		killCursorsReply, err := bson.Marshal(bson.D{{"ok", 1}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		client, conn := newChannelConnClient(t, options.Client(),
+			changeStreamReply(t, "firstBatch", 1),
+			drivertest.MakeReply(getMoreErr),
+			drivertest.MakeReply(killCursorsReply),
+			changeStreamReply(t, "firstBatch", 1),
+		)
+		deployment := &selectorRecordingDeployment{Deployment: client.deployment}
+		client.deployment = deployment
+
+		// Resume so that the empty first batch doesn't need an operation time from the server.
+		csOpts := options.ChangeStream().
+			SetGetMoreReadPreference(readpref.Secondary()).
+			SetResumeAfter(bson.D{{"_data", "0"}})
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+		assert.Nil(t, err, "Watch error: %v", err)
+
+		// The first batch is returned by the aggregate, so the second TryNext sends a getMore that fails and the
+		// change stream resumes.
+		assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false")
+		assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false")
+		assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+		assert.Equal(t, 4, len(conn.Written), "expected 4 commands to be sent, got %v", len(conn.Written))
+		assert.Equal(t, 2, len(deployment.selectors), "expected 2 server selections, got %v", len(deployment.selectors))
+
+		primary := description.Server{Addr: "primary:27017", Kind: description.RSPrimary}
+		secondary := description.Server{Addr: "secondary:27017", Kind: description.RSSecondary}
+		topo := description.Topology{
+			Kind:    description.ReplicaSetWithPrimary,
+			Servers: []description.Server{primary, secondary},
+		}
+		for i, want := range []description.Server{primary, secondary} {
+			selected, err := deployment.selectors[i].SelectServer(topo, topo.Servers)
+			assert.Nil(t, err, "SelectServer error: %v", err)
+			assert.Equal(t, 1, len(selected), "expected 1 selected server, got %v", len(selected))
+			assert.Equal(t, want.Addr, selected[0].Addr, "expected selection %d to be %v, got %v", i, want.Addr, selected[0].Addr)
+		}
+
+		// The getMore and killCursors are sent to the cursor's server without server selection, and the last command
+		// is the resume aggregate.
+		<-conn.Written
+		<-conn.Written
+		<-conn.Written
+		cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+		assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+		_, err = cmd.LookupErr("aggregate")
+		assert.Nil(t, err, "expected aggregate command, got %v", cmd)
+
+		t.Run("retried resume", func(t *testing.T) {
+			aggregateErr, err := bson.Marshal(bson.D{
+				{"ok", 0},
+				{"code", 6},
+				{"errmsg", "host unreachable"},
+			})
+			assert.Nil(t, err, "Marshal error: %v", err)
+			client, conn := newChannelConnClient(t, options.Client(),
+				changeStreamReply(t, "firstBatch", 1),
+				drivertest.MakeReply(getMoreErr),
+				drivertest.MakeReply(killCursorsReply),
+				drivertest.MakeReply(aggregateErr),
+				changeStreamReply(t, "firstBatch", 1),
+			)
+			deployment := &selectorRecordingDeployment{Deployment: client.deployment}
+			client.deployment = deployment
+
+			cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+			assert.Nil(t, err, "Watch error: %v", err)
+
+			// The first resume attempt fails with a retryable error, so the aggregate is retried on a server selected
+			// with the getMore read preference.
+			assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false")
+			assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false")
+			assert.Nil(t, cs.Err(), "change stream error: %v", cs.Err())
+			assert.Equal(t, 5, len(conn.Written), "expected 5 commands to be sent, got %v", len(conn.Written))
+			assert.Equal(t, 3, len(deployment.selectors), "expected 3 server selections, got %v",
+				len(deployment.selectors))
+			for i, want := range []description.Server{primary, secondary, secondary} {
+				selected, err := deployment.selectors[i].SelectServer(topo, topo.Servers)
+				assert.Nil(t, err, "SelectServer error: %v", err)
+				assert.Equal(t, 1, len(selected), "expected 1 selected server, got %v", len(selected))
+				assert.Equal(t, want.Addr, selected[0].Addr, "expected selection %d to be %v, got %v", i, want.Addr,
+					selected[0].Addr)
+			}
+		})
+		t.Run("invalid mode", func(t *testing.T) {
+			client, _ := newChannelConnClient(t, options.Client())
+			csOpts := options.ChangeStream().SetGetMoreReadPreference(&readpref.ReadPref{})
+			_, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, csOpts)
+			assert.NotNil(t, err, "expected Watch error, got nil")
+		})
+	})
+	t.Run("close timeout", func(t *testing.T) {
+		killCursorsReply, err := bson.Marshal(bson.D{{"ok", 1}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+
+		testCases := []struct {
+			name         string
+			opts         *options.ChangeStreamOptions
+			killsCursors bool
+		}{
+			{"not set", options.ChangeStream(), false},
+			{"set", options.ChangeStream().SetCloseTimeout(time.Second), true},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				client, conn := newChannelConnClient(t, options.Client(),
+					changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert")),
+					drivertest.MakeReply(killCursorsReply),
+				)
+				cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{}, tc.opts)
+				assert.Nil(t, err, "Watch error: %v", err)
+				assert.Equal(t, 1, len(conn.Written), "expected 1 command to be sent, got %v", len(conn.Written))
+
+				ctx, cancel := context.WithCancel(bgCtx)
+				cancel()
+				start := time.Now()
+				err = cs.Close(ctx)
+				elapsed := time.Since(start)
+				assert.True(t, elapsed < time.Second, "expected Close to return within 1s, took %v", elapsed)
+
+				if !tc.killsCursors {
+					assert.NotNil(t, err, "expected Close error, got nil")
+					assert.Equal(t, 1, len(conn.Written), "expected no killCursors to be sent, got %v commands", len(conn.Written))
+					return
+				}
+				assert.Nil(t, err, "Close error: %v", err)
+				assert.Equal(t, 2, len(conn.Written), "expected killCursors to be sent, got %v commands", len(conn.Written))
+				<-conn.Written
+				cmd, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+				assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+				_, err = cmd.LookupErr("killCursors")
+				assert.Nil(t, err, "expected killCursors command, got %v", cmd)
+			})
+		}
+	})
+	t.Run("command metadata", func(t *testing.T) {
+		var started []*event.CommandStartedEvent
+		monitor := &event.CommandMonitor{
+			Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+				started = append(started, evt)
+			},
+		}
+		client, _ := newChannelConnClient(t, options.Client().SetMonitor(monitor),
+			changeStreamReply(t, "firstBatch", 1),
+			changeStreamReply(t, "nextBatch", 1, testChangeEvent("1", "insert")),
+			changeStreamReply(t, "nextBatch", 1, testChangeEvent("2", "insert")),
+		)
+
+		metadata := map[string]interface{}{"requestID": "abc", "attempt": 1}
+		ctx := WithCommandMetadata(bgCtx, metadata)
+		csOpts := options.ChangeStream().SetResumeAfter(bson.D{{"_data", "0"}})
+		cs, err := client.Database("foo").Collection("bar").Watch(ctx, Pipeline{}, csOpts)
+		assert.Nil(t, err, "Watch error: %v", err)
+		assert.True(t, cs.Next(ctx), "Next error: %v", cs.Err())
+		assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+
+		assert.Equal(t, 3, len(started), "expected 3 started events, got %v", len(started))
+		for i, name := range []string{"aggregate", "getMore"} {
+			evt := started[i]
+			assert.Equal(t, name, evt.CommandName, "expected %q event, got %q", name, evt.CommandName)
+			assert.Equal(t, metadata, evt.Metadata, "expected metadata %v on %q event, got %v", metadata, name,
+				evt.Metadata)
+		}
+		assert.Nil(t, started[2].Metadata, "expected no metadata without WithCommandMetadata, got %v",
+			started[2].Metadata)
+	})
+	t.Run("tracing", func(t *testing.T) {
+		tracer := &recordingTracer{}
+		client, conn := newChannelConnClient(t, options.Client().SetTracerProvider(tracer),
+			changeStreamReply(t, "firstBatch", 1, testChangeEvent("1", "insert")),
+			changeStreamReply(t, "nextBatch", 1, testChangeEvent("2", "insert"), testChangeEvent("3", "delete")),
+			changeStreamReply(t, "nextBatch", 0),
+		)
+
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{})
+		assert.Nil(t, err, "Watch error: %v", err)
+		defer cs.Close(bgCtx)
+
+		for i := 0; i < 3; i++ {
+			assert.True(t, cs.Next(bgCtx), "Next error: %v", cs.Err())
+		}
+		assert.False(t, cs.TryNext(bgCtx), "expected TryNext to return false")
+		assert.Nil(t, cs.Err(), "TryNext error: %v", cs.Err())
+
+		commonAttrs := []event.SpanAttribute{
+			{Key: event.SpanAttributeDBName, Value: "foo"},
+			{Key: event.SpanAttributeCollectionName, Value: "bar"},
+		}
+		span := func(op string, attrs ...event.SpanAttribute) *recordingSpan {
+			startAttrs := append(append([]event.SpanAttribute{}, commonAttrs...),
+				event.SpanAttribute{Key: event.SpanAttributeOperation, Value: op})
+			return &recordingSpan{name: "changeStream." + op, attrs: append(startAttrs, attrs...), ended: true}
+		}
+		// The first batch is returned by the aggregate, so there is one getMore span per getMore that was sent.
+		want := []*recordingSpan{
+			span("aggregate",
+				event.SpanAttribute{Key: event.SpanAttributeEventCount, Value: int64(1)},
+				event.SpanAttribute{Key: event.SpanAttributeOperationTypePrefix + "insert", Value: int64(1)}),
+			span("getMore",
+				event.SpanAttribute{Key: event.SpanAttributeEventCount, Value: int64(2)},
+				event.SpanAttribute{Key: event.SpanAttributeOperationTypePrefix + "insert", Value: int64(1)},
+				event.SpanAttribute{Key: event.SpanAttributeOperationTypePrefix + "delete", Value: int64(1)}),
+			span("getMore", event.SpanAttribute{Key: event.SpanAttributeEventCount, Value: int64(0)}),
+		}
+		assert.Equal(t, want, tracer.spans, "expected spans %v, got %v", want, tracer.spans)
+		assert.Equal(t, 3, len(conn.Written), "expected 3 commands to be sent, got %v", len(conn.Written))
+	})
+}
+
+// testChangeEvent returns a change event with the given resume token data and operation type.
+func testChangeEvent(tokenData, opType string) bson.D {
+	return bson.D{{"_id", bson.D{{"_data", tokenData}}}, {"operationType", opType}}
+}
+
+// changeStreamReply returns a wire message with the reply to an aggregate or getMore on foo.bar that returns the given
+// events in the batchKey field of the cursor document.
+func changeStreamReply(t *testing.T, batchKey string, cursorID int64, events ...interface{}) []byte {
+	t.Helper()
+
+	raw, err := bson.Marshal(bson.D{
+		{"ok", 1},
+		{"cursor", bson.D{{"id", cursorID}, {"ns", "foo.bar"}, {batchKey, append(bson.A{}, events...)}}},
+	})
+	assert.Nil(t, err, "Marshal error: %v", err)
+	return drivertest.MakeReply(raw)
+}
+
+// newChannelConnClient returns a Client created with clientOpts whose only connection replies to commands with the
+// given wire messages in order.
+func newChannelConnClient(
+	t *testing.T,
+	clientOpts *options.ClientOptions,
+	replies ...[]byte,
+) (*Client, *drivertest.ChannelConn) {
+	t.Helper()
+
+	conn := &drivertest.ChannelConn{
+		Written:  make(chan []byte, len(replies)),
+		ReadResp: make(chan []byte, len(replies)),
+		Desc: description.Server{
+			Kind:        description.Standalone,
+			WireVersion: &description.VersionRange{Min: 6, Max: 17},
+		},
+	}
+	for _, reply := range replies {
+		conn.ReadResp <- reply
+	}
+
+	clientOpts.Deployment = driver.SingleConnectionDeployment{C: conn}
+	client, err := NewClient(clientOpts)
+	assert.Nil(t, err, "NewClient error: %v", err)
+	return client, conn
 }
+
+// selectorRecordingDeployment is a driver.Deployment that records the server selectors passed to SelectServer.
+type selectorRecordingDeployment struct {
+	driver.Deployment
+	selectors []description.ServerSelector
+}
+
+func (d *selectorRecordingDeployment) SelectServer(
+	ctx context.Context,
+	selector description.ServerSelector,
+) (driver.Server, error) {
+	d.selectors = append(d.selectors, selector)
+	return d.Deployment.SelectServer(ctx, selector)
+}
+
+// countingDeployment is a driver.Deployment with a single server that creates a new connection for every checkout and
+// records how many connections are checked out at once. Aggregates return an empty first batch, getMores block until
+// release is closed and then return one event, and all other commands succeed.
+type countingDeployment struct {
+	t       *testing.T
+	release chan struct{}
+
+	mu            sync.Mutex
+	checkedOut    int
+	maxCheckedOut int
+	getMores      int
+}
+
+type countingStats struct {
+	checkedOut    int
+	maxCheckedOut int
+	getMores      int
+}
+
+var _ driver.Deployment = (*countingDeployment)(nil)
+var _ driver.Server = (*countingDeployment)(nil)
+
+func newCountingDeployment(t *testing.T) *countingDeployment {
+	return &countingDeployment{t: t, release: make(chan struct{})}
+}
+
+func (d *countingDeployment) SelectServer(context.Context, description.ServerSelector) (driver.Server, error) {
+	return d, nil
+}
+
+func (d *countingDeployment) Kind() description.TopologyKind { return description.Single }
+
+func (d *countingDeployment) Connection(context.Context) (driver.Connection, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.checkedOut++
+	if d.checkedOut > d.maxCheckedOut {
+		d.maxCheckedOut = d.checkedOut
+	}
+	return &countingConn{
+		ChannelConn: &drivertest.ChannelConn{
+			Desc: description.Server{
+				Addr:        "counting:27017",
+				Kind:        description.Standalone,
+				WireVersion: &description.VersionRange{Min: 6, Max: 17},
+			},
+		},
+		deployment: d,
+	}, nil
+}
+
+func (d *countingDeployment) RTTMonitor() driver.RTTMonitor { return &internal.ZeroRTTMonitor{} }
+
+func (d *countingDeployment) stats() countingStats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return countingStats{checkedOut: d.checkedOut, maxCheckedOut: d.maxCheckedOut, getMores: d.getMores}
+}
+
+// countingConn is a connection created by countingDeployment.
+type countingConn struct {
+	*drivertest.ChannelConn
+	deployment *countingDeployment
+	command    string
+	closeOnce  sync.Once
+}
+
+func (c *countingConn) WriteWireMessage(_ context.Context, wm []byte) error {
+	cmd, err := drivertest.GetCommandFromMsgWireMessage(wm)
+	if err != nil {
+		return err
+	}
+	c.command = cmd.Index(0).Key()
+	return nil
+}
+
+func (c *countingConn) ReadWireMessage(ctx context.Context) ([]byte, error) {
+	d := c.deployment
+	switch c.command {
+	case "aggregate":
+		return changeStreamReply(d.t, "firstBatch", 1), nil
+	case "getMore":
+		d.mu.Lock()
+		d.getMores++
+		d.mu.Unlock()
+
+		select {
+		case <-d.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		return changeStreamReply(d.t, "nextBatch", 1, testChangeEvent("1", "insert")), nil
+	}
+
+	reply, err := bson.Marshal(bson.D{{"ok", 1}})
+	if err != nil {
+		return nil, err
+	}
+	return drivertest.MakeReply(reply), nil
+}
+
+func (c *countingConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.deployment.mu.Lock()
+		c.deployment.checkedOut--
+		c.deployment.mu.Unlock()
+	})
+	return nil
+}
+
+// recordingTracer is an event.TracerProvider and event.Tracer that records the spans it creates in memory.
+type recordingTracer struct {
+	spans []*recordingSpan
+}
+
+func (rt *recordingTracer) Tracer(string) event.Tracer {
+	return rt
+}
+
+func (rt *recordingTracer) Start(
+	ctx context.Context,
+	name string,
+	attrs ...event.SpanAttribute,
+) (context.Context, event.Span) {
+	span := &recordingSpan{name: name, attrs: attrs}
+	rt.spans = append(rt.spans, span)
+	return ctx, span
+}
+
+type recordingSpan struct {
+	name  string
+	attrs []event.SpanAttribute
+	err   error
+	ended bool
+}
+
+func (rs *recordingSpan) SetAttributes(attrs ...event.SpanAttribute) {
+	rs.attrs = append(rs.attrs, attrs...)
+}
+
+func (rs *recordingSpan) RecordError(err error) {
+	rs.err = err
+}
+
+func (rs *recordingSpan) End() {
+	rs.ended = true
+}
+
+func TestEventStream(t *testing.T) {
+	// drainEvents is a consumer written against EventStream that returns the operation types of the events in s and
+	// the final resume token.
+	drainEvents := func(s EventStream) ([]string, bson.Raw, error) {
+		defer s.Close(bgCtx)
+
+		var opTypes []string
+		for s.Next(bgCtx) {
+			var ev struct {
+				OperationType string `bson:"operationType"`
+			}
+			if err := s.Decode(&ev); err != nil {
+				return nil, nil, err
+			}
+			opTypes = append(opTypes, ev.OperationType)
+		}
+		return opTypes, s.ResumeToken(), s.Err()
+	}
+
+	t.Run("fake", func(t *testing.T) {
+		fake := &fakeEventStream{events: []bson.D{
+			testChangeEvent("1", "insert"),
+			testChangeEvent("2", "delete"),
+		}}
+		opTypes, token, err := drainEvents(fake)
+		assert.Nil(t, err, "drainEvents error: %v", err)
+		assert.Equal(t, []string{"insert", "delete"}, opTypes, "expected operation types do not match")
+		assert.Equal(t, "2", token.Lookup("_data").StringValue(), "expected resume token %q, got %v", "2", token)
+		assert.True(t, fake.closed, "expected fake stream to be closed")
+	})
+	t.Run("ChangeStream", func(t *testing.T) {
+		client, _ := newChannelConnClient(t, options.Client(),
+			changeStreamReply(t, "firstBatch", 0, testChangeEvent("1", "insert"), testChangeEvent("2", "delete")),
+		)
+		cs, err := client.Database("foo").Collection("bar").Watch(bgCtx, Pipeline{})
+		assert.Nil(t, err, "Watch error: %v", err)
+
+		opTypes, token, err := drainEvents(cs)
+		assert.Nil(t, err, "drainEvents error: %v", err)
+		assert.Equal(t, []string{"insert", "delete"}, opTypes, "expected operation types do not match")
+		assert.Equal(t, "2", token.Lookup("_data").StringValue(), "expected resume token %q, got %v", "2", token)
+	})
+}
+
+// fakeEventStream is an EventStream that returns a fixed list of events.
+type fakeEventStream struct {
+	events  []bson.D
+	current bson.Raw
+	token   bson.Raw
+	err     error
+	closed  bool
+}
+
+var _ EventStream = (*fakeEventStream)(nil)
+
+func (f *fakeEventStream) Next(context.Context) bool {
+	if f.closed || f.err != nil || len(f.events) == 0 {
+		return false
+	}
+	if f.current, f.err = bson.Marshal(f.events[0]); f.err != nil {
+		return false
+	}
+	f.events = f.events[1:]
+	f.token = f.current.Lookup("_id").Document()
+	return true
+}
+
+func (f *fakeEventStream) TryNext(ctx context.Context) bool { return f.Next(ctx) }
+
+func (f *fakeEventStream) Decode(val interface{}) error { return bson.Unmarshal(f.current, val) }
+
+func (f *fakeEventStream) ResumeToken() bson.Raw { return f.token }
+
+func (f *fakeEventStream) Err() error { return f.err }
+
+func (f *fakeEventStream) Close(context.Context) error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeEventStream) ID() int64 { return 0 }
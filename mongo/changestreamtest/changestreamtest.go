@@ -0,0 +1,200 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+// Package changestreamtest provides helpers for testing code that consumes change streams without a live server.
+//
+// A RecordingStream wraps a mongo.ChangeStream and writes every event it returns, along with the resume token cached
+// after the event, to an io.Writer. A PlaybackStream reads that recording and returns the same events and resume
+// tokens in the same order. Both types implement the mongo.EventStream interface, so consumers written against
+// mongo.EventStream can be tested deterministically by replaying a recording.
+//
+// A recording is a sequence of BSON documents, one per event, of the form
+//
+//	{"event": <event document>, "resumeToken": <resume token document or null>}
+//
+// written back to back with no separators.
+package changestreamtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+var (
+	_ mongo.EventStream = (*RecordingStream)(nil)
+	_ mongo.EventStream = (*PlaybackStream)(nil)
+)
+
+// RecordingStream is a mongo.EventStream that returns the events of a mongo.ChangeStream and records each of them to
+// an io.Writer. RecordingStream is not goroutine safe.
+type RecordingStream struct {
+	// Current is the BSON bytes of the current event. It is only valid until the next call to Next or TryNext.
+	Current bson.Raw
+
+	cs       *mongo.ChangeStream
+	w        io.Writer
+	writeErr error
+}
+
+// NewRecordingStream creates a RecordingStream that returns the events of cs and writes each of them to w.
+func NewRecordingStream(cs *mongo.ChangeStream, w io.Writer) *RecordingStream {
+	return &RecordingStream{cs: cs, w: w}
+}
+
+// Next calls Next on the wrapped change stream and records the event if one is returned. If the event cannot be
+// written, Next returns false and the write error is returned by Err.
+func (rs *RecordingStream) Next(ctx context.Context) bool {
+	return rs.record(rs.cs.Next(ctx))
+}
+
+// TryNext calls TryNext on the wrapped change stream and records the event if one is returned. If the event cannot be
+// written, TryNext returns false and the write error is returned by Err.
+func (rs *RecordingStream) TryNext(ctx context.Context) bool {
+	return rs.record(rs.cs.TryNext(ctx))
+}
+
+func (rs *RecordingStream) record(ok bool) bool {
+	if rs.writeErr != nil || !ok {
+		return false
+	}
+
+	rs.Current = rs.cs.Current
+	if _, rs.writeErr = rs.w.Write(newRecord(rs.Current, rs.cs.ResumeToken())); rs.writeErr != nil {
+		rs.writeErr = fmt.Errorf("error writing change stream event: %w", rs.writeErr)
+		return false
+	}
+	return true
+}
+
+// Decode decodes the current event into val using the wrapped change stream.
+func (rs *RecordingStream) Decode(val interface{}) error {
+	return rs.cs.Decode(val)
+}
+
+// Err returns the error from writing the most recent event, if any, or the error of the wrapped change stream.
+func (rs *RecordingStream) Err() error {
+	if rs.writeErr != nil {
+		return rs.writeErr
+	}
+	return rs.cs.Err()
+}
+
+// Close closes the wrapped change stream. It does not close the io.Writer.
+func (rs *RecordingStream) Close(ctx context.Context) error {
+	return rs.cs.Close(ctx)
+}
+
+// ResumeToken returns the resume token cached by the wrapped change stream.
+func (rs *RecordingStream) ResumeToken() bson.Raw {
+	return rs.cs.ResumeToken()
+}
+
+// ID returns the ID of the wrapped change stream.
+func (rs *RecordingStream) ID() int64 {
+	return rs.cs.ID()
+}
+
+// newRecord returns the recording of a single event and the resume token cached after it.
+func newRecord(event, token bson.Raw) []byte {
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	doc = bsoncore.AppendDocumentElement(doc, "event", event)
+	if token != nil {
+		doc = bsoncore.AppendDocumentElement(doc, "resumeToken", token)
+	} else {
+		doc = bsoncore.AppendNullElement(doc, "resumeToken")
+	}
+	doc, _ = bsoncore.AppendDocumentEnd(doc, idx)
+	return doc
+}
+
+// PlaybackStream is a mongo.EventStream that returns the events recorded by a RecordingStream. PlaybackStream is not
+// goroutine safe.
+type PlaybackStream struct {
+	// Current is the BSON bytes of the current event. It is only valid until the next call to Next or TryNext.
+	Current bson.Raw
+
+	r           io.Reader
+	resumeToken bson.Raw
+	err         error
+	closed      bool
+}
+
+// NewPlaybackStream creates a PlaybackStream that reads a recording from r. Records are read one at a time as the
+// stream is iterated.
+func NewPlaybackStream(r io.Reader) *PlaybackStream {
+	return &PlaybackStream{r: r}
+}
+
+// Next reads the next recorded event. It returns false with Err returning nil once the end of the recording is
+// reached, or false with Err returning an error if the recording is malformed.
+func (ps *PlaybackStream) Next(context.Context) bool {
+	if ps.err != nil || ps.closed {
+		return false
+	}
+
+	rec, err := bson.ReadDocument(ps.r)
+	if errors.Is(err, io.EOF) {
+		return false
+	}
+	if err != nil {
+		ps.err = fmt.Errorf("error reading change stream recording: %w", err)
+		return false
+	}
+
+	event, ok := rec.Lookup("event").DocumentOK()
+	if !ok {
+		ps.err = fmt.Errorf("change stream recording has no event document: %v", rec)
+		return false
+	}
+	tokenVal := rec.Lookup("resumeToken")
+	token, ok := tokenVal.DocumentOK()
+	if !ok && tokenVal.Type != bsontype.Null {
+		ps.err = fmt.Errorf("change stream recording has an invalid resume token: %v", rec)
+		return false
+	}
+
+	ps.Current = event
+	ps.resumeToken = token
+	return true
+}
+
+// TryNext is the same as Next because a recording never has to wait for events.
+func (ps *PlaybackStream) TryNext(ctx context.Context) bool {
+	return ps.Next(ctx)
+}
+
+// Decode unmarshals the current event into val.
+func (ps *PlaybackStream) Decode(val interface{}) error {
+	return bson.Unmarshal(ps.Current, val)
+}
+
+// Err returns the error from reading the recording, if any.
+func (ps *PlaybackStream) Err() error {
+	return ps.err
+}
+
+// Close stops the playback. Next and TryNext return false after Close is called. It does not close the io.Reader.
+func (ps *PlaybackStream) Close(context.Context) error {
+	ps.closed = true
+	return nil
+}
+
+// ResumeToken returns the resume token recorded with the current event, or nil if no event has been returned.
+func (ps *PlaybackStream) ResumeToken() bson.Raw {
+	return ps.resumeToken
+}
+
+// ID always returns 0 because a recording has no server-side cursor.
+func (ps *PlaybackStream) ID() int64 {
+	return 0
+}
@@ -0,0 +1,130 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package changestreamtest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/description"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
+)
+
+type testEvent struct {
+	OperationType string `bson:"operationType"`
+	DocumentKey   struct {
+		ID int `bson:"_id"`
+	} `bson:"documentKey"`
+}
+
+func TestRecordAndPlayback(t *testing.T) {
+	ctx := context.Background()
+
+	events := bson.A{}
+	for i := 0; i < 5; i++ {
+		events = append(events, bson.D{
+			{"_id", bson.D{{"_data", fmt.Sprint(i)}}},
+			{"operationType", "insert"},
+			{"documentKey", bson.D{{"_id", i}}},
+		})
+	}
+	reply, err := bson.Marshal(bson.D{
+		{"ok", 1},
+		{"cursor", bson.D{{"id", int64(0)}, {"ns", "foo.bar"}, {"firstBatch", events}}},
+	})
+	assert.Nil(t, err, "Marshal error: %v", err)
+
+	conn := &drivertest.ChannelConn{
+		Written:  make(chan []byte, 1),
+		ReadResp: make(chan []byte, 1),
+		Desc: description.Server{
+			Kind:        description.Standalone,
+			WireVersion: &description.VersionRange{Min: 6, Max: 17},
+		},
+	}
+	conn.ReadResp <- drivertest.MakeReply(reply)
+	clientOpts := options.Client()
+	clientOpts.Deployment = driver.SingleConnectionDeployment{C: conn}
+	client, err := mongo.NewClient(clientOpts)
+	assert.Nil(t, err, "NewClient error: %v", err)
+
+	cs, err := client.Database("foo").Collection("bar").Watch(ctx, mongo.Pipeline{})
+	assert.Nil(t, err, "Watch error: %v", err)
+
+	type step struct {
+		event bson.Raw
+		token bson.Raw
+		value testEvent
+	}
+	iterate := func(s mongo.EventStream, current func() bson.Raw) []step {
+		var steps []step
+		for s.Next(ctx) {
+			var value testEvent
+			err := s.Decode(&value)
+			assert.Nil(t, err, "Decode error: %v", err)
+			steps = append(steps, step{
+				event: append(bson.Raw{}, current()...),
+				token: append(bson.Raw{}, s.ResumeToken()...),
+				value: value,
+			})
+		}
+		assert.Nil(t, s.Err(), "stream error: %v", s.Err())
+		err := s.Close(ctx)
+		assert.Nil(t, err, "Close error: %v", err)
+		return steps
+	}
+
+	buf := new(bytes.Buffer)
+	rs := NewRecordingStream(cs, buf)
+	recorded := iterate(rs, func() bson.Raw { return rs.Current })
+	assert.Equal(t, 5, len(recorded), "expected 5 recorded events, got %v", len(recorded))
+	for i, s := range recorded {
+		assert.Equal(t, i, s.value.DocumentKey.ID, "expected event %v to have _id %v, got %v", i, i, s.value.DocumentKey.ID)
+	}
+
+	ps := NewPlaybackStream(bytes.NewReader(buf.Bytes()))
+	replayed := iterate(ps, func() bson.Raw { return ps.Current })
+	assert.Equal(t, recorded, replayed, "expected replayed events to match recorded events")
+	assert.False(t, ps.Next(ctx), "expected Next to return false after Close")
+}
+
+func TestPlaybackStreamErrors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("truncated recording", func(t *testing.T) {
+		rec := newRecord(bson.Raw(bsonDoc(t, bson.D{{"operationType", "insert"}})), nil)
+		ps := NewPlaybackStream(bytes.NewReader(rec[:len(rec)-1]))
+		assert.False(t, ps.Next(ctx), "expected Next to return false")
+		assert.NotNil(t, ps.Err(), "expected error, got nil")
+	})
+	t.Run("missing event", func(t *testing.T) {
+		ps := NewPlaybackStream(bytes.NewReader(bsonDoc(t, bson.D{{"resumeToken", nil}})))
+		assert.False(t, ps.Next(ctx), "expected Next to return false")
+		assert.NotNil(t, ps.Err(), "expected error, got nil")
+	})
+	t.Run("null resume token", func(t *testing.T) {
+		rec := newRecord(bson.Raw(bsonDoc(t, bson.D{{"operationType", "insert"}})), nil)
+		ps := NewPlaybackStream(bytes.NewReader(rec))
+		assert.True(t, ps.Next(ctx), "Next error: %v", ps.Err())
+		assert.Nil(t, ps.ResumeToken(), "expected nil resume token, got %v", ps.ResumeToken())
+	})
+}
+
+func bsonDoc(t *testing.T, val interface{}) []byte {
+	t.Helper()
+
+	doc, err := bson.Marshal(val)
+	assert.Nil(t, err, "Marshal error: %v", err)
+	return doc
+}
@@ -19,6 +19,7 @@ import (
 	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/internal/logger"
 	"go.mongodb.org/mongo-driver/internal/uuid"
+	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
@@ -52,24 +53,34 @@ var (
 // The Client type opens and closes connections automatically and maintains a pool of idle connections. For
 // connection pool configuration options, see documentation for the ClientOptions type in the mongo/options package.
 type Client struct {
-	id             uuid.UUID
-	deployment     driver.Deployment
-	localThreshold time.Duration
-	retryWrites    bool
-	retryReads     bool
-	clock          *session.ClusterClock
-	readPreference *readpref.ReadPref
-	readConcern    *readconcern.ReadConcern
-	writeConcern   *writeconcern.WriteConcern
-	bsonOpts       *options.BSONOptions
-	registry       *bsoncodec.Registry
-	monitor        *event.CommandMonitor
-	serverAPI      *driver.ServerAPIOptions
-	serverMonitor  *event.ServerMonitor
-	sessionPool    *session.Pool
-	timeout        *time.Duration
-	httpClient     *http.Client
-	logger         *logger.Logger
+	id               uuid.UUID
+	deployment       driver.Deployment
+	localThreshold   time.Duration
+	maxPoolSize      uint64
+	compressors      []string
+	retryWrites      bool
+	retryReads       bool
+	maxRetryDuration *time.Duration
+	clock            *session.ClusterClock
+	readPreference   *readpref.ReadPref
+	readConcern      *readconcern.ReadConcern
+	writeConcern     *writeconcern.WriteConcern
+	bsonOpts         *options.BSONOptions
+	registry         *bsoncodec.Registry
+	monitor          *event.CommandMonitor
+	serverAPI        *driver.ServerAPIOptions
+	requestIDFn      func() string
+	watchConsistent  bool
+	tracer           event.Tracer
+	serverMonitor    *event.ServerMonitor
+	sessionPool      *session.Pool
+	timeout          *time.Duration
+	httpClient       *http.Client
+	logger           *logger.Logger
+
+	// getMoreLimiter limits the number of concurrent change stream getMores per server. It is nil if the
+	// MaxConcurrentStreamingGetMores option is not set.
+	getMoreLimiter *getMoreLimiter
 
 	// client-side encryption fields
 	keyVaultClientFLE  *Client
@@ -183,8 +194,19 @@ func NewClient(opts ...*options.ClientOptions) (*Client, error) {
 	if clientOpt.RetryReads != nil {
 		client.retryReads = *clientOpt.RetryReads
 	}
+	client.maxRetryDuration = clientOpt.MaxRetryDuration
 	// Timeout
 	client.timeout = clientOpt.Timeout
+	// RequestIDFunc
+	client.requestIDFn = clientOpt.RequestIDFunc
+	// DefaultCausalConsistencyForWatch
+	if clientOpt.DefaultCausalConsistencyForWatch != nil {
+		client.watchConsistent = *clientOpt.DefaultCausalConsistencyForWatch
+	}
+	// TracerProvider
+	if clientOpt.TracerProvider != nil {
+		client.tracer = clientOpt.TracerProvider.Tracer("go.mongodb.org/mongo-driver")
+	}
 	client.httpClient = clientOpt.HTTPClient
 	// WriteConcern
 	if clientOpt.WriteConcern != nil {
@@ -208,6 +230,11 @@ func NewClient(opts ...*options.ClientOptions) (*Client, error) {
 	if clientOpt.MaxPoolSize == nil {
 		clientOpt.SetMaxPoolSize(defaultMaxPoolSize)
 	}
+	client.maxPoolSize = *clientOpt.MaxPoolSize
+	client.compressors = clientOpt.Compressors
+	if clientOpt.MaxConcurrentStreamingGetMores != nil && *clientOpt.MaxConcurrentStreamingGetMores > 0 {
+		client.getMoreLimiter = newGetMoreLimiter(*clientOpt.MaxConcurrentStreamingGetMores)
+	}
 
 	if err != nil {
 		return nil, err
@@ -369,6 +396,79 @@ func (c *Client) Ping(ctx context.Context, rp *readpref.ReadPref) error {
 	return replaceErrors(res.Err())
 }
 
+// Warmup opens connections to the server selected by the Client's read preference so that later operations, such as
+// the aggregate run by the first call to Watch, do not wait for connections to be established. When Warmup returns
+// successfully, the server's connection pool holds at least minConns idle connections, or MaxPoolSize connections if
+// that is smaller and not 0. Connections are created the same way as for any other operation, so the usual pool
+// events are published for them.
+//
+// The connections are subject to MaxConnIdleTime like any other idle connection.
+func (c *Client) Warmup(ctx context.Context, minConns int) error {
+	if c.sessionPool == nil {
+		return ErrClientDisconnected
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if c.maxPoolSize != 0 && uint64(minConns) > c.maxPoolSize {
+		minConns = int(c.maxPoolSize)
+	}
+	if minConns <= 0 {
+		return nil
+	}
+
+	selector := description.CompositeSelector([]description.ServerSelector{
+		description.ReadPrefSelector(c.readPreference),
+		description.LatencySelector(c.localThreshold),
+	})
+	server, err := c.deployment.SelectServer(ctx, selector)
+	if err != nil {
+		return replaceErrors(err)
+	}
+
+	// Hold every connection until all have been checked out so that the pool has to create new connections instead
+	// of handing out the same idle one again.
+	conns := make([]driver.Connection, 0, minConns)
+	defer func() {
+		for _, conn := range conns {
+			_ = conn.Close()
+		}
+	}()
+	for len(conns) < minConns {
+		conn, err := server.Connection(ctx)
+		if err != nil {
+			return replaceErrors(err)
+		}
+		conns = append(conns, conn)
+	}
+	return nil
+}
+
+// NegotiatedCompressor returns the name of the compressor used for messages sent to the server at serverAddr, as
+// negotiated during the handshake from the Compressors client option and the compressors the server supports. An
+// empty name with ok set to true means that messages to the server are not compressed.
+//
+// ok is false if the Client has not yet completed a handshake with the server, if the server is not part of the
+// Client's deployment, or if the Client is connected to a load balancer or uses a custom deployment.
+func (c *Client) NegotiatedCompressor(serverAddr string) (name string, ok bool) {
+	topo, isTopology := c.deployment.(*topology.Topology)
+	if !isTopology {
+		return "", false
+	}
+
+	addr := address.Address(serverAddr).Canonicalize()
+	for _, desc := range topo.Description().Servers {
+		if desc.Addr.Canonicalize() != addr {
+			continue
+		}
+		if desc.Kind == description.Unknown || desc.Kind == description.LoadBalancer {
+			return "", false
+		}
+		return driver.NegotiateCompressor(c.compressors, desc.Compression), true
+	}
+	return "", false
+}
+
 // StartSession starts a new session configured with the given options.
 //
 // StartSession does not actually communicate with the server and will not error if the client is
@@ -433,7 +533,7 @@ func (c *Client) endSessions(ctx context.Context) {
 	sessionIDs := c.sessionPool.IDSlice()
 	op := operation.NewEndSessions(nil).ClusterClock(c.clock).Deployment(c.deployment).
 		ServerSelector(description.ReadPrefSelector(readpref.PrimaryPreferred())).CommandMonitor(c.monitor).
-		Database("admin").Crypt(c.cryptFLE).ServerAPI(c.serverAPI)
+		Database("admin").Crypt(c.cryptFLE).ServerAPI(c.serverAPI).RequestIDFn(c.requestIDFn)
 
 	totalNumIDs := len(sessionIDs)
 	var currentBatch []bsoncore.Document
@@ -694,7 +794,7 @@ func (c *Client) ListDatabases(ctx context.Context, filter interface{}, opts ...
 	op := operation.NewListDatabases(filterDoc).
 		Session(sess).ReadPreference(c.readPreference).CommandMonitor(c.monitor).
 		ServerSelector(selector).ClusterClock(c.clock).Database("admin").Deployment(c.deployment).Crypt(c.cryptFLE).
-		ServerAPI(c.serverAPI).Timeout(c.timeout)
+		ServerAPI(c.serverAPI).RequestIDFn(c.requestIDFn).Timeout(c.timeout)
 
 	if ldo.NameOnly != nil {
 		op = op.NameOnly(*ldo.NameOnly)
@@ -707,7 +807,7 @@ func (c *Client) ListDatabases(ctx context.Context, filter interface{}, opts ...
 	if c.retryReads {
 		retry = driver.RetryOncePerCommand
 	}
-	op.Retry(retry)
+	op.Retry(retry).MaxRetryDuration(c.maxRetryDuration)
 
 	err = op.Execute(ctx)
 	if err != nil {
@@ -791,7 +891,8 @@ func (c *Client) UseSessionWithOptions(ctx context.Context, opts *options.Sessio
 // https://www.mongodb.com/docs/manual/changeStreams/ for more information about change streams.
 //
 // The client must be configured with read concern majority or no read concern for a change stream to be created
-// successfully.
+// successfully. Watch returns an error without contacting the server if the read concern level is one that change
+// streams never support (available, linearizable, or snapshot).
 //
 // The pipeline parameter must be an array of documents, each representing a pipeline stage. The pipeline cannot be
 // nil or empty. The stage documents must all be non-nil. See https://www.mongodb.com/docs/manual/changeStreams/ for a list
@@ -838,6 +939,7 @@ func (c *Client) createBaseCursorOptions() driver.CursorOptions {
 		CommandMonitor: c.monitor,
 		Crypt:          c.cryptFLE,
 		ServerAPI:      c.serverAPI,
+		RequestIDFn:    c.requestIDFn,
 	}
 }
 
@@ -298,6 +298,11 @@ func TestClient(t *testing.T) {
 		client := setupClient(options.Client().SetServerMonitor(monitor))
 		assert.Equal(t, monitor, client.serverMonitor, "expected sdam monitor %v, got %v", monitor, client.serverMonitor)
 	})
+	t.Run("Warmup requires a connected client", func(t *testing.T) {
+		client := setupClient()
+		err := client.Warmup(bgCtx, 1)
+		assert.Equal(t, ErrClientDisconnected, err, "expected error %v, got %v", ErrClientDisconnected, err)
+	})
 	t.Run("GetURI", func(t *testing.T) {
 		t.Run("ApplyURI not called", func(t *testing.T) {
 			opts := options.Client().SetHosts([]string{"localhost:27017"})
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -42,6 +43,7 @@ type Collection struct {
 	writeSelector  description.ServerSelector
 	bsonOpts       *options.BSONOptions
 	registry       *bsoncodec.Registry
+	countCache     *estimatedCountCache
 }
 
 // aggregateParams is used to store information to configure an Aggregate operation.
@@ -117,6 +119,7 @@ func newCollection(db *Database, name string, opts ...*options.CollectionOptions
 		writeSelector:  writeSelector,
 		bsonOpts:       bsonOpts,
 		registry:       reg,
+		countCache:     newEstimatedCountCache(),
 	}
 
 	return coll
@@ -133,6 +136,7 @@ func (coll *Collection) copy() *Collection {
 		readSelector:   coll.readSelector,
 		writeSelector:  coll.writeSelector,
 		registry:       coll.registry,
+		countCache:     newEstimatedCountCache(),
 	}
 }
 
@@ -286,36 +290,86 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 
 	selector := makePinnedSelector(sess, coll.writeSelector)
 
-	op := operation.NewInsert(docs...).
-		Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).
-		ServerSelector(selector).ClusterClock(coll.client.clock).
-		Database(coll.db.name).Collection(coll.name).
-		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Ordered(true).
-		ServerAPI(coll.client.serverAPI).Timeout(coll.client.timeout).Logger(coll.client.logger)
 	imo := options.MergeInsertManyOptions(opts...)
-	if imo.BypassDocumentValidation != nil && *imo.BypassDocumentValidation {
-		op = op.BypassDocumentValidation(*imo.BypassDocumentValidation)
-	}
+	var comment bsoncore.Value
 	if imo.Comment != nil {
-		comment, err := marshalValue(imo.Comment, coll.bsonOpts, coll.registry)
+		comment, err = marshalValue(imo.Comment, coll.bsonOpts, coll.registry)
 		if err != nil {
 			return nil, err
 		}
-		op = op.Comment(comment)
-	}
-	if imo.Ordered != nil {
-		op = op.Ordered(*imo.Ordered)
 	}
+	ordered := imo.Ordered == nil || *imo.Ordered
 	retry := driver.RetryNone
 	if coll.client.retryWrites {
 		retry = driver.RetryOncePerCommand
 	}
-	op = op.Retry(retry)
 
-	err = op.Execute(ctx)
-	wce, ok := err.(driver.WriteCommandError)
-	if !ok {
-		return result, err
+	chunks := [][]bsoncore.Document{docs}
+	if imo.MaxBatchBytes != nil && *imo.MaxBatchBytes > 0 {
+		chunks = splitDocumentsByBytes(docs, *imo.MaxBatchBytes)
+	}
+
+	var wce driver.WriteCommandError
+	var execErr error
+	var offset int64
+	for _, chunk := range chunks {
+		op := operation.NewInsert(chunk...).
+			Session(sess).WriteConcern(wc).CommandMonitor(coll.client.monitor).
+			ServerSelector(selector).ClusterClock(coll.client.clock).
+			Database(coll.db.name).Collection(coll.name).
+			Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Ordered(ordered).
+			ServerAPI(coll.client.serverAPI).
+			RequestIDFn(coll.client.requestIDFn).Timeout(coll.client.timeout).Logger(coll.client.logger)
+		if imo.BypassDocumentValidation != nil && *imo.BypassDocumentValidation {
+			op = op.BypassDocumentValidation(*imo.BypassDocumentValidation)
+		}
+		if imo.Comment != nil {
+			op = op.Comment(comment)
+		}
+		op = op.Retry(retry).MaxRetryDuration(coll.client.maxRetryDuration)
+
+		err = op.Execute(ctx)
+		if err == nil {
+			offset += int64(len(chunk))
+			continue
+		}
+		if err == driver.ErrUnacknowledgedWrite {
+			// Unacknowledged commands do not report errors, so send the remaining commands as well.
+			execErr = err
+			offset += int64(len(chunk))
+			continue
+		}
+		chunkWCE, ok := err.(driver.WriteCommandError)
+		if !ok {
+			// The documents of this command may or may not have been inserted and those of the remaining commands were
+			// not sent, so only the IDs of the documents sent by the commands that succeeded are returned.
+			execErr = err
+			result = result[:offset]
+			break
+		}
+
+		// Merge the errors of each command, making write error indexes relative to the full documents slice.
+		for _, we := range chunkWCE.WriteErrors {
+			we.Index += offset
+			wce.WriteErrors = append(wce.WriteErrors, we)
+		}
+		if chunkWCE.WriteConcernError != nil {
+			wce.WriteConcernError = chunkWCE.WriteConcernError
+		}
+		wce.Labels = append(wce.Labels, chunkWCE.Labels...)
+		wce.Raw = chunkWCE.Raw
+		offset += int64(len(chunk))
+		if ordered && len(chunkWCE.WriteErrors) > 0 {
+			// Nothing after the failed document was inserted, including the documents of the remaining chunks.
+			break
+		}
+	}
+	if wce.WriteErrors == nil && wce.WriteConcernError == nil {
+		return result, execErr
+	}
+	err = wce
+	if execErr != nil {
+		err = execErr
 	}
 
 	// remove the ids that had writeErrors from result
@@ -323,7 +377,7 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 		// i indexes have been removed before the current error, so the index is we.Index-i
 		idIndex := int(we.Index) - i
 		// if the insert is ordered, nothing after the error was inserted
-		if imo.Ordered == nil || *imo.Ordered {
+		if ordered {
 			result = result[:idIndex]
 			break
 		}
@@ -333,6 +387,21 @@ func (coll *Collection) insert(ctx context.Context, documents []interface{},
 	return result, err
 }
 
+// splitDocumentsByBytes splits docs into consecutive chunks whose combined size is at most maxBytes. A document larger
+// than maxBytes is placed in a chunk on its own.
+func splitDocumentsByBytes(docs []bsoncore.Document, maxBytes int) [][]bsoncore.Document {
+	var chunks [][]bsoncore.Document
+	var start, size int
+	for i, doc := range docs {
+		if i > start && size+len(doc) > maxBytes {
+			chunks = append(chunks, docs[start:i])
+			start, size = i, 0
+		}
+		size += len(doc)
+	}
+	return append(chunks, docs[start:])
+}
+
 // InsertOne executes an insert command to insert a single document into the collection.
 //
 // The document parameter must be the document to be inserted. It cannot be nil. If the document does not have an _id
@@ -384,7 +453,12 @@ func (coll *Collection) InsertMany(ctx context.Context, documents []interface{},
 	result, err := coll.insert(ctx, documents, opts...)
 	rr, err := processWriteError(err)
 	if rr&rrMany == 0 {
-		return nil, err
+		// If the documents were split by MaxBatchBytes, the IDs of the documents inserted by the commands that succeeded
+		// before the error are returned.
+		if len(result) == 0 {
+			return nil, err
+		}
+		return &InsertManyResult{InsertedIDs: result}, err
 	}
 
 	imResult := &InsertManyResult{InsertedIDs: result}
@@ -471,7 +545,8 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Ordered(true).
-		ServerAPI(coll.client.serverAPI).Timeout(coll.client.timeout).Logger(coll.client.logger)
+		ServerAPI(coll.client.serverAPI).
+		RequestIDFn(coll.client.requestIDFn).Timeout(coll.client.timeout).Logger(coll.client.logger)
 	if do.Comment != nil {
 		comment, err := marshalValue(do.Comment, coll.bsonOpts, coll.registry)
 		if err != nil {
@@ -495,7 +570,7 @@ func (coll *Collection) delete(ctx context.Context, filter interface{}, deleteOn
 	if deleteOne && coll.client.retryWrites {
 		retryMode = driver.RetryOncePerCommand
 	}
-	op = op.Retry(retryMode)
+	op = op.Retry(retryMode).MaxRetryDuration(coll.client.maxRetryDuration)
 	rr, err := processWriteError(op.Execute(ctx))
 	if rr&expectedRr == 0 {
 		return nil, err
@@ -588,6 +663,7 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).Hint(uo.Hint != nil).
 		ArrayFilters(uo.ArrayFilters != nil).Ordered(true).ServerAPI(coll.client.serverAPI).
+		RequestIDFn(coll.client.requestIDFn).
 		Timeout(coll.client.timeout).Logger(coll.client.logger)
 	if uo.Let != nil {
 		let, err := marshal(uo.Let, coll.bsonOpts, coll.registry)
@@ -612,7 +688,7 @@ func (coll *Collection) updateOrReplace(ctx context.Context, filter bsoncore.Doc
 	if !multi && coll.client.retryWrites {
 		retry = driver.RetryOncePerCommand
 	}
-	op = op.Retry(retry)
+	op = op.Retry(retry).MaxRetryDuration(coll.client.maxRetryDuration)
 	err = op.Execute(ctx)
 
 	rr, err := processWriteError(err)
@@ -859,6 +935,7 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 		Deployment(a.client.deployment).
 		Crypt(a.client.cryptFLE).
 		ServerAPI(a.client.serverAPI).
+		RequestIDFn(a.client.requestIDFn).
 		HasOutputStage(hasOutputStage).
 		Timeout(a.client.timeout).
 		MaxTime(ao.MaxTime)
@@ -925,7 +1002,7 @@ func aggregate(a aggregateParams) (cur *Cursor, err error) {
 	if a.retryRead && !hasOutputStage {
 		retry = driver.RetryOncePerCommand
 	}
-	op = op.Retry(retry)
+	op = op.Retry(retry).MaxRetryDuration(a.client.maxRetryDuration)
 
 	err = op.Execute(a.ctx)
 	if err != nil {
@@ -983,6 +1060,7 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	op := operation.NewAggregate(pipelineArr).Session(sess).ReadConcern(rc).ReadPreference(coll.readPreference).
 		CommandMonitor(coll.client.monitor).ServerSelector(selector).ClusterClock(coll.client.clock).Database(coll.db.name).
 		Collection(coll.name).Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+		RequestIDFn(coll.client.requestIDFn).
 		Timeout(coll.client.timeout).MaxTime(countOpts.MaxTime)
 	if countOpts.Collation != nil {
 		op.Collation(bsoncore.Document(countOpts.Collation.ToDocument()))
@@ -1004,7 +1082,7 @@ func (coll *Collection) CountDocuments(ctx context.Context, filter interface{},
 	if coll.client.retryReads {
 		retry = driver.RetryOncePerCommand
 	}
-	op = op.Retry(retry)
+	op = op.Retry(retry).MaxRetryDuration(coll.client.maxRetryDuration)
 
 	err = op.Execute(ctx)
 	if err != nil {
@@ -1043,8 +1121,18 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 		ctx = context.Background()
 	}
 
+	co := options.MergeEstimatedDocumentCountOptions(opts...)
 	sess := sessionFromContext(ctx)
 
+	// Counts read in a session are neither served from nor stored in the cache because the session's transaction or
+	// causal consistency guarantees do not apply to counts read outside of it.
+	useCache := co.CacheTTL != nil && sess == nil
+	if useCache {
+		if n, ok := coll.countCache.get(*co.CacheTTL); ok {
+			return n, nil
+		}
+	}
+
 	var err error
 	if sess == nil && coll.client.sessionPool != nil {
 		sess = session.NewImplicitClientSession(coll.client.sessionPool, coll.client.id)
@@ -1061,13 +1149,12 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 		rc = nil
 	}
 
-	co := options.MergeEstimatedDocumentCountOptions(opts...)
-
 	selector := makeReadPrefSelector(sess, coll.readSelector, coll.client.localThreshold)
 	op := operation.NewCount().Session(sess).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
 		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
 		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+		RequestIDFn(coll.client.requestIDFn).
 		Timeout(coll.client.timeout).MaxTime(co.MaxTime)
 
 	if co.Comment != nil {
@@ -1082,10 +1169,58 @@ func (coll *Collection) EstimatedDocumentCount(ctx context.Context,
 	if coll.client.retryReads {
 		retry = driver.RetryOncePerCommand
 	}
-	op.Retry(retry)
+	op.Retry(retry).MaxRetryDuration(coll.client.maxRetryDuration)
 
 	err = op.Execute(ctx)
-	return op.Result().N, replaceErrors(err)
+	n := op.Result().N
+	if err == nil && useCache {
+		coll.countCache.set(n)
+	}
+	return n, replaceErrors(err)
+}
+
+// estimatedCountCache caches the result of EstimatedDocumentCount for the CacheTTL option.
+type estimatedCountCache struct {
+	mu        sync.Mutex
+	count     int64
+	fetchedAt time.Time
+	cached    bool
+
+	// now returns the current time. It is time.Now, except in tests.
+	now func() time.Time
+}
+
+func newEstimatedCountCache() *estimatedCountCache {
+	return &estimatedCountCache{now: time.Now}
+}
+
+// get returns the cached count if it was fetched from the server less than ttl ago.
+func (c *estimatedCountCache) get(ttl time.Duration) (int64, bool) {
+	if c == nil {
+		return 0, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.cached || c.now().Sub(c.fetchedAt) >= ttl {
+		return 0, false
+	}
+	return c.count, true
+}
+
+// set caches a count that was just fetched from the server.
+func (c *estimatedCountCache) set(count int64) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.count = count
+	c.fetchedAt = c.now()
+	c.cached = true
 }
 
 // Distinct executes a distinct command to find the unique values for a specified field in the collection.
@@ -1135,6 +1270,7 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 		Database(coll.db.name).Collection(coll.name).CommandMonitor(coll.client.monitor).
 		Deployment(coll.client.deployment).ReadConcern(rc).ReadPreference(coll.readPreference).
 		ServerSelector(selector).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+		RequestIDFn(coll.client.requestIDFn).
 		Timeout(coll.client.timeout).MaxTime(option.MaxTime)
 
 	if option.Collation != nil {
@@ -1151,7 +1287,7 @@ func (coll *Collection) Distinct(ctx context.Context, fieldName string, filter i
 	if coll.client.retryReads {
 		retry = driver.RetryOncePerCommand
 	}
-	op = op.Retry(retry)
+	op = op.Retry(retry).MaxRetryDuration(coll.client.maxRetryDuration)
 
 	err = op.Execute(ctx)
 	if err != nil {
@@ -1230,6 +1366,7 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 		CommandMonitor(coll.client.monitor).ServerSelector(selector).
 		ClusterClock(coll.client.clock).Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).ServerAPI(coll.client.serverAPI).
+		RequestIDFn(coll.client.requestIDFn).
 		Timeout(coll.client.timeout).MaxTime(fo.MaxTime).Logger(coll.client.logger)
 
 	cursorOpts := coll.client.createBaseCursorOptions()
@@ -1349,7 +1486,7 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	if coll.client.retryReads {
 		retry = driver.RetryOncePerCommand
 	}
-	op = op.Retry(retry)
+	op = op.Retry(retry).MaxRetryDuration(coll.client.maxRetryDuration)
 
 	if err = op.Execute(ctx); err != nil {
 		return nil, replaceErrors(err)
@@ -1362,6 +1499,106 @@ func (coll *Collection) Find(ctx context.Context, filter interface{},
 	return newCursorWithSession(bc, coll.bsonOpts, coll.registry, sess)
 }
 
+// FindChan executes a find command and streams the matching documents on the results channel. The results parameter
+// must be a channel that can be sent on (e.g. make(chan MyType)). Each document is decoded into a new value of the
+// channel's element type before being sent. If the element type is a pointer, a new value of the pointed-to type is
+// allocated for each document.
+//
+// FindChan returns immediately. The query runs in a separate goroutine, which closes the results channel when all
+// documents have been sent, an error occurs, or ctx is cancelled. Any error, including a decode error or ctx.Err(), is
+// sent on the returned error channel, which is closed after the results channel. The underlying cursor is always
+// closed before the results channel is closed.
+//
+// The filter and opts parameters are the same as for Find.
+func (coll *Collection) FindChan(ctx context.Context, results interface{}, filter interface{},
+	opts ...*options.FindOptions) <-chan error {
+
+	return streamToChan(ctx, results, func(ctx context.Context) (chanSource, error) {
+		return coll.Find(ctx, filter, opts...)
+	})
+}
+
+// chanSource is the part of Cursor and ChangeStream used to stream decoded documents on a channel.
+type chanSource interface {
+	Next(context.Context) bool
+	Decode(interface{}) error
+	Err() error
+	Close(context.Context) error
+}
+
+// streamToChan validates that results is a channel that can be sent on and starts a goroutine that opens a source with
+// open and sends each of its documents, decoded into a new value of the channel's element type, on results. The
+// returned error channel receives the first error, if any, and is closed after results.
+func streamToChan(ctx context.Context, results interface{},
+	open func(context.Context) (chanSource, error)) <-chan error {
+
+	errs := make(chan error, 1)
+
+	resultsVal := reflect.ValueOf(results)
+	if resultsVal.Kind() != reflect.Chan || resultsVal.Type().ChanDir()&reflect.SendDir == 0 {
+		errs <- fmt.Errorf("results argument must be a channel that can be sent on, but was a %T", results)
+		close(errs)
+		return errs
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	go func() {
+		defer close(errs)
+		defer resultsVal.Close()
+
+		if err := sendDecoded(ctx, resultsVal, open); err != nil {
+			errs <- err
+		}
+	}()
+
+	return errs
+}
+
+func sendDecoded(ctx context.Context, resultsVal reflect.Value,
+	open func(context.Context) (chanSource, error)) error {
+
+	src, err := open(ctx)
+	if err != nil {
+		return err
+	}
+	// Use a new context to close the source because ctx may have been cancelled.
+	defer src.Close(context.Background())
+
+	elemType := resultsVal.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	cases := []reflect.SelectCase{
+		{Dir: reflect.SelectSend, Chan: resultsVal},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+	}
+	for src.Next(ctx) {
+		newElem := reflect.New(elemType)
+		if err := src.Decode(newElem.Interface()); err != nil {
+			return err
+		}
+		if !isPtr {
+			newElem = newElem.Elem()
+		}
+
+		// Check for cancellation first because Select chooses randomly if both cases are ready.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		cases[0].Send = newElem
+		if chosen, _, _ := reflect.Select(cases); chosen == 1 {
+			return ctx.Err()
+		}
+	}
+
+	return src.Err()
+}
+
 // FindOne executes a find command and returns a SingleResult for one document in the collection.
 //
 // The filter parameter must be a document containing query operators and can be used to select the document to be
@@ -1459,6 +1696,7 @@ func (coll *Collection) findAndModify(ctx context.Context, op *operation.FindAnd
 		Collection(coll.name).
 		Deployment(coll.client.deployment).
 		Retry(retry).
+		MaxRetryDuration(coll.client.maxRetryDuration).
 		Crypt(coll.client.cryptFLE)
 
 	_, err = processWriteError(op.Execute(ctx))
@@ -1493,7 +1731,8 @@ func (coll *Collection) FindOneAndDelete(ctx context.Context, filter interface{}
 		return &SingleResult{err: err}
 	}
 	fod := options.MergeFindOneAndDeleteOptions(opts...)
-	op := operation.NewFindAndModify(f).Remove(true).ServerAPI(coll.client.serverAPI).Timeout(coll.client.timeout).
+	op := operation.NewFindAndModify(f).Remove(true).ServerAPI(coll.client.serverAPI).
+		RequestIDFn(coll.client.requestIDFn).Timeout(coll.client.timeout).
 		MaxTime(fod.MaxTime)
 	if fod.Collation != nil {
 		op = op.Collation(bsoncore.Document(fod.Collation.ToDocument()))
@@ -1574,7 +1813,8 @@ func (coll *Collection) FindOneAndReplace(ctx context.Context, filter interface{
 
 	fo := options.MergeFindOneAndReplaceOptions(opts...)
 	op := operation.NewFindAndModify(f).Update(bsoncore.Value{Type: bsontype.EmbeddedDocument, Data: r}).
-		ServerAPI(coll.client.serverAPI).Timeout(coll.client.timeout).MaxTime(fo.MaxTime)
+		ServerAPI(coll.client.serverAPI).
+		RequestIDFn(coll.client.requestIDFn).Timeout(coll.client.timeout).MaxTime(fo.MaxTime)
 	if fo.BypassDocumentValidation != nil && *fo.BypassDocumentValidation {
 		op = op.BypassDocumentValidation(*fo.BypassDocumentValidation)
 	}
@@ -1660,7 +1900,8 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 	}
 
 	fo := options.MergeFindOneAndUpdateOptions(opts...)
-	op := operation.NewFindAndModify(f).ServerAPI(coll.client.serverAPI).Timeout(coll.client.timeout).
+	op := operation.NewFindAndModify(f).ServerAPI(coll.client.serverAPI).
+		RequestIDFn(coll.client.requestIDFn).Timeout(coll.client.timeout).
 		MaxTime(fo.MaxTime)
 
 	u, err := marshalUpdateValue(update, coll.bsonOpts, coll.registry, true)
@@ -1742,7 +1983,11 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 // https://www.mongodb.com/docs/manual/changeStreams/ for more information about change streams.
 //
 // The Collection must be configured with read concern majority or no read concern for a change stream to be created
-// successfully.
+// successfully. Watch returns an error without contacting the server if the read concern level is one that change
+// streams never support (available, linearizable, or snapshot).
+//
+// Change streams cannot be opened on views, which includes time series collections. The server returns a
+// CommandNotSupportedOnView error in that case.
 //
 // The pipeline parameter must be an array of documents, each representing a pipeline stage. The pipeline cannot be
 // nil but can be empty. The stage documents must all be non-nil. See https://www.mongodb.com/docs/manual/changeStreams/ for
@@ -1754,6 +1999,74 @@ func (coll *Collection) FindOneAndUpdate(ctx context.Context, filter interface{}
 func (coll *Collection) Watch(ctx context.Context, pipeline interface{},
 	opts ...*options.ChangeStreamOptions) (*ChangeStream, error) {
 
+	if err := coll.ensurePreAndPostImages(ctx, options.MergeChangeStreamOptions(opts...)); err != nil {
+		return nil, err
+	}
+
+	csConfig := changeStreamConfig{
+		readConcern:    coll.readConcern,
+		readPreference: coll.readPreference,
+		client:         coll.client,
+		bsonOpts:       coll.bsonOpts,
+		registry:       coll.registry,
+		streamType:     CollectionStream,
+		collectionName: coll.Name(),
+		databaseName:   coll.db.Name(),
+		crypt:          coll.client.cryptFLE,
+	}
+	return newChangeStream(ctx, csConfig, pipeline, opts...)
+}
+
+// ensurePreAndPostImages enables changeStreamPreAndPostImages on the collection with a collMod command if the
+// EnsurePreAndPostImages option is set, the options request pre- or post-images, and the collection does not already
+// have it enabled.
+func (coll *Collection) ensurePreAndPostImages(ctx context.Context, cso *options.ChangeStreamOptions) error {
+	if cso.EnsurePreAndPostImages == nil || !*cso.EnsurePreAndPostImages {
+		return nil
+	}
+	requestsImage := func(fd *options.FullDocument) bool {
+		return fd != nil && (*fd == options.WhenAvailable || *fd == options.Required)
+	}
+	if !requestsImage(cso.FullDocument) && !requestsImage(cso.FullDocumentBeforeChange) {
+		return nil
+	}
+
+	specs, err := coll.db.ListCollectionSpecifications(ctx, bson.D{{"name", coll.name}})
+	if err != nil {
+		return fmt.Errorf("error checking changeStreamPreAndPostImages for collection %q: %w", coll.name, err)
+	}
+	if len(specs) == 1 {
+		if enabled, ok := specs[0].Options.Lookup("changeStreamPreAndPostImages", "enabled").BooleanOK(); ok && enabled {
+			return nil
+		}
+	}
+
+	cmd := bson.D{
+		{"collMod", coll.name},
+		{"changeStreamPreAndPostImages", bson.D{{"enabled", true}}},
+	}
+	if err := coll.db.RunCommand(ctx, cmd).Err(); err != nil {
+		return fmt.Errorf("error enabling changeStreamPreAndPostImages for collection %q: %w", coll.name, err)
+	}
+	return nil
+}
+
+// WatchRaw is like Watch, except that the driver does not build the $changeStream stage. The pipeline parameter is
+// sent to the server as-is and must be a BSON array of stage documents whose first stage is a $changeStream stage.
+// This can be used to pass $changeStream settings that are not modeled by options.ChangeStreamOptions.
+//
+// The returned ChangeStream still caches resume tokens from the results and resumes on resumable errors. When
+// resuming, the resumeAfter, startAfter, and startAtOperationTime fields of the original $changeStream stage are
+// replaced with the cached resume point; all other fields are sent unchanged.
+//
+// The opts parameter can be used to specify options for change stream creation (see the options.ChangeStreamOptions
+// documentation). Options that modify the pipeline (FullDocument, FullDocumentBeforeChange, ResumeAfter,
+// ShowExpandedEvents, StartAfter, StartAtOperationTime, CustomPipeline, ExcludeSystemNamespaces, NamespaceDBRegex,
+// NamespaceCollRegex, SingleCollection, Match, and ProjectFullDocumentFields) are ignored and must be set in the
+// pipeline instead.
+func (coll *Collection) WatchRaw(ctx context.Context, pipeline bson.Raw,
+	opts ...*options.ChangeStreamOptions) (*ChangeStream, error) {
+
 	csConfig := changeStreamConfig{
 		readConcern:    coll.readConcern,
 		readPreference: coll.readPreference,
@@ -1764,10 +2077,56 @@ func (coll *Collection) Watch(ctx context.Context, pipeline interface{},
 		collectionName: coll.Name(),
 		databaseName:   coll.db.Name(),
 		crypt:          coll.client.cryptFLE,
+		rawPipeline:    true,
 	}
 	return newChangeStream(ctx, csConfig, pipeline, opts...)
 }
 
+// WatchChan opens a change stream like Watch and streams its events on the results channel. The results parameter must
+// be a channel that can be sent on (e.g. make(chan MyEvent, 100)). Each event is decoded into a new value of the
+// channel's element type before being sent. If the element type is a pointer, a new value of the pointed-to type is
+// allocated for each event.
+//
+// The capacity of results is the number of decoded events that can be buffered. When the buffer is full, the goroutine
+// reading the change stream blocks until the consumer receives an event, so no further getMore commands are sent while
+// the consumer is behind.
+//
+// WatchChan returns immediately. The change stream runs in a separate goroutine, which closes the results channel when
+// ctx is cancelled, the change stream returns an error, or the change stream ends, e.g. because of an invalidate event.
+// Any error, including an error opening the change stream, a decode error, or ctx.Err(), is sent on the returned error
+// channel, which is closed after the results channel. The change stream is always closed before the results channel is
+// closed.
+//
+// The pipeline and opts parameters are the same as for Watch.
+func (coll *Collection) WatchChan(ctx context.Context, results interface{}, pipeline interface{},
+	opts ...*options.ChangeStreamOptions) <-chan error {
+
+	return streamToChan(ctx, results, func(ctx context.Context) (chanSource, error) {
+		return coll.Watch(ctx, pipeline, opts...)
+	})
+}
+
+// WatchWithSession is like Watch, except that the change stream is pinned to the given explicit session instead of a
+// session stored in ctx or an implicit session. The aggregate, getMore, and killCursors commands for the change stream
+// are all sent with the session's lsid, including the commands run when the change stream resumes, so the stream
+// participates in the session's causal consistency guarantees.
+//
+// The session must have been created by the Client that owns the Collection. The session is not ended when the change
+// stream is closed, and must not be ended while the change stream is in use. ErrInvalidSession is returned if sess is
+// nil or was not created by Client.StartSession, and ErrWrongClient is returned if it was created by a different
+// Client.
+func (coll *Collection) WatchWithSession(ctx context.Context, sess Session, pipeline interface{},
+	opts ...*options.ChangeStreamOptions) (*ChangeStream, error) {
+
+	if impl, ok := sess.(*sessionImpl); !ok || impl == nil {
+		return nil, ErrInvalidSession
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return coll.Watch(NewSessionContext(ctx, sess), pipeline, opts...)
+}
+
 // Indexes returns an IndexView instance that can be used to perform operations on the indexes for the collection.
 func (coll *Collection) Indexes() IndexView {
 	return IndexView{coll: coll}
@@ -1857,7 +2216,7 @@ func (coll *Collection) drop(ctx context.Context) error {
 		ServerSelector(selector).ClusterClock(coll.client.clock).
 		Database(coll.db.name).Collection(coll.name).
 		Deployment(coll.client.deployment).Crypt(coll.client.cryptFLE).
-		ServerAPI(coll.client.serverAPI).Timeout(coll.client.timeout)
+		ServerAPI(coll.client.serverAPI).RequestIDFn(coll.client.requestIDFn).Timeout(coll.client.timeout)
 	err = op.Execute(ctx)
 
 	// ignore namespace not found erorrs
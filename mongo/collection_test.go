@@ -8,14 +8,21 @@ package mongo
 
 import (
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/session"
 )
 
 const (
@@ -213,4 +220,235 @@ func TestCollection(t *testing.T) {
 		_, err = coll.Watch(bgCtx, nil)
 		assert.Equal(t, aggErr, err, "expected error %v, got %v", aggErr, err)
 	})
+	t.Run("FindChan argument errors", func(t *testing.T) {
+		coll := setupColl("foo")
+
+		t.Run("results is not a channel", func(t *testing.T) {
+			err := <-coll.FindChan(bgCtx, []bson.D{}, bson.D{})
+			want := errors.New("results argument must be a channel that can be sent on, but was a []primitive.D")
+			assert.Equal(t, want, err, "expected error %v, got %v", want, err)
+		})
+		t.Run("results is a receive-only channel", func(t *testing.T) {
+			err := <-coll.FindChan(bgCtx, make(<-chan bson.D), bson.D{})
+			want := errors.New("results argument must be a channel that can be sent on, but was a <-chan primitive.D")
+			assert.Equal(t, want, err, "expected error %v, got %v", want, err)
+		})
+		t.Run("nil filter", func(t *testing.T) {
+			results := make(chan bson.D)
+			errs := coll.FindChan(bgCtx, results, nil)
+
+			_, ok := <-results
+			assert.False(t, ok, "expected results channel to be closed")
+			err := <-errs
+			assert.Equal(t, ErrNilDocument, err, "expected error %v, got %v", ErrNilDocument, err)
+			_, ok = <-errs
+			assert.False(t, ok, "expected error channel to be closed")
+		})
+	})
+	t.Run("WatchWithSession invalid session", func(t *testing.T) {
+		coll := setupColl("foo")
+
+		_, err := coll.WatchWithSession(bgCtx, nil, Pipeline{})
+		assert.Equal(t, ErrInvalidSession, err, "expected error %v, got %v", ErrInvalidSession, err)
+
+		var typedNil *sessionImpl
+		_, err = coll.WatchWithSession(bgCtx, typedNil, Pipeline{})
+		assert.Equal(t, ErrInvalidSession, err, "expected error %v, got %v", ErrInvalidSession, err)
+	})
+	t.Run("EstimatedDocumentCount CacheTTL", func(t *testing.T) {
+		conn := &drivertest.ChannelConn{
+			Written:  make(chan []byte, 4),
+			ReadResp: make(chan []byte, 4),
+			Desc:     description.Server{Kind: description.Standalone},
+		}
+		for _, n := range []int32{1, 2, 3, 4} {
+			conn.ReadResp <- drivertest.MakeReply(bsoncore.NewDocumentBuilder().
+				AppendInt32("ok", 1).
+				AppendInt32("n", n).
+				Build())
+		}
+
+		clientOpts := options.Client()
+		clientOpts.Deployment = driver.SingleConnectionDeployment{C: conn}
+		client, err := NewClient(clientOpts)
+		assert.Nil(t, err, "NewClient error: %v", err)
+		coll := client.Database("foo").Collection("bar")
+
+		now := time.Now()
+		coll.countCache.now = func() time.Time { return now }
+		opts := options.EstimatedDocumentCount().SetCacheTTL(time.Minute)
+
+		count := func(expected int64, expectedWritten int) {
+			t.Helper()
+
+			n, err := coll.EstimatedDocumentCount(bgCtx, opts)
+			assert.Nil(t, err, "EstimatedDocumentCount error: %v", err)
+			assert.Equal(t, expected, n, "expected count %v, got %v", expected, n)
+			assert.Equal(t, expectedWritten, len(conn.Written),
+				"expected %v commands to be sent, got %v", expectedWritten, len(conn.Written))
+		}
+
+		count(1, 1)
+		now = now.Add(30 * time.Second)
+		count(1, 1)
+		now = now.Add(30 * time.Second)
+		count(2, 2)
+
+		// A call in a session always sends a command and does not update the cache. The client is never connected, so
+		// set a session pool manually to start a session.
+		client.sessionPool = session.NewPool(nil)
+		sess, err := client.StartSession()
+		assert.Nil(t, err, "StartSession error: %v", err)
+		defer sess.EndSession(bgCtx)
+		n, err := coll.EstimatedDocumentCount(NewSessionContext(bgCtx, sess), opts)
+		assert.Nil(t, err, "EstimatedDocumentCount error: %v", err)
+		assert.Equal(t, int64(3), n, "expected count 3, got %v", n)
+		assert.Equal(t, 3, len(conn.Written), "expected 3 commands to be sent, got %v", len(conn.Written))
+		count(2, 3)
+
+		// A call without CacheTTL always sends a command.
+		n, err = coll.EstimatedDocumentCount(bgCtx)
+		assert.Nil(t, err, "EstimatedDocumentCount error: %v", err)
+		assert.Equal(t, int64(4), n, "expected count 4, got %v", n)
+		assert.Equal(t, 4, len(conn.Written), "expected 4 commands to be sent, got %v", len(conn.Written))
+	})
+	t.Run("InsertMany MaxBatchBytes", func(t *testing.T) {
+		// Each document is 122 bytes, so a limit of 250 bytes allows two documents per insert command.
+		docs := make([]interface{}, 0, 4)
+		for i := 0; i < 4; i++ {
+			docs = append(docs, bson.D{{"_id", int32(i)}, {"s", strings.Repeat("x", 100)}})
+		}
+		setup := func(replies ...bsoncore.Document) (*Collection, *drivertest.ChannelConn) {
+			conn := &drivertest.ChannelConn{
+				Written:  make(chan []byte, len(replies)),
+				ReadResp: make(chan []byte, len(replies)),
+				Desc: description.Server{
+					Kind:            description.Standalone,
+					MaxDocumentSize: 16 * 1024 * 1024,
+					MaxMessageSize:  48 * 1024 * 1024,
+					MaxBatchCount:   100000,
+				},
+			}
+			for _, reply := range replies {
+				conn.ReadResp <- drivertest.MakeReply(reply)
+			}
+
+			clientOpts := options.Client()
+			clientOpts.Deployment = driver.SingleConnectionDeployment{C: conn}
+			client, err := NewClient(clientOpts)
+			assert.Nil(t, err, "NewClient error: %v", err)
+			return client.Database("foo").Collection("bar"), conn
+		}
+		sentDocuments := func(conn *drivertest.ChannelConn) []int {
+			var counts []int
+			for len(conn.Written) > 0 {
+				cmd, err := drivertest.GetCommandFromQueryWireMessage(<-conn.Written)
+				assert.Nil(t, err, "error reading command: %v", err)
+				values, err := cmd.Lookup("documents").Array().Values()
+				assert.Nil(t, err, "error reading documents: %v", err)
+				counts = append(counts, len(values))
+			}
+			return counts
+		}
+		okReply := func(n int32) bsoncore.Document {
+			return bsoncore.NewDocumentBuilder().AppendInt32("ok", 1).AppendInt32("n", n).Build()
+		}
+
+		t.Run("documents are split", func(t *testing.T) {
+			coll, conn := setup(okReply(2), okReply(1))
+			res, err := coll.InsertMany(bgCtx, docs[:3], options.InsertMany().SetMaxBatchBytes(250))
+			assert.Nil(t, err, "InsertMany error: %v", err)
+
+			want := []interface{}{int32(0), int32(1), int32(2)}
+			assert.Equal(t, want, res.InsertedIDs, "expected IDs %v, got %v", want, res.InsertedIDs)
+			counts := sentDocuments(conn)
+			assert.Equal(t, []int{2, 1}, counts, "expected document counts [2 1], got %v", counts)
+		})
+		t.Run("write error indexes are relative to all documents", func(t *testing.T) {
+			writeErrReply := bsoncore.NewDocumentBuilder().
+				AppendInt32("ok", 1).
+				AppendInt32("n", 1).
+				AppendArray("writeErrors", bsoncore.NewArrayBuilder().
+					AppendDocument(bsoncore.NewDocumentBuilder().
+						AppendInt32("index", 1).
+						AppendInt32("code", 11000).
+						AppendString("errmsg", "duplicate key").
+						Build()).
+					Build()).
+				Build()
+			coll, conn := setup(okReply(2), writeErrReply)
+			res, err := coll.InsertMany(bgCtx, docs, options.InsertMany().SetMaxBatchBytes(250))
+
+			var bwe BulkWriteException
+			assert.True(t, errors.As(err, &bwe), "expected BulkWriteException, got %v", err)
+			assert.Equal(t, 1, len(bwe.WriteErrors), "expected 1 write error, got %v", len(bwe.WriteErrors))
+			assert.Equal(t, 3, bwe.WriteErrors[0].Index, "expected index 3, got %v", bwe.WriteErrors[0].Index)
+			want := []interface{}{int32(0), int32(1), int32(2)}
+			assert.Equal(t, want, res.InsertedIDs, "expected IDs %v, got %v", want, res.InsertedIDs)
+			counts := sentDocuments(conn)
+			assert.Equal(t, []int{2, 2}, counts, "expected document counts [2 2], got %v", counts)
+		})
+		t.Run("unacknowledged", func(t *testing.T) {
+			// Unacknowledged writes use OP_MSG with moreToCome, so no replies are read.
+			coll, conn := setup()
+			conn.Written = make(chan []byte, 2)
+			conn.Desc.WireVersion = &description.VersionRange{Min: 6, Max: 17}
+			wc := writeconcern.New(writeconcern.W(0))
+			coll = coll.Database().Collection("bar", options.Collection().SetWriteConcern(wc))
+			res, err := coll.InsertMany(bgCtx, docs, options.InsertMany().SetMaxBatchBytes(250))
+
+			assert.True(t, errors.Is(err, ErrUnacknowledgedWrite), "expected error %v, got %v", ErrUnacknowledgedWrite, err)
+			assert.NotNil(t, res, "expected result, got nil")
+			want := []interface{}{int32(0), int32(1), int32(2), int32(3)}
+			assert.Equal(t, want, res.InsertedIDs, "expected IDs %v, got %v", want, res.InsertedIDs)
+			assert.Equal(t, 2, len(conn.Written), "expected 2 commands to be sent, got %v", len(conn.Written))
+		})
+		t.Run("network error", func(t *testing.T) {
+			// The connection only accepts one message, so writing the second insert command fails with a network
+			// error.
+			coll, conn := setup(okReply(2))
+			res, err := coll.InsertMany(bgCtx, docs, options.InsertMany().SetMaxBatchBytes(250))
+
+			assert.True(t, IsNetworkError(err), "expected network error, got %v", err)
+			var bwe BulkWriteException
+			assert.False(t, errors.As(err, &bwe), "expected error other than BulkWriteException, got %v", err)
+			assert.NotNil(t, res, "expected result, got nil")
+			want := []interface{}{int32(0), int32(1)}
+			assert.Equal(t, want, res.InsertedIDs, "expected IDs %v, got %v", want, res.InsertedIDs)
+			counts := sentDocuments(conn)
+			assert.Equal(t, []int{2}, counts, "expected document counts [2], got %v", counts)
+		})
+	})
+	t.Run("split documents by bytes", func(t *testing.T) {
+		doc := func(size int) bsoncore.Document {
+			return make(bsoncore.Document, size)
+		}
+		sizes := func(chunks [][]bsoncore.Document) [][]int {
+			var got [][]int
+			for _, chunk := range chunks {
+				var chunkSizes []int
+				for _, d := range chunk {
+					chunkSizes = append(chunkSizes, len(d))
+				}
+				got = append(got, chunkSizes)
+			}
+			return got
+		}
+
+		testCases := []struct {
+			name string
+			docs []bsoncore.Document
+			want [][]int
+		}{
+			{"all fit", []bsoncore.Document{doc(10), doc(20)}, [][]int{{10, 20}}},
+			{"exact limit", []bsoncore.Document{doc(50), doc(50), doc(10)}, [][]int{{50, 50}, {10}}},
+			{"oversized document", []bsoncore.Document{doc(10), doc(200), doc(10)}, [][]int{{10}, {200}, {10}}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				got := sizes(splitDocumentsByBytes(tc.docs, 100))
+				assert.Equal(t, tc.want, got, "expected chunks %v, got %v", tc.want, got)
+			})
+		}
+	})
 }
@@ -38,6 +38,9 @@ type Cursor struct {
 	registry      *bsoncodec.Registry
 	clientSession *session.Client
 
+	// batchDiscarded is true if a getMore that did not return any documents discarded the contents of batch.
+	batchDiscarded bool
+
 	err error
 }
 
@@ -123,6 +126,12 @@ func NewCursorFromDocuments(documents []interface{}, err error, registry *bsonco
 // ID returns the ID of this cursor, or 0 if the cursor has been closed or exhausted.
 func (c *Cursor) ID() int64 { return c.bc.ID() }
 
+// Exhausted returns true if the server has no more results for this cursor (i.e. the cursor ID is 0), meaning no further
+// getMore commands can be sent. Documents from the current batch may still be available through Next or TryNext. This
+// can be used by polling loops to decide whether to stop calling TryNext after it returns false. A tailable cursor, such
+// as one created for a capped collection or a change stream, is never exhausted until it is closed or killed.
+func (c *Cursor) Exhausted() bool { return c.ID() == 0 }
+
 // Next gets the next document for this cursor. It returns true if there were no errors and the cursor has not been
 // exhausted.
 //
@@ -175,8 +184,14 @@ func (c *Cursor) next(ctx context.Context, nonBlocking bool) bool {
 	// call the Next method in a loop until at least one document is returned in the next batch or
 	// the context times out.
 	for {
+		// A getMore is only sent once the first batch has been loaded and if the server cursor is still open.
+		runsGetMore := c.batch != nil && c.bc.ID() != 0
+
 		// If we don't have a next batch
 		if !c.bc.Next(ctx) {
+			if runsGetMore {
+				c.batchDiscarded = true
+			}
 			// Do we have an error? If so we return false.
 			c.err = replaceErrors(c.bc.Err())
 			if c.err != nil {
@@ -206,10 +221,12 @@ func (c *Cursor) next(ctx context.Context, nonBlocking bool) bool {
 		doc, err = c.batch.Next()
 		switch err {
 		case nil:
+			c.batchDiscarded = false
 			c.batchLength--
 			c.Current = bson.Raw(doc)
 			return true
 		case io.EOF: // Empty batch so we continue
+			c.batchDiscarded = true
 		default:
 			c.err = err
 			return false
@@ -240,6 +257,9 @@ func getDecoder(
 		if opts.DefaultDocumentM {
 			dec.DefaultDocumentM()
 		}
+		if opts.ErrorOnUnknownFields {
+			dec.ErrorOnUnknownFields()
+		}
 		if opts.UseJSONStructTags {
 			dec.UseJSONStructTags()
 		}
@@ -252,6 +272,12 @@ func getDecoder(
 		if opts.ZeroStructs {
 			dec.ZeroStructs()
 		}
+		if opts.DurationUnit > 0 {
+			dec.DurationUnit(opts.DurationUnit)
+		}
+		if opts.CanonicalJSONRawMessage {
+			dec.CanonicalJSONRawMessage()
+		}
 	}
 
 	if reg != nil {
@@ -275,6 +301,32 @@ func (c *Cursor) Decode(val interface{}) error {
 	return dec.Decode(val)
 }
 
+// Rewind resets the cursor to the start of its current batch, so the next call to Next or TryNext returns the first
+// document of the batch again and the documents of the batch can be decoded again, for example into a different type.
+// Rewind does not contact the server, and documents from batches before the current one cannot be re-read. Calling
+// Rewind before the first call to Next or TryNext has no effect.
+//
+// Once the last document of the current batch has been returned, the next call to Next or TryNext runs a getMore,
+// which replaces the current batch. If the getMore returns no documents, the previous batch is discarded and Rewind
+// returns ErrCursorBatchDiscarded. If the cursor has an error, Rewind returns it. Rewind must not be called after
+// Close.
+func (c *Cursor) Rewind() error {
+	if c.err != nil {
+		return c.err
+	}
+	if c.batchDiscarded {
+		return ErrCursorBatchDiscarded
+	}
+	if c.batch == nil {
+		return nil
+	}
+
+	c.batch.ResetIterator()
+	c.batchLength = c.batch.DocumentCount()
+	c.Current = nil
+	return nil
+}
+
 // Err returns the last error seen by the Cursor, or nil if no error has occurred.
 func (c *Cursor) Err() error { return c.err }
 
@@ -285,12 +337,35 @@ func (c *Cursor) Close(ctx context.Context) error {
 	return replaceErrors(c.bc.Close(ctx))
 }
 
+// CloseInfo is like Close, but it also reports whether a killCursors command was run to close the cursor on the server.
+// No command is run if the cursor was exhausted (i.e. ID returns 0) or has already been closed, because the server
+// has already released it. If err is not nil, the killCursors command may have failed before it was sent.
+func (c *Cursor) CloseInfo(ctx context.Context) (killCursorsSent bool, err error) {
+	killCursorsSent = c.bc.ID() != 0 && c.bc.Server() != nil
+	return killCursorsSent, c.Close(ctx)
+}
+
 // All iterates the cursor and decodes each document into results. The results parameter must be a pointer to a slice.
 // The slice pointed to by results will be completely overwritten. This method will close the cursor after retrieving
 // all documents. If the cursor has been iterated, any previously iterated documents will not be included in results.
 //
 // This method requires driver version >= 1.1.0.
 func (c *Cursor) All(ctx context.Context, results interface{}) error {
+	return c.all(ctx, results, 0)
+}
+
+// AllWithCapacity is like All, but if the slice pointed to by results has a capacity smaller than capacityHint, it
+// is replaced with a slice of the same length and a capacity of capacityHint before any documents are decoded. When
+// the number of results is known ahead of time, this avoids repeatedly growing the slice while iterating the cursor.
+// The hint only affects allocation; results contains exactly the documents returned by the cursor.
+//
+// All also uses any existing capacity of the slice pointed to by results, so passing a slice created with
+// make([]T, 0, n) to All has the same effect.
+func (c *Cursor) AllWithCapacity(ctx context.Context, results interface{}, capacityHint int) error {
+	return c.all(ctx, results, capacityHint)
+}
+
+func (c *Cursor) all(ctx context.Context, results interface{}, capacityHint int) error {
 	resultsVal := reflect.ValueOf(results)
 	if resultsVal.Kind() != reflect.Ptr {
 		return fmt.Errorf("results argument must be a pointer to a slice, but was a %s", resultsVal.Kind())
@@ -305,6 +380,12 @@ func (c *Cursor) All(ctx context.Context, results interface{}) error {
 		return fmt.Errorf("results argument must be a pointer to a slice, but was a pointer to %s", sliceVal.Kind())
 	}
 
+	if sliceVal.Cap() < capacityHint {
+		grown := reflect.MakeSlice(sliceVal.Type(), sliceVal.Len(), capacityHint)
+		reflect.Copy(grown, sliceVal)
+		sliceVal = grown
+	}
+
 	elementType := sliceVal.Type().Elem()
 	var index int
 	var err error
@@ -336,6 +417,47 @@ func (c *Cursor) All(ctx context.Context, results interface{}) error {
 	return nil
 }
 
+// WriteExtJSON writes all remaining documents in the cursor to w as newline-delimited extended JSON. If canonical is
+// true, canonical extended JSON is written. Otherwise, relaxed extended JSON is written. HTML characters are not
+// escaped. Each document is written as soon as it is converted, so the results are never held in memory all at once,
+// which makes this suitable for exporting large result sets.
+//
+// Like All, this method closes the cursor after writing all documents or when an error occurs. If the cursor has been
+// iterated, any previously iterated documents are not written. The returned error is the first error from iterating
+// the cursor, converting a document, or writing to w.
+func (c *Cursor) WriteExtJSON(ctx context.Context, w io.Writer, canonical bool) error {
+	// Defer a call to Close to try to clean up the cursor server-side when all documents have not been exhausted. Use
+	// context.Background() to ensure Close completes even if the context passed to WriteExtJSON has errored.
+	defer c.Close(context.Background())
+
+	var buf []byte
+	batch := c.batch // exhaust the current batch before iterating the batch cursor
+	for {
+		docs, err := batch.Documents()
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			buf, err = bson.MarshalExtJSONAppendWithRegistry(c.registry, buf[:0], bson.Raw(doc), canonical, false)
+			if err != nil {
+				return fmt.Errorf("error converting document to extended JSON: %w", err)
+			}
+			buf = append(buf, '\n')
+			if _, err = w.Write(buf); err != nil {
+				return err
+			}
+		}
+
+		if !c.bc.Next(ctx) {
+			break
+		}
+
+		batch = c.bc.Batch()
+	}
+
+	return replaceErrors(c.bc.Err())
+}
+
 // RemainingBatchLength returns the number of documents left in the current batch. If this returns zero, the subsequent
 // call to Next or TryNext will do a network request to fetch the next batch.
 func (c *Cursor) RemainingBatchLength() int {
@@ -7,12 +7,17 @@
 package mongo
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsoncodec"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/require"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -206,7 +211,269 @@ func TestCursor(t *testing.T) {
 
 			assert.Equal(t, want, got, "expected and actual All results are different")
 		})
+		t.Run("with ErrorOnUnknownFields BSONOption", func(t *testing.T) {
+			cursor, err := newCursor(
+				newTestBatchCursor(1, 5),
+				&options.BSONOptions{
+					ErrorOnUnknownFields: true,
+				},
+				nil)
+			require.NoError(t, err, "newCursor error")
+
+			type myDocument struct {
+				Bar int32 `bson:"bar"`
+			}
+			var got []myDocument
+
+			err = cursor.All(context.Background(), &got)
+			assert.True(t, errors.Is(err, bsoncodec.ErrUnknownField),
+				"expected error %v, got %v", bsoncodec.ErrUnknownField, err)
+		})
+	})
+	t.Run("AllWithCapacity", func(t *testing.T) {
+		var want []bson.D
+		cursor, err := newCursor(newTestBatchCursor(2, 5), nil, nil)
+		require.NoError(t, err, "newCursor error")
+		err = cursor.All(context.Background(), &want)
+		require.NoError(t, err, "All error")
+
+		testCases := []struct {
+			name    string
+			hint    int
+			wantCap int
+		}{
+			{"no hint", 0, 10},
+			{"hint smaller than results", 3, 10},
+			{"exact hint", 10, 10},
+			{"hint larger than results", 20, 20},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				cursor, err := newCursor(newTestBatchCursor(2, 5), nil, nil)
+				require.NoError(t, err, "newCursor error")
+
+				var got []bson.D
+				err = cursor.AllWithCapacity(context.Background(), &got, tc.hint)
+				require.NoError(t, err, "AllWithCapacity error")
+				assert.Equal(t, want, got, "expected and actual results are different")
+				assert.True(t, cap(got) >= tc.wantCap, "expected capacity of at least %v, got %v", tc.wantCap, cap(got))
+			})
+		}
+		t.Run("keeps existing elements when growing", func(t *testing.T) {
+			type document struct {
+				Foo int32 `bson:"foo"`
+				Bar string
+			}
+			cursor, err := newCursor(newTestBatchCursor(1, 2), nil, nil)
+			require.NoError(t, err, "newCursor error")
+
+			// Like All, existing elements are decoded into rather than replaced.
+			got := []document{{Bar: "a"}}
+			err = cursor.AllWithCapacity(context.Background(), &got, 10)
+			require.NoError(t, err, "AllWithCapacity error")
+
+			want := []document{{Foo: 0, Bar: "a"}, {Foo: 1}}
+			assert.Equal(t, want, got, "expected and actual results are different")
+		})
+	})
+	t.Run("WriteExtJSON", func(t *testing.T) {
+		docs := []interface{}{
+			bson.D{{"_id", primitive.NewObjectID()}, {"int32", int32(1)}, {"int64", int64(2)}},
+			bson.D{{"double", 3.5}, {"date", primitive.NewDateTimeFromTime(time.Unix(1600000000, 0))}},
+			bson.D{{"html", "<a href=\"x\">&</a>"}, {"nested", bson.D{{"arr", bson.A{"a", int64(4)}}}}},
+		}
+
+		for _, canonical := range []bool{true, false} {
+			t.Run(fmt.Sprintf("canonical %v", canonical), func(t *testing.T) {
+				var want bytes.Buffer
+				for _, doc := range docs {
+					b, err := bson.MarshalExtJSON(doc, canonical, false)
+					require.NoError(t, err, "MarshalExtJSON error")
+					want.Write(b)
+					want.WriteByte('\n')
+				}
+
+				cursor, err := NewCursorFromDocuments(docs, nil, nil)
+				require.NoError(t, err, "NewCursorFromDocuments error")
+
+				var got bytes.Buffer
+				err = cursor.WriteExtJSON(context.Background(), &got, canonical)
+				require.NoError(t, err, "WriteExtJSON error")
+				assert.Equal(t, want.String(), got.String(), "expected and actual output are different")
+			})
+		}
+		t.Run("multiple batches are included and cursor is closed", func(t *testing.T) {
+			tbc := newTestBatchCursor(2, 5)
+			cursor, err := newCursor(tbc, nil, nil)
+			require.NoError(t, err, "newCursor error")
+
+			var got bytes.Buffer
+			err = cursor.WriteExtJSON(context.Background(), &got, true)
+			require.NoError(t, err, "WriteExtJSON error")
+			assert.True(t, tbc.closed, "expected batch cursor to be closed but was not")
+
+			lines := strings.Split(strings.TrimSuffix(got.String(), "\n"), "\n")
+			assert.Equal(t, 10, len(lines), "expected 10 documents, got %v", len(lines))
+			for i, line := range lines {
+				want := fmt.Sprintf(`{"foo":{"$numberInt":"%d"}}`, i)
+				assert.Equal(t, want, line, "expected document %v to be %v, got %v", i, want, line)
+			}
+		})
+		t.Run("write error is returned and cursor is closed", func(t *testing.T) {
+			tbc := newTestBatchCursor(2, 5)
+			cursor, err := newCursor(tbc, nil, nil)
+			require.NoError(t, err, "newCursor error")
+
+			writeErr := errors.New("write error")
+			err = cursor.WriteExtJSON(context.Background(), errorWriter{writeErr}, true)
+			assert.Equal(t, writeErr, err, "expected error %v, got %v", writeErr, err)
+			assert.True(t, tbc.closed, "expected batch cursor to be closed but was not")
+		})
 	})
+	t.Run("Rewind", func(t *testing.T) {
+		type fooDoc struct {
+			Foo int32
+		}
+		decodeBatch := func(t *testing.T, cursor *Cursor, n int) []fooDoc {
+			t.Helper()
+
+			var docs []fooDoc
+			for i := 0; i < n; i++ {
+				require.True(t, cursor.Next(context.Background()), "Next error: %v", cursor.Err())
+				var doc fooDoc
+				require.NoError(t, cursor.Decode(&doc), "Decode error")
+				docs = append(docs, doc)
+			}
+			return docs
+		}
+
+		t.Run("re-reads the current batch", func(t *testing.T) {
+			cursor, err := newCursor(newTestBatchCursor(2, 3), nil, nil)
+			require.NoError(t, err, "newCursor error")
+
+			// Speculatively decode into the wrong type, then rewind and decode again.
+			require.True(t, cursor.Next(context.Background()), "Next error: %v", cursor.Err())
+			var wrong struct {
+				Foo string
+			}
+			assert.NotNil(t, cursor.Decode(&wrong), "expected Decode error, got nil")
+
+			require.NoError(t, cursor.Rewind(), "Rewind error")
+			assert.Equal(t, 3, cursor.RemainingBatchLength(), "expected 3 remaining documents, got %v",
+				cursor.RemainingBatchLength())
+			first := decodeBatch(t, cursor, 3)
+			require.NoError(t, cursor.Rewind(), "Rewind error")
+			second := decodeBatch(t, cursor, 3)
+			want := []fooDoc{{0}, {1}, {2}}
+			assert.Equal(t, want, first, "expected documents %v, got %v", want, first)
+			assert.Equal(t, want, second, "expected documents %v, got %v", want, second)
+
+			// After a getMore, only the new batch can be re-read.
+			third := decodeBatch(t, cursor, 2)
+			require.NoError(t, cursor.Rewind(), "Rewind error")
+			fourth := decodeBatch(t, cursor, 3)
+			assert.Equal(t, []fooDoc{{3}, {4}}, third, "expected documents %v, got %v", []fooDoc{{3}, {4}}, third)
+			want = []fooDoc{{3}, {4}, {5}}
+			assert.Equal(t, want, fourth, "expected documents %v, got %v", want, fourth)
+		})
+		t.Run("exhausted cursor", func(t *testing.T) {
+			docs := []interface{}{bson.D{{"foo", int32(0)}}, bson.D{{"foo", int32(1)}}}
+			cursor, err := NewCursorFromDocuments(docs, nil, nil)
+			require.NoError(t, err, "NewCursorFromDocuments error")
+
+			first := decodeBatch(t, cursor, 2)
+			assert.False(t, cursor.Next(context.Background()), "expected Next to return false")
+			require.NoError(t, cursor.Rewind(), "Rewind error")
+			second := decodeBatch(t, cursor, 2)
+			assert.Equal(t, first, second, "expected documents %v, got %v", first, second)
+		})
+		t.Run("before Next", func(t *testing.T) {
+			cursor, err := newCursor(newTestBatchCursor(1, 2), nil, nil)
+			require.NoError(t, err, "newCursor error")
+
+			require.NoError(t, cursor.Rewind(), "Rewind error")
+			got := decodeBatch(t, cursor, 2)
+			assert.Equal(t, []fooDoc{{0}, {1}}, got, "expected documents %v, got %v", []fooDoc{{0}, {1}}, got)
+		})
+		t.Run("batch discarded by empty getMore", func(t *testing.T) {
+			tbc := newTestBatchCursor(1, 2)
+			tbc.batches = append(tbc.batches, &bsoncore.DocumentSequence{Style: bsoncore.SequenceStyle})
+			cursor, err := newCursor(tbc, nil, nil)
+			require.NoError(t, err, "newCursor error")
+
+			decodeBatch(t, cursor, 2)
+			assert.False(t, cursor.TryNext(context.Background()), "expected TryNext to return false")
+			err = cursor.Rewind()
+			assert.Equal(t, ErrCursorBatchDiscarded, err, "expected error %v, got %v", ErrCursorBatchDiscarded, err)
+		})
+	})
+	t.Run("cancel in-flight getMore", func(t *testing.T) {
+		// The server never replies to the getMore, as if it were waiting for the whole maxAwaitTime.
+		client, conn := newChannelConnClient(t, options.Client(), changeStreamReply(t, "firstBatch", 1))
+		conn.Written = make(chan []byte, 2)
+		findOpts := options.Find().SetCursorType(options.TailableAwait).SetMaxAwaitTime(time.Hour)
+		cursor, err := client.Database("foo").Collection("bar").Find(context.Background(), bson.D{}, findOpts)
+		require.NoError(t, err, "Find error")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(50*time.Millisecond, cancel)
+		start := time.Now()
+		assert.False(t, cursor.Next(ctx), "expected Next to return false")
+		elapsed := time.Since(start)
+
+		assert.True(t, elapsed < 5*time.Second, "expected Next to return promptly, took %v", elapsed)
+		assert.True(t, errors.Is(cursor.Err(), context.Canceled),
+			"expected error %v, got %v", context.Canceled, cursor.Err())
+		assert.Equal(t, 2, len(conn.Written), "expected 2 commands to be sent, got %v", len(conn.Written))
+	})
+}
+
+type errorWriter struct {
+	err error
+}
+
+func (ew errorWriter) Write([]byte) (int, error) {
+	return 0, ew.err
+}
+
+func BenchmarkCursorAll(b *testing.B) {
+	const numBatches, batchSize = 10, 100
+
+	benchmarks := []struct {
+		name string
+		all  func(*Cursor, *[]bson.Raw) error
+	}{
+		{
+			name: "All",
+			all: func(c *Cursor, docs *[]bson.Raw) error {
+				return c.All(context.Background(), docs)
+			},
+		},
+		{
+			name: "AllWithCapacity",
+			all: func(c *Cursor, docs *[]bson.Raw) error {
+				return c.AllWithCapacity(context.Background(), docs, numBatches*batchSize)
+			},
+		},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				cursor, err := newCursor(newTestBatchCursor(numBatches, batchSize), nil, nil)
+				if err != nil {
+					b.Fatalf("newCursor error: %v", err)
+				}
+				b.StartTimer()
+
+				var docs []bson.Raw
+				if err := bm.all(cursor, &docs); err != nil {
+					b.Fatalf("error getting all results: %v", err)
+				}
+			}
+		})
+	}
 }
 
 func TestNewCursorFromDocuments(t *testing.T) {
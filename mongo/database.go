@@ -179,6 +179,12 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
 		cursorOpts := db.client.createBaseCursorOptions()
 
 		cursorOpts.MarshalValueEncoderFn = newEncoderFn(db.bsonOpts, db.registry)
+		if ro.MaxAwaitTime != nil {
+			if ro.CursorType == nil || *ro.CursorType != options.TailableAwait {
+				return nil, sess, errors.New("the MaxAwaitTime option can only be used with the TailableAwait cursor type")
+			}
+			cursorOpts.MaxTimeMS = int64(*ro.MaxAwaitTime / time.Millisecond)
+		}
 
 		op = operation.NewCursorCommand(runCmdDoc, cursorOpts)
 	default:
@@ -192,6 +198,7 @@ func (db *Database) processRunCommand(ctx context.Context, cmd interface{},
 		ServerSelector(readSelect).ClusterClock(db.client.clock).
 		Database(db.name).Deployment(db.client.deployment).ReadConcern(db.readConcern).
 		Crypt(db.client.cryptFLE).ReadPreference(ro.ReadPreference).ServerAPI(db.client.serverAPI).
+		RequestIDFn(db.client.requestIDFn).
 		Timeout(db.client.timeout).Logger(db.client.logger), sess, nil
 }
 
@@ -240,6 +247,11 @@ func (db *Database) RunCommand(ctx context.Context, runCommand interface{}, opts
 //
 // The opts parameter can be used to specify options for this operation (see the options.RunCmdOptions documentation).
 //
+// If the command creates a tailable cursor, the returned Cursor can be iterated like one returned by Collection.Find
+// with a tailable cursor type: Next blocks across empty batches until a document is available, and TryNext returns
+// false after an empty batch without closing the cursor. For tailable await cursors, set the CursorType option to
+// TailableAwait and the MaxAwaitTime option to bound how long the server waits on each getMore.
+//
 // The behavior of RunCommandCursor is undefined if the command document contains any of the following:
 // - A session ID or any transaction-specific fields
 // - API versioning options when an API version is already declared on the Client
@@ -305,7 +317,7 @@ func (db *Database) Drop(ctx context.Context) error {
 		Session(sess).WriteConcern(wc).CommandMonitor(db.client.monitor).
 		ServerSelector(selector).ClusterClock(db.client.clock).
 		Database(db.name).Deployment(db.client.deployment).Crypt(db.client.cryptFLE).
-		ServerAPI(db.client.serverAPI)
+		ServerAPI(db.client.serverAPI).RequestIDFn(db.client.requestIDFn)
 
 	err = op.Execute(ctx)
 
@@ -399,7 +411,7 @@ func (db *Database) ListCollections(ctx context.Context, filter interface{}, opt
 		Session(sess).ReadPreference(db.readPreference).CommandMonitor(db.client.monitor).
 		ServerSelector(selector).ClusterClock(db.client.clock).
 		Database(db.name).Deployment(db.client.deployment).Crypt(db.client.cryptFLE).
-		ServerAPI(db.client.serverAPI).Timeout(db.client.timeout)
+		ServerAPI(db.client.serverAPI).RequestIDFn(db.client.requestIDFn).Timeout(db.client.timeout)
 
 	cursorOpts := db.client.createBaseCursorOptions()
 
@@ -420,7 +432,7 @@ func (db *Database) ListCollections(ctx context.Context, filter interface{}, opt
 	if db.client.retryReads {
 		retry = driver.RetryOncePerCommand
 	}
-	op = op.Retry(retry)
+	op = op.Retry(retry).MaxRetryDuration(db.client.maxRetryDuration)
 
 	err = op.Execute(ctx)
 	if err != nil {
@@ -499,7 +511,8 @@ func (db *Database) WriteConcern() *writeconcern.WriteConcern {
 // https://www.mongodb.com/docs/manual/changeStreams/ for more information about change streams.
 //
 // The Database must be configured with read concern majority or no read concern for a change stream to be created
-// successfully.
+// successfully. Watch returns an error without contacting the server if the read concern level is one that change
+// streams never support (available, linearizable, or snapshot).
 //
 // The pipeline parameter must be a slice of documents, each representing a pipeline stage. The pipeline cannot be
 // nil but can be empty. The stage documents must all be non-nil. See https://www.mongodb.com/docs/manual/changeStreams/ for
@@ -676,7 +689,7 @@ func (db *Database) createCollection(ctx context.Context, name string, opts ...*
 
 func (db *Database) createCollectionOperation(name string, opts ...*options.CreateCollectionOptions) (*operation.Create, error) {
 	cco := options.MergeCreateCollectionOptions(opts...)
-	op := operation.NewCreate(name).ServerAPI(db.client.serverAPI)
+	op := operation.NewCreate(name).ServerAPI(db.client.serverAPI).RequestIDFn(db.client.requestIDFn)
 
 	if cco.Capped != nil {
 		op.Capped(*cco.Capped)
@@ -802,7 +815,7 @@ func (db *Database) CreateView(ctx context.Context, viewName, viewOn string, pip
 	op := operation.NewCreate(viewName).
 		ViewOn(viewOn).
 		Pipeline(pipelineArray).
-		ServerAPI(db.client.serverAPI)
+		ServerAPI(db.client.serverAPI).RequestIDFn(db.client.requestIDFn)
 	cvo := options.MergeCreateViewOptions(opts...)
 	if cvo.Collation != nil {
 		op.Collation(bsoncore.Document(cvo.Collation.ToDocument()))
@@ -19,6 +19,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/drivertest"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 )
 
@@ -132,6 +133,56 @@ func TestDatabase(t *testing.T) {
 			assert.True(t, le.HasErrorLabel("TransientTransactionError"), `expected error to include the "TransientTransactionError" label`)
 		})
 	})
+	t.Run("RunCommandCursor tailable await cursor", func(t *testing.T) {
+		emptyBatch := changeStreamReply(t, "nextBatch", 1)
+		killCursorsReply, err := bson.Marshal(bson.D{{"ok", 1}})
+		assert.Nil(t, err, "Marshal error: %v", err)
+		client, conn := newChannelConnClient(t, options.Client(),
+			changeStreamReply(t, "firstBatch", 1),
+			emptyBatch,
+			emptyBatch,
+			changeStreamReply(t, "nextBatch", 1, bson.D{{"x", 1}}, bson.D{{"x", 2}}),
+			drivertest.MakeReply(killCursorsReply),
+		)
+
+		cmd := bson.D{{"find", "bar"}, {"tailable", true}, {"awaitData", true}}
+		opts := options.RunCmd().SetCursorType(options.TailableAwait).SetMaxAwaitTime(100 * time.Millisecond)
+		cursor, err := client.Database("foo").RunCommandCursor(bgCtx, cmd, opts)
+		assert.Nil(t, err, "RunCommandCursor error: %v", err)
+		defer cursor.Close(bgCtx)
+
+		// TryNext returns after one empty batch without closing the cursor, and Next waits for documents.
+		assert.False(t, cursor.TryNext(bgCtx), "expected TryNext to return false")
+		assert.Nil(t, cursor.Err(), "cursor error: %v", cursor.Err())
+		assert.False(t, cursor.Exhausted(), "expected cursor to not be exhausted")
+		for _, want := range []int32{1, 2} {
+			assert.True(t, cursor.Next(bgCtx), "Next error: %v", cursor.Err())
+			got := cursor.Current.Lookup("x").Int32()
+			assert.Equal(t, want, got, "expected x to be %v, got %v", want, got)
+		}
+
+		assert.Equal(t, 4, len(conn.Written), "expected 4 commands to be sent, got %v", len(conn.Written))
+		<-conn.Written
+		for i := 0; i < 3; i++ {
+			getMore, err := drivertest.GetCommandFromMsgWireMessage(<-conn.Written)
+			assert.Nil(t, err, "GetCommandFromMsgWireMessage error: %v", err)
+			maxTimeMS := getMore.Lookup("maxTimeMS").AsInt64()
+			assert.Equal(t, int64(100), maxTimeMS, "expected maxTimeMS 100 on getMore, got %v", maxTimeMS)
+		}
+	})
+	t.Run("RunCommandCursor MaxAwaitTime requires TailableAwait", func(t *testing.T) {
+		client, conn := newChannelConnClient(t, options.Client())
+		cmd := bson.D{{"find", "bar"}, {"tailable", true}}
+
+		for _, opts := range []*options.RunCmdOptions{
+			options.RunCmd().SetMaxAwaitTime(time.Second),
+			options.RunCmd().SetCursorType(options.Tailable).SetMaxAwaitTime(time.Second),
+		} {
+			_, err := client.Database("foo").RunCommandCursor(bgCtx, cmd, opts)
+			assert.ErrorContains(t, err, "can only be used with the TailableAwait cursor type")
+		}
+		assert.Equal(t, 0, len(conn.Written), "expected no commands to be sent, got %v", len(conn.Written))
+	})
 	t.Run("nil document error", func(t *testing.T) {
 		db := setupDb("foo")
 
@@ -33,6 +33,10 @@ var ErrNilDocument = errors.New("document is nil")
 // ErrNilValue is returned when a nil value is passed to a CRUD method.
 var ErrNilValue = errors.New("value is nil")
 
+// ErrCursorBatchDiscarded is returned by Cursor.Rewind if the documents in the cursor's current batch were discarded
+// by a getMore that did not return any documents.
+var ErrCursorBatchDiscarded = errors.New("cannot rewind cursor: the current batch was discarded by a getMore")
+
 // ErrEmptySlice is returned when an empty slice is passed to a CRUD method that requires a non-empty slice.
 var ErrEmptySlice = errors.New("must provide at least one element in input slice")
 
@@ -114,7 +118,9 @@ func IsDuplicateKeyError(err error) bool {
 	return false
 }
 
-// IsTimeout returns true if err is from a timeout
+// IsTimeout returns true if err is from a timeout. This includes client-side timeouts (e.g. a context deadline being
+// exceeded or server selection timing out), server-side timeouts (e.g. a MaxTimeMSExpired error with code 50), and
+// network timeouts.
 func IsTimeout(err error) bool {
 	for ; err != nil; err = unwrap(err) {
 		// check unwrappable errors together
@@ -130,6 +136,9 @@ func IsTimeout(err error) bool {
 		if _, ok := err.(topology.WaitQueueTimeoutError); ok {
 			return true
 		}
+		if _, ok := err.(ChangeStreamWaitTimeoutError); ok {
+			return true
+		}
 		if ce, ok := err.(CommandError); ok && ce.IsMaxTimeMSExpiredError() {
 			return true
 		}
@@ -137,8 +146,12 @@ func IsTimeout(err error) bool {
 			we.WriteConcernError.IsMaxTimeMSExpiredError() {
 			return true
 		}
-		if ne, ok := err.(net.Error); ok {
-			return ne.Timeout()
+		if bwe, ok := err.(BulkWriteException); ok && bwe.WriteConcernError != nil &&
+			bwe.WriteConcernError.IsMaxTimeMSExpiredError() {
+			return true
+		}
+		if ne, ok := err.(net.Error); ok && ne.Timeout() {
+			return true
 		}
 		//timeout error labels
 		if le, ok := err.(LabeledError); ok {
@@ -177,6 +190,44 @@ func IsNetworkError(err error) bool {
 	return errorHasLabel(err, "NetworkError")
 }
 
+// IsNonResumableChangeStreamError returns true if err has the "NonResumableChangeStreamError" label. A change stream
+// never resumes after an error with this label, so the error is returned by ChangeStream.Err.
+func IsNonResumableChangeStreamError(err error) bool {
+	return errorHasLabel(err, nonResumableErrorLabel)
+}
+
+// IsRetryableRead returns true if err would cause the driver to retry a read operation, such as Find, Aggregate, or
+// the aggregate that opens a change stream, when retryable reads are enabled. This is the case for network errors
+// and for server errors with a retryable error code (e.g. NotWritablePrimary, HostUnreachable, or
+// ShutdownInProgress).
+func IsRetryableRead(err error) bool {
+	for ; err != nil; err = unwrap(err) {
+		switch e := err.(type) {
+		case driver.Error:
+			if e.RetryableRead() {
+				return true
+			}
+		case CommandError:
+			if (driver.Error{Code: e.Code, Labels: e.Labels}).RetryableRead() {
+				return true
+			}
+		case LabeledError:
+			if e.HasErrorLabel(driver.NetworkError) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsRetryableWrite returns true if err would cause the driver to retry a write operation when retryable writes are
+// enabled. This is the case for errors with the "RetryableWriteError" or "NetworkError" labels. Servers 4.4 and newer
+// attach the RetryableWriteError label to retryable errors, and the driver adds it to retryable errors from older
+// servers when retryable writes are enabled.
+func IsRetryableWrite(err error) bool {
+	return errorHasLabel(err, driver.RetryableWriteError) || errorHasLabel(err, driver.NetworkError)
+}
+
 // MongocryptError represents an libmongocrypt error during client-side encryption.
 type MongocryptError struct {
 	Code    int32
@@ -7,11 +7,16 @@
 package mongo
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/x/mongo/driver"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 )
 
 func TestErrorMessages(t *testing.T) {
@@ -70,3 +75,117 @@ func TestErrorMessages(t *testing.T) {
 		})
 	}
 }
+
+type netErr struct {
+	timeout bool
+}
+
+func (netErr) Error() string    { return "network error" }
+func (ne netErr) Timeout() bool { return ne.timeout }
+func (netErr) Temporary() bool  { return false }
+
+func TestIsTimeout(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"context deadline exceeded", context.DeadlineExceeded, true},
+		{
+			"wrapped context deadline exceeded",
+			CommandError{Message: "operation failed", Wrapped: context.DeadlineExceeded},
+			true,
+		},
+		{"deadline would be exceeded", driver.ErrDeadlineWouldBeExceeded, true},
+		{"server selection timeout", topology.ErrServerSelectionTimeout, true},
+		{"wait queue timeout", topology.WaitQueueTimeoutError{}, true},
+		{"MaxTimeMSExpired command error", CommandError{Code: 50, Name: "MaxTimeMSExpired"}, true},
+		{
+			"MaxTimeMSExpired write concern error",
+			WriteException{WriteConcernError: &WriteConcernError{Code: 50}},
+			true,
+		},
+		{
+			"MaxTimeMSExpired bulk write concern error",
+			BulkWriteException{WriteConcernError: &WriteConcernError{Code: 50}},
+			true,
+		},
+		{"network timeout", netErr{timeout: true}, true},
+		{
+			"wrapped network timeout",
+			topology.ConnectionError{Wrapped: netErr{timeout: true}},
+			true,
+		},
+		{"non-timeout network error", netErr{timeout: false}, false},
+		{"NetworkTimeoutError label", CommandError{Labels: []string{"NetworkTimeoutError"}}, true},
+		{"ExceededTimeLimitError label", CommandError{Labels: []string{"ExceededTimeLimitError"}}, true},
+		{"context canceled", context.Canceled, false},
+		{"other command error", CommandError{Code: 11000, Name: "DuplicateKey"}, false},
+		{"other error", errors.New("foo"), false},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := IsTimeout(tc.err)
+			assert.Equal(t, tc.want, got, "expected IsTimeout(%v) to be %v, got %v", tc.err, tc.want, got)
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		err       error
+		wantRead  bool
+		wantWrite bool
+	}{
+		{"nil", nil, false, false},
+		{"other error", errors.New("foo"), false, false},
+		{"NetworkError label", CommandError{Labels: []string{driver.NetworkError}}, true, true},
+		{"RetryableWriteError label", CommandError{Code: 91, Labels: []string{driver.RetryableWriteError}}, true, true},
+		{"retryable code without label", CommandError{Code: 10107, Name: "NotWritablePrimary"}, true, false},
+		{"non-retryable code", CommandError{Code: 11000, Name: "DuplicateKey"}, false, false},
+		{"driver error with retryable code", driver.Error{Code: 189}, true, false},
+		{
+			"wrapped network error",
+			fmt.Errorf("watch failed: %w", CommandError{Labels: []string{driver.NetworkError}}),
+			true,
+			true,
+		},
+		{
+			"write exception with RetryableWriteError label",
+			WriteException{
+				WriteConcernError: &WriteConcernError{Code: 91},
+				Labels:            []string{driver.RetryableWriteError},
+			},
+			false,
+			true,
+		},
+		{
+			"bulk write exception without labels",
+			BulkWriteException{WriteConcernError: &WriteConcernError{Code: 91}},
+			false,
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := IsRetryableRead(tc.err)
+			assert.Equal(t, tc.wantRead, got, "expected IsRetryableRead(%v) to be %v, got %v", tc.err, tc.wantRead, got)
+			got = IsRetryableWrite(tc.err)
+			assert.Equal(t, tc.wantWrite, got, "expected IsRetryableWrite(%v) to be %v, got %v", tc.err, tc.wantWrite, got)
+		})
+	}
+}
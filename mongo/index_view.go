@@ -91,6 +91,7 @@ func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOption
 		ServerSelector(selector).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).
 		Deployment(iv.coll.client.deployment).ServerAPI(iv.coll.client.serverAPI).
+		RequestIDFn(iv.coll.client.requestIDFn).
 		Timeout(iv.coll.client.timeout)
 
 	cursorOpts := iv.coll.client.createBaseCursorOptions()
@@ -107,7 +108,7 @@ func (iv IndexView) List(ctx context.Context, opts ...*options.ListIndexesOption
 	if iv.coll.client.retryReads {
 		retry = driver.RetryOncePerCommand
 	}
-	op.Retry(retry)
+	op.Retry(retry).MaxRetryDuration(iv.coll.client.maxRetryDuration)
 
 	err = op.Execute(ctx)
 	if err != nil {
@@ -255,6 +256,7 @@ func (iv IndexView) CreateMany(ctx context.Context, models []IndexModel, opts ..
 		Session(sess).WriteConcern(wc).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).CommandMonitor(iv.coll.client.monitor).
 		Deployment(iv.coll.client.deployment).ServerSelector(selector).ServerAPI(iv.coll.client.serverAPI).
+		RequestIDFn(iv.coll.client.requestIDFn).
 		Timeout(iv.coll.client.timeout).MaxTime(option.MaxTime)
 	if option.CommitQuorum != nil {
 		commitQuorum, err := marshalValue(option.CommitQuorum, iv.coll.bsonOpts, iv.coll.registry)
@@ -392,6 +394,7 @@ func (iv IndexView) drop(ctx context.Context, name string, opts ...*options.Drop
 		ServerSelector(selector).ClusterClock(iv.coll.client.clock).
 		Database(iv.coll.db.name).Collection(iv.coll.name).
 		Deployment(iv.coll.client.deployment).ServerAPI(iv.coll.client.serverAPI).
+		RequestIDFn(iv.coll.client.requestIDFn).
 		Timeout(iv.coll.client.timeout).MaxTime(dio.MaxTime)
 
 	err = op.Execute(ctx)
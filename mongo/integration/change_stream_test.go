@@ -8,6 +8,8 @@ package integration
 
 import (
 	"context"
+	"errors"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -22,6 +24,9 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
 )
 
 type resumeType int
@@ -120,6 +125,131 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 		getMorePbrt := evt.Reply.Lookup("cursor", "postBatchResumeToken").Document()
 		assert.Equal(mt, newToken, getMorePbrt, "expected resume token %v, got %v", getMorePbrt, newToken)
 	})
+	causalWatchOpts := mtest.NewOptions().
+		MinServerVersion("4.0").
+		ClientOptions(options.Client().SetDefaultCausalConsistencyForWatch(true))
+	mt.RunOpts("default causal consistency for watch", causalWatchOpts, func(mt *mtest.T) {
+		// The aggregate for a change stream without an explicit session should read after the latest cluster time
+		// seen by the client, which is at least the operationTime of the preceding write.
+
+		sess, err := mt.Client.StartSession()
+		require.NoError(mt, err, "StartSession error")
+		defer sess.EndSession(context.Background())
+		err = mongo.WithSession(context.Background(), sess, func(sc mongo.SessionContext) error {
+			_, err := mt.Coll.InsertOne(sc, bson.D{{"x", 1}})
+			return err
+		})
+		require.NoError(mt, err, "InsertOne error")
+		opTime := sess.OperationTime()
+		require.NotNil(mt, opTime, "expected an operation time, got nil")
+
+		mt.ClearEvents()
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		evt := mt.GetStartedEvent()
+		require.NotNil(mt, evt, "expected aggregate event, got nil")
+		t, i, ok := evt.Command.Lookup("readConcern", "afterClusterTime").TimestampOK()
+		require.True(mt, ok, "expected afterClusterTime in aggregate command, got %v", evt.Command)
+		after := primitive.Timestamp{T: t, I: i}
+		assert.False(mt, after.Before(*opTime),
+			"expected afterClusterTime %v to be at least the insert operationTime %v", after, *opTime)
+	})
+	mt.RunOpts("no causal consistency for watch by default", mtest.NewOptions().MinServerVersion("4.0"),
+		func(mt *mtest.T) {
+			generateEvents(mt, 1)
+
+			mt.ClearEvents()
+			cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+			require.NoError(mt, err, "Watch error")
+			defer closeStream(cs)
+
+			evt := mt.GetStartedEvent()
+			require.NotNil(mt, evt, "expected aggregate event, got nil")
+			_, err = evt.Command.LookupErr("readConcern", "afterClusterTime")
+			assert.NotNil(mt, err, "expected no afterClusterTime in aggregate command, got %v", evt.Command)
+		})
+	mt.RunOpts("last cluster time updated on empty batch", mtest.NewOptions().MinServerVersion("4.0.7"),
+		func(mt *mtest.T) {
+			// The last cluster time is advanced by an empty batch using the server's post batch resume token.
+
+			cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+			require.NoError(mt, err, "Watch error")
+			defer closeStream(cs)
+
+			generateEvents(mt, 1)
+			require.True(mt, cs.Next(context.Background()), "expected Next to return true, got false")
+			first := cs.LastClusterTime()
+			require.NotNil(mt, first, "expected a cluster time, got nil")
+
+			// cause an event on a different collection than the one being watched so the server's PBRT is updated
+			diffColl := mt.CreateCollection(mtest.Collection{Name: "diffCollLastClusterTime"}, false)
+			_, err = diffColl.InsertOne(context.Background(), bson.D{{"x", 1}})
+			require.NoError(mt, err, "InsertOne error")
+
+			mt.ClearEvents()
+			assert.False(mt, cs.TryNext(context.Background()), "unexpected event document: %v", cs.Current)
+			require.NoError(mt, cs.Err(), "change stream error getting new batch")
+
+			evt := mt.GetSucceededEvent()
+			assert.Equal(mt, "getMore", evt.CommandName, "expected event for 'getMore', got '%v'", evt.CommandName)
+			docs, err := evt.Reply.LookupErr("cursor", "nextBatch")
+			require.NoError(mt, err, "nextBatch not found in getMore reply")
+			assert.Equal(mt, 0, len(docs.Array()), "expected empty batch, got %v", docs)
+
+			last := cs.LastClusterTime()
+			require.NotNil(mt, last, "expected a cluster time, got nil")
+			assert.True(mt, first.Before(*last), "expected cluster time to advance past %v, got %v", *first, *last)
+		})
+	mt.RunOpts("last cluster time", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		pbrtResponse := func(batchIdentifier mtest.BatchIdentifier, clusterTime string) bson.D {
+			return bson.D{
+				{"ok", 1},
+				{"cursor", bson.D{
+					{"id", int64(1)},
+					{"ns", ns},
+					{string(batchIdentifier), bson.A{}},
+					{"postBatchResumeToken", bson.D{{"_data", "82" + clusterTime + "2B0229296E04"}}},
+				}},
+			}
+		}
+		opTimeResponse := func(batchIdentifier mtest.BatchIdentifier, opTime primitive.Timestamp) bson.D {
+			return bson.D{
+				{"ok", 1},
+				{"cursor", bson.D{
+					{"id", int64(1)},
+					{"ns", ns},
+					{string(batchIdentifier), bson.A{}},
+				}},
+				{"operationTime", opTime},
+			}
+		}
+		mt.AddMockResponses(
+			pbrtResponse(mtest.FirstBatch, "0000000A00000001"),
+			pbrtResponse(mtest.NextBatch, "0000000B00000001"),
+			opTimeResponse(mtest.NextBatch, primitive.Timestamp{T: 12, I: 1}),
+			pbrtResponse(mtest.NextBatch, "0000000900000001"), // older than the last cluster time
+			mtest.CreateSuccessResponse(),                     // killCursors
+		)
+
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		want := []primitive.Timestamp{{T: 10, I: 1}, {T: 11, I: 1}, {T: 12, I: 1}, {T: 12, I: 1}}
+		for i, ts := range want {
+			if i > 0 {
+				assert.False(mt, cs.TryNext(context.Background()), "expected TryNext to return false")
+				require.NoError(mt, cs.Err(), "change stream error")
+			}
+
+			lct := cs.LastClusterTime()
+			require.NotNil(mt, lct, "expected a cluster time, got nil")
+			assert.Equal(mt, ts, *lct, "expected cluster time %v, got %v", ts, *lct)
+		}
+	})
 	mt.Run("missing resume token", func(mt *mtest.T) {
 		// ChangeStream will throw an exception if the server response is missing the resume token
 
@@ -190,6 +320,40 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 		comparisonErr := compareDocs(mt, newResumeTokenRaw, cs.ResumeToken())
 		assert.Nil(mt, comparisonErr, "expected resume token %s, got %s", newResumeTokenRaw, cs.ResumeToken())
 	})
+	mt.RunOpts("resume with mock builder", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		// The responses built by ChangeStreamMockBuilder open a change stream, fail the getMore with a resumable
+		// error, and resume it with a new cursor.
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		newResumeToken := bson.D{{"second", "resume token"}}
+		responses := mtest.NewChangeStreamMockBuilder(ns).
+			Aggregate(bson.D{{"_id", bson.D{{"first", "resume token"}}}}).
+			GetMoreError(errorHostUnreachable, resumableChangeStreamError).
+			KillCursorsSuccess().
+			ResumedAggregate(bson.D{{"_id", newResumeToken}}).
+			Responses()
+		assert.Equal(mt, 4, len(responses), "expected 4 responses, got %v", len(responses))
+		mt.AddMockResponses(responses...)
+
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+		assert.Nil(mt, err, "Watch error: %v", err)
+		defer closeStream(cs)
+		assert.Equal(mt, int64(1), cs.ID(), "expected change stream ID to be 1, got %d", cs.ID())
+		assert.True(mt, cs.Next(context.Background()), "expected Next to return true, got false")
+
+		mt.ClearEvents()
+		assert.True(mt, cs.Next(context.Background()), "expected Next to return true, got false")
+		for _, name := range []string{"getMore", "killCursors", "aggregate"} {
+			evt := mt.GetStartedEvent()
+			assert.NotNil(mt, evt, "expected %v event, got nil", name)
+			assert.Equal(mt, name, evt.CommandName, "expected command name %q, got %q", name, evt.CommandName)
+		}
+
+		assert.Equal(mt, int64(2), cs.ID(), "expected change stream ID to be 2, got %d", cs.ID())
+		newResumeTokenRaw, err := bson.Marshal(newResumeToken)
+		assert.Nil(mt, err, "Marshal error: %v", err)
+		comparisonErr := compareDocs(mt, newResumeTokenRaw, cs.ResumeToken())
+		assert.Nil(mt, comparisonErr, "expected resume token %s, got %s", newResumeTokenRaw, cs.ResumeToken())
+	})
 	mt.RunOpts("no resume for aggregate errors", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
 		// ChangeStream will not attempt to resume on any error encountered while executing an aggregate command
 
@@ -220,9 +384,273 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 
 		assert.False(mt, cs.Next(context.Background()), "expected Next to return false, got true")
 	})
-	mt.RunOpts("server selection before resume", mtest.NewOptions().CreateClient(false), func(mt *mtest.T) {
-		// ChangeStream will perform server selection before attempting to resume, using initial readPreference
-		mt.Skip("skipping for lack of SDAM monitoring")
+	mt.RunOpts("manual resume resets error", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		// The automatic resume attempt fails, so Next returns false. A successful manual Resume should clear the
+		// stored error and allow iteration to continue.
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		resumableErr := mtest.CommandError{
+			Code:    errorHostUnreachable,
+			Name:    "foo",
+			Message: "bar",
+			Labels:  []string{resumableChangeStreamError},
+		}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(1, ns, mtest.FirstBatch),
+			mtest.CreateCommandErrorResponse(resumableErr), // getMore
+			mtest.CreateSuccessResponse(),                  // killCursors
+			mtest.CreateCommandErrorResponse(resumableErr), // resumed aggregate
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{"_id", bson.D{{"_data", "1"}}}}),
+		)
+
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		require.False(mt, cs.Next(context.Background()), "expected Next to return false, got true")
+		require.Error(mt, cs.Err(), "expected change stream error, got nil")
+
+		mt.ClearEvents()
+		err = cs.Resume(context.Background())
+		require.NoError(mt, err, "Resume error")
+		assert.NoError(mt, cs.Err(), "expected error to be reset after Resume")
+
+		evt := mt.GetStartedEvent()
+		require.NotNil(mt, evt, "expected aggregate event, got nil")
+		assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate', got %q", evt.CommandName)
+		assert.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+	})
+	mt.RunOpts("resumable error classifier", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		proxyErr := mtest.CommandError{Code: 12345, Name: "ProxyError", Message: "proxy restarted"}
+		resumableErr := mtest.CommandError{
+			Code:    errorHostUnreachable,
+			Name:    "foo",
+			Message: "bar",
+			Labels:  []string{resumableChangeStreamError},
+		}
+		isProxyErr := func(err error) bool {
+			var ce mongo.CommandError
+			return errors.As(err, &ce) && ce.Code == proxyErr.Code
+		}
+		countAggregates := func(mt *mtest.T) int {
+			var count int
+			for _, evt := range mt.GetAllStartedEvents() {
+				if evt.CommandName == "aggregate" {
+					count++
+				}
+			}
+			return count
+		}
+
+		mt.Run("extends default rules", func(mt *mtest.T) {
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(1, ns, mtest.FirstBatch),
+				mtest.CreateCommandErrorResponse(proxyErr), // getMore
+				mtest.CreateSuccessResponse(),              // killCursors
+				mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, bson.D{{"_id", bson.D{{"_data", "1"}}}}),
+			)
+
+			opts := options.ChangeStream().SetResumableErrorClassifier(isProxyErr)
+			cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+			require.NoError(mt, err, "Watch error")
+			defer closeStream(cs)
+
+			assert.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+			assert.Equal(mt, 2, countAggregates(mt), "expected the change stream to resume")
+		})
+		mt.Run("not resumable without classifier", func(mt *mtest.T) {
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(1, ns, mtest.FirstBatch),
+				mtest.CreateCommandErrorResponse(proxyErr), // getMore
+			)
+
+			cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+			require.NoError(mt, err, "Watch error")
+			defer closeStream(cs)
+
+			assert.False(mt, cs.Next(context.Background()), "expected Next to return false, got true")
+			assert.Error(mt, cs.Err(), "expected change stream error, got nil")
+			assert.Equal(mt, 1, countAggregates(mt), "expected the change stream not to resume")
+		})
+		mt.Run("replaces default rules", func(mt *mtest.T) {
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(1, ns, mtest.FirstBatch),
+				mtest.CreateCommandErrorResponse(resumableErr), // getMore
+			)
+
+			opts := options.ChangeStream().
+				SetResumableErrorClassifier(isProxyErr).
+				SetReplaceDefaultResumableErrors(true)
+			cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+			require.NoError(mt, err, "Watch error")
+			defer closeStream(cs)
+
+			assert.False(mt, cs.Next(context.Background()), "expected Next to return false, got true")
+			assert.Error(mt, cs.Err(), "expected change stream error, got nil")
+			assert.Equal(mt, 1, countAggregates(mt), "expected the change stream not to resume")
+		})
+	})
+	mt.RunOpts("max staleness", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		event := func(token string, wallTime time.Time) bson.D {
+			return bson.D{
+				{"_id", bson.D{{"_data", token}}},
+				{"operationType", "insert"},
+				{"wallTime", primitive.NewDateTimeFromTime(wallTime)},
+			}
+		}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			event("1", time.Now()),
+			event("2", time.Now().Add(-time.Hour)),
+		))
+
+		opts := options.ChangeStream().SetMaxStaleness(time.Minute)
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		assert.False(mt, cs.Next(context.Background()), "expected Next to return false for a stale event, got true")
+
+		var staleErr mongo.ChangeStreamStalenessError
+		require.True(mt, errors.As(cs.Err(), &staleErr), "expected ChangeStreamStalenessError, got %v", cs.Err())
+		assert.Equal(mt, time.Minute, staleErr.MaxStaleness, "expected MaxStaleness 1m, got %v", staleErr.MaxStaleness)
+		assert.True(mt, staleErr.Staleness > time.Minute, "expected staleness over 1m, got %v", staleErr.Staleness)
+
+		// The stale event should not be consumed.
+		token := cs.ResumeToken().Lookup("_data").StringValue()
+		assert.Equal(mt, "1", token, "expected resume token of the last consumed event, got %q", token)
+	})
+	mt.RunOpts("max staleness with delayed processing", mtest.NewOptions().MinServerVersion("6.0"), func(mt *mtest.T) {
+		opts := options.ChangeStream().SetMaxStaleness(100 * time.Millisecond)
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		_, err = mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+		require.NoError(mt, err, "InsertOne error")
+
+		// Simulate a consumer that can't keep up.
+		time.Sleep(500 * time.Millisecond)
+		assert.False(mt, cs.Next(context.Background()), "expected Next to return false for a stale event, got true")
+
+		var staleErr mongo.ChangeStreamStalenessError
+		assert.True(mt, errors.As(cs.Err(), &staleErr), "expected ChangeStreamStalenessError, got %v", cs.Err())
+	})
+	mt.RunOpts("current wall time", mtest.NewOptions().MinServerVersion("6.0"), func(mt *mtest.T) {
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		before := time.Now()
+		_, err = mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+		require.NoError(mt, err, "InsertOne error")
+		after := time.Now()
+
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		wallTime, ok := cs.CurrentWallTime()
+		require.True(mt, ok, "expected event to have a wallTime, got %v", cs.Current)
+
+		// Allow for clock skew between the test runner and the server.
+		const skew = time.Minute
+		assert.True(mt, wallTime.After(before.Add(-skew)) && wallTime.Before(after.Add(skew)),
+			"expected wallTime between %v and %v, got %v", before, after, wallTime)
+	})
+	mt.RunOpts("current wall time missing", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+			bson.D{{"_id", bson.D{{"_data", "1"}}}, {"operationType", "insert"}},
+		))
+
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		wallTime, ok := cs.CurrentWallTime()
+		assert.False(mt, ok, "expected no wallTime, got %v", wallTime)
+	})
+	mt.RunOpts("post batch hook", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		cursorResponse := func(batchKey, pbrt string, events ...interface{}) bson.D {
+			return bson.D{
+				{"ok", 1},
+				{"cursor", bson.D{
+					{"id", int64(1)},
+					{"ns", ns},
+					{batchKey, append(bson.A{}, events...)},
+					{"postBatchResumeToken", bson.D{{"_data", pbrt}}},
+				}},
+			}
+		}
+		event := func(token string) bson.D {
+			return bson.D{{"_id", bson.D{{"_data", token}}}, {"operationType", "insert"}}
+		}
+		mt.AddMockResponses(
+			cursorResponse("firstBatch", "2", event("1"), event("2")),
+			cursorResponse("nextBatch", "3"),
+		)
+
+		type batch struct {
+			len  int
+			pbrt string
+		}
+		var batches []batch
+		opts := options.ChangeStream().SetPostBatchHook(func(batchLen int, pbrt bson.Raw) {
+			batches = append(batches, batch{batchLen, pbrt.Lookup("_data").StringValue()})
+		})
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		for i := 0; i < 2; i++ {
+			require.True(mt, cs.TryNext(context.Background()), "TryNext error: %v", cs.Err())
+		}
+		assert.False(mt, cs.TryNext(context.Background()), "expected TryNext to return false for an empty batch")
+		require.NoError(mt, cs.Err(), "TryNext error")
+
+		want := []batch{{2, "2"}, {0, "3"}}
+		assert.Equal(mt, want, batches, "expected batches %v, got %v", want, batches)
+	})
+	mt.RunOpts("server selection before resume", mtest.NewOptions().MinServerVersion("4.0"), func(mt *mtest.T) {
+		// ChangeStream will perform server selection before attempting to resume, using initial readPreference.
+		hello, err := mt.DB.RunCommand(context.Background(), bson.D{{"hello", 1}}).DecodeBytes()
+		require.NoError(mt, err, "hello error")
+		primary := hello.Lookup("primary").StringValue()
+
+		coll, err := mt.Coll.Clone(options.Collection().SetReadPreference(readpref.Primary()))
+		require.NoError(mt, err, "Clone error")
+		cs, err := coll.Watch(context.Background(), mongo.Pipeline{})
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+		assert.Equal(mt, primary, cs.CurrentServerAddress(),
+			"expected change stream to be opened on primary %q, got %q", primary, cs.CurrentServerAddress())
+
+		mt.SetFailPoint(mtest.FailPoint{
+			ConfigureFailPoint: "failCommand",
+			Mode: mtest.FailPointMode{
+				Times: 1,
+			},
+			Data: mtest.FailPointData{
+				FailCommands:    []string{"getMore"},
+				CloseConnection: true,
+			},
+		})
+		mt.ClearEvents()
+
+		_, err = mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+		require.NoError(mt, err, "InsertOne error")
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+
+		var aggregates int
+		for _, evt := range mt.GetAllStartedEvents() {
+			if evt.CommandName == "aggregate" {
+				aggregates++
+			}
+		}
+		assert.Equal(mt, 1, aggregates, "expected change stream to resume with 1 aggregate, got %d", aggregates)
+		assert.Equal(mt, primary, cs.CurrentServerAddress(),
+			"expected change stream to resume on primary %q, got %q", primary, cs.CurrentServerAddress())
 	})
 	mt.Run("empty batch cursor not closed", func(mt *mtest.T) {
 		// Ensure that a cursor returned from an aggregate command with a cursor id and an initial empty batch is not closed
@@ -260,6 +688,73 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 		assert.Nil(mt, cs.Err(), "change stream error: %v", cs.Err())
 	})
 
+	mt.RunOpts("hint", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+
+		testCases := []struct {
+			name string
+			hint interface{}
+			want bson.RawValue
+		}{
+			{"string", "_id_", bson.RawValue{Type: bson.TypeString, Value: bsoncore.AppendString(nil, "_id_")}},
+			{
+				"document",
+				bson.D{{"_id", 1}},
+				bson.RawValue{Type: bson.TypeEmbeddedDocument, Value: bsoncore.BuildDocumentFromElements(nil,
+					bsoncore.AppendInt32Element(nil, "_id", 1))},
+			},
+		}
+		for _, tc := range testCases {
+			mt.Run(tc.name, func(mt *mtest.T) {
+				mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch))
+
+				cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, options.ChangeStream().SetHint(tc.hint))
+				require.NoError(mt, err, "Watch error")
+				defer closeStream(cs)
+
+				evt := mt.GetStartedEvent()
+				assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate' got, %q", evt.CommandName)
+				got, err := evt.Command.LookupErr("hint")
+				require.NoError(mt, err, "expected field 'hint' in aggregate command not found")
+				assert.True(mt, tc.want.Equal(got), "expected hint %v, got %v", tc.want, got)
+			})
+		}
+
+		mt.Run("multi-key map", func(mt *mtest.T) {
+			_, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{},
+				options.ChangeStream().SetHint(bson.M{"a": 1, "b": 1}))
+			want := mongo.ErrMapForOrderedArgument{"hint"}
+			assert.Equal(mt, want, err, "expected error %v, got %v", want, err)
+		})
+	})
+
+	mt.RunOpts("read concern", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+
+		mt.Run("majority is accepted", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch))
+			coll := mt.Coll.Database().Collection(mt.Coll.Name(),
+				options.Collection().SetReadConcern(mtest.MajorityRc))
+
+			cs, err := coll.Watch(context.Background(), mongo.Pipeline{})
+			require.NoError(mt, err, "Watch error")
+			defer closeStream(cs)
+
+			evt := mt.GetStartedEvent()
+			level, err := evt.Command.LookupErr("readConcern", "level")
+			require.NoError(mt, err, "expected readConcern level in aggregate command not found")
+			assert.Equal(mt, "majority", level.StringValue(), "expected level 'majority', got %v", level)
+		})
+		mt.Run("snapshot is rejected", func(mt *mtest.T) {
+			coll := mt.Coll.Database().Collection(mt.Coll.Name(),
+				options.Collection().SetReadConcern(readconcern.Snapshot()))
+
+			_, err := coll.Watch(context.Background(), mongo.Pipeline{})
+			assert.ErrorContains(mt, err, "is not supported by change streams")
+			assert.Nil(mt, mt.GetStartedEvent(), "expected no command to be sent")
+		})
+	})
+
 	startAtOpTimeOpts := mtest.NewOptions().MinServerVersion("4.0").MaxServerVersion("4.0.6")
 	mt.RunOpts("include startAtOperationTime", startAtOpTimeOpts, func(mt *mtest.T) {
 		// $changeStream stage for ChangeStream against a server >=4.0 and <4.0.7 that has not received any results yet
@@ -663,6 +1158,34 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 		assert.True(mt, ok, "expected field 'allowDiskUse' to be boolean, got %v", aduVal.Type.String())
 		assert.True(mt, adu, "expected field 'allowDiskUse' to be true, got false")
 	})
+	mt.Run("AllowDiskUse", func(mt *mtest.T) {
+		opts := options.ChangeStream().SetAllowDiskUse(true)
+
+		mt.ClearEvents()
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		assert.Nil(mt, err, "Watch error: %v", err)
+		defer closeStream(cs)
+
+		// Assert that allowDiskUse is passed to the initial aggregate.
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate' got, %q", evt.CommandName)
+
+		aduVal, err := evt.Command.LookupErr("allowDiskUse")
+		assert.Nil(mt, err, "expected field 'allowDiskUse' in started command not found")
+		adu, ok := aduVal.BooleanOK()
+		assert.True(mt, ok, "expected field 'allowDiskUse' to be boolean, got %v", aduVal.Type.String())
+		assert.True(mt, adu, "expected field 'allowDiskUse' to be true, got false")
+	})
+	mt.Run("AllowDiskUse and Custom allowDiskUse", func(mt *mtest.T) {
+		opts := options.ChangeStream().SetAllowDiskUse(true).SetCustom(bson.M{"allowDiskUse": true})
+
+		mt.ClearEvents()
+		_, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		assert.NotNil(mt, err, "expected Watch error, got nil")
+
+		evt := mt.GetStartedEvent()
+		assert.Nil(mt, evt, "expected no started events, got %v", evt)
+	})
 	mt.RunOpts("CustomPipeline", mtest.NewOptions().MinServerVersion("4.0"), func(mt *mtest.T) {
 		// Custom pipeline options should be a BSON map of option names to Marshalable option values.
 		// We use "allChangesForCluster" as an example.
@@ -686,6 +1209,115 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 		assert.False(mt, acfc, "expected field 'allChangesForCluster' to be false, got %v", acfc)
 	})
 
+	mt.RunOpts("Pipeline", mtest.NewOptions().MinServerVersion("4.0"), func(mt *mtest.T) {
+		customPipelineOpts := bson.M{"allChangesForCluster": false}
+		opts := options.ChangeStream().SetCustomPipeline(customPipelineOpts)
+		userStage := bson.D{{"$match", bson.D{{"operationType", "insert"}}}}
+
+		mt.ClearEvents()
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{userStage}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		pipeline := cs.Pipeline()
+		require.NotNil(mt, pipeline, "expected a pipeline, got nil")
+
+		// The returned pipeline should be the same as the one sent in the aggregate command.
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate' got, %q", evt.CommandName)
+		sent := evt.Command.Lookup("pipeline").Array()
+		assert.Equal(mt, bson.Raw(sent), pipeline, "expected pipeline %v, got %v", bson.Raw(sent), pipeline)
+
+		acfcVal, err := pipeline.LookupErr("0", "$changeStream", "allChangesForCluster")
+		require.NoError(mt, err, "expected field 'allChangesForCluster' in $changeStream stage not found")
+		assert.Equal(mt, bson.TypeBoolean, acfcVal.Type, "expected field 'allChangesForCluster' to be boolean, got %v",
+			acfcVal.Type)
+
+		opType, err := pipeline.LookupErr("1", "$match", "operationType")
+		require.NoError(mt, err, "expected user $match stage in pipeline not found")
+		assert.Equal(mt, "insert", opType.StringValue(), "expected operationType 'insert', got %v", opType)
+	})
+
+	tsOpts := options.CreateCollection().SetTimeSeriesOptions(options.TimeSeries().SetTimeField("ts"))
+	mt.RunOpts("time series collection", mtest.NewOptions().MinServerVersion("5.0").CollectionCreateOptions(tsOpts),
+		func(mt *mtest.T) {
+			// Time series collections are views over internal bucket collections, and the server does not support
+			// change streams on views.
+			_, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+			var ce mongo.CommandError
+			require.True(mt, errors.As(err, &ce), "expected a CommandError, got %v", err)
+			assert.Equal(mt, int32(166), ce.Code, "expected error code 166 (CommandNotSupportedOnView), got %v", ce.Code)
+		})
+
+	mt.RunOpts("WatchRaw", mtest.NewOptions().MinServerVersion("4.0"), func(mt *mtest.T) {
+		csStage, err := bson.Marshal(bson.D{{"$changeStream", bson.D{{"fullDocument", "updateLookup"}}}})
+		require.NoError(mt, err, "Marshal error")
+		matchStage, err := bson.Marshal(bson.D{{"$match", bson.D{{"operationType", "insert"}}}})
+		require.NoError(mt, err, "Marshal error")
+		rawPipeline := bson.Raw(bsoncore.BuildArray(nil,
+			bsoncore.Value{Type: bson.TypeEmbeddedDocument, Data: csStage},
+			bsoncore.Value{Type: bson.TypeEmbeddedDocument, Data: matchStage},
+		))
+
+		mt.ClearEvents()
+		cs, err := mt.Coll.WatchRaw(context.Background(), rawPipeline)
+		require.NoError(mt, err, "WatchRaw error")
+		defer closeStream(cs)
+
+		// The pipeline should be sent to the server unchanged.
+		evt := mt.GetStartedEvent()
+		assert.Equal(mt, "aggregate", evt.CommandName, "expected command 'aggregate' got, %q", evt.CommandName)
+		sent := bson.Raw(evt.Command.Lookup("pipeline").Array())
+		assert.Equal(mt, rawPipeline, sent, "expected pipeline %v, got %v", rawPipeline, sent)
+
+		generateEvents(mt, 2)
+		for i := 0; i < 2; i++ {
+			require.True(mt, cs.Next(context.Background()), "expected next to return true, got false; err=%v", cs.Err())
+
+			opType := cs.Current.Lookup("operationType").StringValue()
+			assert.Equal(mt, "insert", opType, "expected operationType 'insert', got %q", opType)
+			_, err = cs.Current.LookupErr("fullDocument")
+			assert.Nil(mt, err, "expected field 'fullDocument' in change event not found")
+		}
+		assert.NotNil(mt, cs.ResumeToken(), "expected a resume token, got nil")
+	})
+
+	var numRequestIDs int
+	requestIDOpts := mtest.NewOptions().ClientType(mtest.Mock).ClientOptions(
+		options.Client().SetRequestIDFunc(func() string {
+			numRequestIDs++
+			return "request-" + strconv.Itoa(numRequestIDs)
+		}))
+	mt.RunOpts("request ID", requestIDOpts, func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		changeEvent := bson.D{{"_id", bson.D{{"_data", "1"}}}, {"x", 1}}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(1, ns, mtest.FirstBatch),
+			mtest.CreateCursorResponse(0, ns, mtest.NextBatch, changeEvent),
+		)
+		numRequestIDs = 0
+
+		csOpts := options.ChangeStream().SetComment("user comment")
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, csOpts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+		require.True(mt, cs.Next(context.Background()), "expected Next to return true, got false; error: %v", cs.Err())
+
+		started := mt.GetAllStartedEvents()
+		require.Equal(mt, 2, len(started), "expected 2 started events, got %v", len(started))
+		for i, cmdName := range []string{"aggregate", "getMore"} {
+			evt := started[i]
+			assert.Equal(mt, cmdName, evt.CommandName, "expected command %q, got %q", cmdName, evt.CommandName)
+
+			got, ok := evt.Command.Lookup("comment").DocumentOK()
+			require.True(mt, ok, "expected %v comment to be a document, got %v", cmdName, evt.Command.Lookup("comment"))
+			want := bsoncore.NewDocumentBuilder().
+				AppendString("requestId", "request-"+strconv.Itoa(i+1)).
+				AppendString("comment", "user comment").
+				Build()
+			assert.Equal(mt, bson.Raw(want), got, "expected %v comment %v, got %v", cmdName, want, got)
+		}
+	})
 	withBSONOpts := mtest.NewOptions().ClientOptions(
 		options.Client().SetBSONOptions(&options.BSONOptions{
 			UseJSONStructTags: true,
@@ -810,6 +1442,702 @@ func TestChangeStream_ReplicaSet(t *testing.T) {
 
 		wg.Wait()
 	})
+	splitLargeUnsupportedOpts := mtest.NewOptions().MaxServerVersion("5.99.99")
+	mt.RunOpts("split large changes unsupported", splitLargeUnsupportedOpts, func(mt *mtest.T) {
+		// Servers older than 6.0 do not support $changeStreamSplitLargeEvent. Watch should return an error that
+		// names the required server version rather than the server's pipeline error.
+		pipeline := mongo.Pipeline{
+			{{"$changeStreamSplitLargeEvent", bson.D{}}},
+		}
+		_, err := mt.Coll.Watch(context.Background(), pipeline)
+		require.Error(mt, err, "expected Watch error, got nil")
+		assert.True(mt, strings.Contains(err.Error(), "requires a minimum server version of 6.0.9"),
+			"expected error to name the minimum server version, got %v", err)
+	})
+	mt.RunOpts("split event reassembly", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		firstFragment := bson.D{
+			{"_id", bson.D{{"_data", "1"}}},
+			{"splitEvent", bson.D{{"fragment", int32(1)}, {"of", int32(2)}}},
+			{"operationType", "update"},
+			{"fullDocumentBeforeChange", bson.D{{"_id", 1}, {"value", "q"}}},
+		}
+		secondFragment := bson.D{
+			{"_id", bson.D{{"_data", "2"}}},
+			{"splitEvent", bson.D{{"fragment", int32(2)}, {"of", int32(2)}}},
+			{"fullDocument", bson.D{{"_id", 1}, {"value", "z"}}},
+		}
+
+		mt.Run("fragment info", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(1, ns, mtest.FirstBatch, firstFragment, secondFragment))
+
+			cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+			require.NoError(mt, err, "Watch error")
+			defer closeStream(cs)
+
+			for want := int32(1); want <= 2; want++ {
+				require.True(mt, cs.Next(context.Background()), "expected Next to return true, got false")
+				fragment, of, ok := cs.FragmentInfo()
+				assert.True(mt, ok, "expected current event to be a fragment")
+				assert.Equal(mt, want, fragment, "expected fragment %d, got %d", want, fragment)
+				assert.Equal(mt, int32(2), of, "expected 2 fragments, got %d", of)
+			}
+		})
+		mt.Run("reassembled", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(1, ns, mtest.FirstBatch, firstFragment, secondFragment))
+
+			cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+			require.NoError(mt, err, "Watch error")
+			defer closeStream(cs)
+
+			require.True(mt, cs.NextReassembled(context.Background()), "NextReassembled error: %v", cs.Err())
+			_, _, ok := cs.FragmentInfo()
+			assert.False(mt, ok, "expected reassembled event to not be a fragment")
+
+			want, err := bson.Marshal(bson.D{
+				{"_id", bson.D{{"_data", "2"}}},
+				{"operationType", "update"},
+				{"fullDocumentBeforeChange", bson.D{{"_id", 1}, {"value", "q"}}},
+				{"fullDocument", bson.D{{"_id", 1}, {"value", "z"}}},
+			})
+			require.NoError(mt, err, "Marshal error")
+			assert.Equal(mt, bson.Raw(want), cs.Current, "expected reassembled event %v, got %v", bson.Raw(want), cs.Current)
+			assert.Equal(mt, cs.Current.Lookup("_id").Document(), cs.ResumeToken(),
+				"expected resume token to be the _id of the last fragment")
+		})
+		mt.Run("incomplete sequence", func(mt *mtest.T) {
+			nextEvent := bson.D{
+				{"_id", bson.D{{"_data", "3"}}},
+				{"operationType", "insert"},
+			}
+			mt.AddMockResponses(mtest.CreateCursorResponse(1, ns, mtest.FirstBatch, firstFragment, nextEvent))
+
+			cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+			require.NoError(mt, err, "Watch error")
+			defer closeStream(cs)
+
+			assert.False(mt, cs.NextReassembled(context.Background()), "expected NextReassembled to return false")
+			assert.NotNil(mt, cs.Err(), "expected error for incomplete split event, got nil")
+		})
+	})
+	mt.RunOpts("exclude system namespaces", mtest.NewOptions().MinServerVersion("4.0"), func(mt *mtest.T) {
+		opts := options.ChangeStream().SetExcludeSystemNamespaces(true)
+		cs, err := mt.Client.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		// The filter should be added as a $match stage immediately after the $changeStream stage.
+		evt := mt.GetStartedEvent()
+		require.NotNil(mt, evt, "expected aggregate event, got nil")
+		_, err = evt.Command.LookupErr("pipeline", "1", "$match", "ns.db", "$nin")
+		assert.Nil(mt, err, "expected ns.db filter in second pipeline stage, got %v", evt.Command)
+		_, err = evt.Command.LookupErr("pipeline", "1", "$match", "ns.coll", "$not")
+		assert.Nil(mt, err, "expected ns.coll filter in second pipeline stage, got %v", evt.Command)
+
+		adminColl := mt.Client.Database("admin").Collection("changeStreamExcludeSystem")
+		defer func() { _ = adminColl.Drop(context.Background()) }()
+		_, err = adminColl.InsertOne(context.Background(), bson.D{{"x", 1}})
+		require.NoError(mt, err, "InsertOne error")
+		generateEvents(mt, 1)
+
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		db := cs.Current.Lookup("ns", "db").StringValue()
+		coll := cs.Current.Lookup("ns", "coll").StringValue()
+		assert.Equal(mt, mt.Coll.Database().Name(), db, "expected event for database %q, got %q", mt.Coll.Database().Name(), db)
+		assert.Equal(mt, mt.Coll.Name(), coll, "expected event for collection %q, got %q", mt.Coll.Name(), coll)
+	})
+	mt.RunOpts("namespace regex", mtest.NewOptions().MinServerVersion("4.2"), func(mt *mtest.T) {
+		opts := options.ChangeStream().SetNamespaceRegex("", "^events_")
+		cs, err := mt.DB.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		// The filter should be added as a $match stage immediately after the $changeStream stage.
+		evt := mt.GetStartedEvent()
+		require.NotNil(mt, evt, "expected aggregate event, got nil")
+		regex, err := evt.Command.LookupErr("pipeline", "1", "$match", "$expr", "$and", "0", "$regexMatch", "regex")
+		require.NoError(mt, err, "expected $regexMatch in second pipeline stage, got %v", evt.Command)
+		assert.Equal(mt, "^events_", regex.StringValue(), "expected regex %q, got %q", "^events_", regex.StringValue())
+
+		other := mt.CreateCollection(mtest.Collection{Name: "other_" + mt.Coll.Name()}, false)
+		matching := mt.CreateCollection(mtest.Collection{Name: "events_" + mt.Coll.Name()}, false)
+		_, err = other.InsertOne(context.Background(), bson.D{{"x", 1}})
+		require.NoError(mt, err, "InsertOne error")
+		_, err = matching.InsertOne(context.Background(), bson.D{{"x", 2}})
+		require.NoError(mt, err, "InsertOne error")
+
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		coll := cs.Current.Lookup("ns", "coll").StringValue()
+		assert.Equal(mt, matching.Name(), coll, "expected event for collection %q, got %q", matching.Name(), coll)
+	})
+	mt.RunOpts("project fullDocument fields", mtest.NewOptions().MinServerVersion("4.0"), func(mt *mtest.T) {
+		opts := options.ChangeStream().SetProjectFullDocumentFields([]string{"name"})
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		docs := []interface{}{
+			bson.D{{"_id", 1}, {"name", "a"}, {"payload", "large"}},
+			bson.D{{"_id", 2}, {"name", "b"}, {"payload", "large"}},
+		}
+		_, err = mt.Coll.InsertMany(context.Background(), docs)
+		require.NoError(mt, err, "InsertMany error")
+
+		assertProjected := func(cs *mongo.ChangeStream, id int32, name string) {
+			mt.Helper()
+
+			require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+			_, err := cs.Current.LookupErr("_id", "_data")
+			assert.NoError(mt, err, "expected resume token in event %v", cs.Current)
+
+			want := bson.D{{"_id", id}, {"name", name}}
+			var got bson.D
+			err = cs.Current.Lookup("fullDocument").Unmarshal(&got)
+			require.NoError(mt, err, "Unmarshal error")
+			assert.Equal(mt, want, got, "expected fullDocument %v, got %v", want, got)
+		}
+		assertProjected(cs, 1, "a")
+
+		// The event _id is not changed by the projection, so a new stream can resume after the first event.
+		resumeOpts := options.ChangeStream().
+			SetProjectFullDocumentFields([]string{"name"}).
+			SetResumeAfter(cs.ResumeToken())
+		resumed, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, resumeOpts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(resumed)
+		assertProjected(resumed, 2, "b")
+	})
+	mt.RunOpts("single collection", mtest.NewOptions().MinServerVersion("4.0"), func(mt *mtest.T) {
+		target := mt.CreateCollection(mtest.Collection{Name: "target_" + mt.Coll.Name()}, false)
+		other := mt.CreateCollection(mtest.Collection{Name: "other_" + mt.Coll.Name()}, false)
+
+		opts := options.ChangeStream().SetSingleCollection(target.Name())
+		cs, err := mt.DB.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		// The filter should be added as a $match stage immediately after the $changeStream stage.
+		evt := mt.GetStartedEvent()
+		require.NotNil(mt, evt, "expected aggregate event, got nil")
+		coll, err := evt.Command.LookupErr("pipeline", "1", "$match", "$or", "0", "ns.coll")
+		require.NoError(mt, err, "expected $match on ns.coll in second pipeline stage, got %v", evt.Command)
+		assert.Equal(mt, target.Name(), coll.StringValue(), "expected collection %q, got %q", target.Name(),
+			coll.StringValue())
+
+		_, err = other.InsertOne(context.Background(), bson.D{{"x", 1}})
+		require.NoError(mt, err, "InsertOne error")
+		_, err = target.InsertOne(context.Background(), bson.D{{"x", 2}})
+		require.NoError(mt, err, "InsertOne error")
+
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		got := cs.Current.Lookup("ns", "coll").StringValue()
+		assert.Equal(mt, target.Name(), got, "expected event for collection %q, got %q", target.Name(), got)
+		x := cs.Current.Lookup("fullDocument", "x").Int32()
+		assert.Equal(mt, int32(2), x, "expected fullDocument.x to be 2, got %v", x)
+	})
+	matchOpts := mtest.NewOptions().MinServerVersion("4.0").Topologies(mtest.ReplicaSet)
+	mt.RunOpts("match", matchOpts, func(mt *mtest.T) {
+		opts := options.ChangeStream().SetMatch(bson.D{{"operationType", "update"}})
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		insertAndUpdate := func(id int) {
+			_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"_id", id}})
+			require.NoError(mt, err, "InsertOne error")
+			_, err = mt.Coll.UpdateOne(context.Background(), bson.D{{"_id", id}}, bson.D{{"$set", bson.D{{"x", id}}}})
+			require.NoError(mt, err, "UpdateOne error")
+		}
+		nextUpdate := func(id int) {
+			require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+			opType := cs.Current.Lookup("operationType").StringValue()
+			assert.Equal(mt, "update", opType, "expected update event, got %q", opType)
+			got := cs.Current.Lookup("documentKey", "_id").Int32()
+			assert.Equal(mt, int32(id), got, "expected event for document %v, got %v", id, got)
+		}
+
+		insertAndUpdate(1)
+		nextUpdate(1)
+
+		// Force a resume. The resumed aggregate must keep the $match stage so the insert is still filtered out.
+		mt.SetFailPoint(mtest.FailPoint{
+			ConfigureFailPoint: "failCommand",
+			Mode: mtest.FailPointMode{
+				Times: 1,
+			},
+			Data: mtest.FailPointData{
+				FailCommands:    []string{"getMore"},
+				CloseConnection: true,
+			},
+		})
+		mt.ClearEvents()
+
+		insertAndUpdate(2)
+		nextUpdate(2)
+
+		var aggregates int
+		for _, evt := range mt.GetAllStartedEvents() {
+			if evt.CommandName != "aggregate" {
+				continue
+			}
+			aggregates++
+			opType, err := evt.Command.LookupErr("pipeline", "1", "$match", "operationType")
+			require.NoError(mt, err, "expected $match in second pipeline stage, got %v", evt.Command)
+			assert.Equal(mt, "update", opType.StringValue(), "expected $match on update events, got %v", opType)
+		}
+		assert.Equal(mt, 1, aggregates, "expected change stream to resume with 1 aggregate, got %d", aggregates)
+	})
+	ensureImagesOpts := mtest.NewOptions().MinServerVersion("6.0").Topologies(mtest.ReplicaSet)
+	mt.RunOpts("ensure pre and post images", ensureImagesOpts, func(mt *mtest.T) {
+		_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"_id", 1}, {"x", 1}})
+		require.NoError(mt, err, "InsertOne error")
+
+		commandNames := func() []string {
+			var names []string
+			for _, evt := range mt.GetAllStartedEvents() {
+				names = append(names, evt.CommandName)
+			}
+			return names
+		}
+		opts := options.ChangeStream().
+			SetFullDocumentBeforeChange(options.Required).
+			SetEnsurePreAndPostImages(true)
+
+		mt.ClearEvents()
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		want := []string{"listCollections", "collMod", "aggregate"}
+		got := commandNames()
+		assert.Equal(mt, want, got, "expected commands %v, got %v", want, got)
+
+		_, err = mt.Coll.UpdateOne(context.Background(), bson.D{{"_id", 1}}, bson.D{{"$set", bson.D{{"x", 2}}}})
+		require.NoError(mt, err, "UpdateOne error")
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		x, err := cs.Current.LookupErr("fullDocumentBeforeChange", "x")
+		require.NoError(mt, err, "expected pre-image in event %v", cs.Current)
+		assert.Equal(mt, int32(1), x.Int32(), "expected pre-image value 1, got %v", x)
+
+		// Pre- and post-images are now enabled, so opening another change stream does not run collMod again.
+		mt.ClearEvents()
+		other, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(other)
+
+		want = []string{"listCollections", "aggregate"}
+		got = commandNames()
+		assert.Equal(mt, want, got, "expected commands %v, got %v", want, got)
+	})
+	mt.RunOpts("high water mark", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		pbrtResponse := func(batchIdentifier mtest.BatchIdentifier, clusterTime string) bson.D {
+			return bson.D{
+				{"ok", 1},
+				{"cursor", bson.D{
+					{"id", int64(1)},
+					{"ns", ns},
+					{string(batchIdentifier), bson.A{}},
+					{"postBatchResumeToken", bson.D{{"_data", "82" + clusterTime + "2B0229296E04"}}},
+				}},
+			}
+		}
+		mt.AddMockResponses(
+			pbrtResponse(mtest.FirstBatch, "0000000A00000001"),
+			pbrtResponse(mtest.NextBatch, "0000000A00000002"),
+			pbrtResponse(mtest.NextBatch, "0000000B00000001"),
+			mtest.CreateSuccessResponse(), // killCursors
+		)
+
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		want := []primitive.Timestamp{{T: 10, I: 1}, {T: 10, I: 2}, {T: 11, I: 1}}
+		for i, ts := range want {
+			if i > 0 {
+				assert.False(mt, cs.TryNext(context.Background()), "expected TryNext to return false")
+				require.NoError(mt, cs.Err(), "change stream error")
+			}
+
+			hwm := cs.HighWaterMark()
+			require.NotNil(mt, hwm, "expected a high water mark, got nil")
+			assert.Equal(mt, ts, *hwm, "expected high water mark %v, got %v", ts, *hwm)
+		}
+	})
+	mt.RunOpts("decode event", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		nsDoc := bson.D{{"db", mt.Coll.Database().Name()}, {"coll", mt.Coll.Name()}}
+		insertEvent := bson.D{
+			{"_id", bson.D{{"_data", "1"}}},
+			{"operationType", "insert"},
+			{"clusterTime", primitive.Timestamp{T: 10, I: 1}},
+			{"fullDocument", bson.D{{"_id", 1}, {"x", 1}}},
+			{"ns", nsDoc},
+			{"documentKey", bson.D{{"_id", 1}}},
+		}
+		updateEvent := bson.D{
+			{"_id", bson.D{{"_data", "2"}}},
+			{"operationType", "update"},
+			{"clusterTime", primitive.Timestamp{T: 10, I: 2}},
+			{"ns", nsDoc},
+			{"documentKey", bson.D{{"_id", 1}}},
+			{"updateDescription", bson.D{
+				{"updatedFields", bson.D{{"x", 2}}},
+				{"removedFields", bson.A{"y"}},
+			}},
+		}
+		deleteEvent := bson.D{
+			{"_id", bson.D{{"_data", "3"}}},
+			{"operationType", "delete"},
+			{"clusterTime", primitive.Timestamp{T: 10, I: 3}},
+			{"ns", nsDoc},
+			{"documentKey", bson.D{{"_id", 1}}},
+		}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, insertEvent, updateEvent, deleteEvent),
+		)
+
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		wantNs := mongo.ChangeEventNamespace{Db: mt.Coll.Database().Name(), Coll: mt.Coll.Name()}
+		wantKey := bson.Raw(bsoncore.NewDocumentBuilder().AppendInt32("_id", 1).Build())
+		var ev mongo.ChangeEvent
+
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		err = cs.DecodeEvent(&ev)
+		require.NoError(mt, err, "DecodeEvent error")
+		assert.Equal(mt, "1", ev.ID.Lookup("_data").StringValue(), "expected resume token 1, got %v", ev.ID)
+		assert.Equal(mt, "insert", ev.OperationType, "expected operationType insert, got %q", ev.OperationType)
+		assert.Equal(mt, wantNs, ev.Ns, "expected namespace %v, got %v", wantNs, ev.Ns)
+		assert.Equal(mt, wantKey, ev.DocumentKey, "expected documentKey %v, got %v", wantKey, ev.DocumentKey)
+		assert.Equal(mt, primitive.Timestamp{T: 10, I: 1}, ev.ClusterTime, "unexpected clusterTime %v", ev.ClusterTime)
+		assert.Equal(mt, int32(1), ev.FullDocument.Lookup("x").Int32(), "unexpected fullDocument %v", ev.FullDocument)
+		assert.Nil(mt, ev.UpdateDescription, "expected no updateDescription, got %v", ev.UpdateDescription)
+
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		err = cs.DecodeEvent(&ev)
+		require.NoError(mt, err, "DecodeEvent error")
+		assert.Equal(mt, "update", ev.OperationType, "expected operationType update, got %q", ev.OperationType)
+		assert.Nil(mt, ev.FullDocument, "expected fullDocument from previous event to be reset, got %v", ev.FullDocument)
+		require.NotNil(mt, ev.UpdateDescription, "expected updateDescription, got nil")
+		updated := ev.UpdateDescription.UpdatedFields.Lookup("x").Int32()
+		assert.Equal(mt, int32(2), updated, "expected updated field x to be 2, got %v", updated)
+		assert.Equal(mt, []string{"y"}, ev.UpdateDescription.RemovedFields,
+			"expected removedFields [y], got %v", ev.UpdateDescription.RemovedFields)
+		// Servers older than 5.0 do not report truncatedArrays.
+		assert.Nil(mt, ev.UpdateDescription.TruncatedArrays,
+			"expected no truncatedArrays, got %v", ev.UpdateDescription.TruncatedArrays)
+
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		err = cs.DecodeEvent(&ev)
+		require.NoError(mt, err, "DecodeEvent error")
+		assert.Equal(mt, "delete", ev.OperationType, "expected operationType delete, got %q", ev.OperationType)
+		assert.Equal(mt, wantKey, ev.DocumentKey, "expected documentKey %v, got %v", wantKey, ev.DocumentKey)
+		assert.Nil(mt, ev.UpdateDescription, "expected updateDescription to be reset, got %v", ev.UpdateDescription)
+
+		err = cs.DecodeEvent(nil)
+		assert.Equal(mt, mongo.ErrNilDocument, err, "expected error %v, got %v", mongo.ErrNilDocument, err)
+	})
+	mt.RunOpts("decode event truncated arrays", mtest.NewOptions().MinServerVersion("5.0"), func(mt *mtest.T) {
+		_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"_id", 1}, {"arr", bson.A{1, 2, 3, 4, 5}}})
+		require.NoError(mt, err, "InsertOne error")
+
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		// Only pipeline-based updates report array truncations.
+		update := mongo.Pipeline{{{"$set", bson.D{{"arr", bson.D{{"$slice", bson.A{"$arr", 2}}}}}}}}
+		_, err = mt.Coll.UpdateOne(context.Background(), bson.D{{"_id", 1}}, update)
+		require.NoError(mt, err, "UpdateOne error")
+
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		var ev mongo.ChangeEvent
+		err = cs.DecodeEvent(&ev)
+		require.NoError(mt, err, "DecodeEvent error")
+		assert.Equal(mt, "update", ev.OperationType, "expected operationType update, got %q", ev.OperationType)
+		require.NotNil(mt, ev.UpdateDescription, "expected updateDescription, got nil")
+
+		want := []mongo.ChangeEventTruncatedArray{{Field: "arr", NewSize: 2}}
+		got := ev.UpdateDescription.TruncatedArrays
+		assert.Equal(mt, want, got, "expected truncatedArrays %v, got %v", want, got)
+	})
+	mt.RunOpts("next batch", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		var events []bson.D
+		for i := 1; i <= 5; i++ {
+			events = append(events, bson.D{{"_id", bson.D{{"_data", strconv.Itoa(i)}}}, {"x", i}})
+		}
+
+		mt.Run("single getMore", func(mt *mtest.T) {
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(1, ns, mtest.FirstBatch),
+				mtest.CreateCursorResponse(0, ns, mtest.NextBatch, events...),
+			)
+
+			cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+			require.NoError(mt, err, "Watch error")
+			defer closeStream(cs)
+			mt.ClearEvents()
+
+			batch, ok := cs.NextBatch(context.Background())
+			require.True(mt, ok, "NextBatch error: %v", cs.Err())
+			require.Len(mt, batch, len(events), "expected %d events, got %d", len(events), len(batch))
+			for i, doc := range batch {
+				assert.Equal(mt, int32(i+1), doc.Lookup("x").Int32(), "unexpected event at index %d: %v", i, doc)
+			}
+
+			getMores := 0
+			for _, evt := range mt.GetAllStartedEvents() {
+				if evt.CommandName == "getMore" {
+					getMores++
+				}
+			}
+			assert.Equal(mt, 1, getMores, "expected 1 getMore, got %d", getMores)
+			assert.Equal(mt, batch[4], cs.Current, "expected Current to be the last event, got %v", cs.Current)
+			token := cs.ResumeToken().Lookup("_data").StringValue()
+			assert.Equal(mt, "5", token, "expected resume token of last event, got %q", token)
+		})
+		mt.Run("returns remaining in-memory batch", func(mt *mtest.T) {
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, events...))
+
+			cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+			require.NoError(mt, err, "Watch error")
+			defer closeStream(cs)
+
+			require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+			mt.ClearEvents()
+
+			batch, ok := cs.NextBatch(context.Background())
+			require.True(mt, ok, "NextBatch error: %v", cs.Err())
+			require.Len(mt, batch, len(events)-1, "expected %d events, got %d", len(events)-1, len(batch))
+			first := batch[0].Lookup("x").Int32()
+			assert.Equal(mt, int32(2), first, "expected first event to be the second in the batch, got %v", first)
+			assert.Len(mt, mt.GetAllStartedEvents(), 0, "expected no commands to be sent")
+		})
+	})
+	mt.Run("WatchWithSession", func(mt *mtest.T) {
+		sess, err := mt.Client.StartSession()
+		require.NoError(mt, err, "StartSession error")
+		defer sess.EndSession(context.Background())
+
+		cs, err := mt.Coll.WatchWithSession(context.Background(), sess, mongo.Pipeline{})
+		require.NoError(mt, err, "WatchWithSession error")
+
+		_, err = mt.Coll.InsertOne(context.Background(), bson.D{{"x", 1}})
+		require.NoError(mt, err, "InsertOne error")
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		err = cs.Close(context.Background())
+		require.NoError(mt, err, "Close error")
+
+		// The lsid of the explicit session should be used for every command sent by the change stream, even though
+		// Next and Close are called with a context that does not contain the session.
+		seen := make(map[string]bool)
+		for _, evt := range mt.GetAllStartedEvents() {
+			switch evt.CommandName {
+			case "aggregate", "getMore", "killCursors":
+			default:
+				continue
+			}
+
+			seen[evt.CommandName] = true
+			lsid, err := evt.Command.LookupErr("lsid")
+			require.NoError(mt, err, "expected lsid in %s command %v", evt.CommandName, evt.Command)
+			assert.Equal(mt, sess.ID(), lsid.Document(), "expected %s command to use lsid %v, got %v",
+				evt.CommandName, sess.ID(), lsid.Document())
+		}
+		for _, name := range []string{"aggregate", "getMore", "killCursors"} {
+			assert.True(mt, seen[name], "expected a %s command to be sent", name)
+		}
+	})
+	timeoutOpts := mtest.NewOptions().ClientOptions(options.Client().SetTimeout(500 * time.Millisecond))
+	mt.RunOpts("client timeout applies to each iteration", timeoutOpts, func(mt *mtest.T) {
+		// With no events, getMore commands are sent until the client Timeout expires. The timeout error must not
+		// invalidate the change stream: the next call to Next resumes it.
+		csOpts := options.ChangeStream().SetMaxAwaitTime(100 * time.Millisecond)
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, csOpts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		start := time.Now()
+		assert.False(mt, cs.Next(context.Background()), "expected Next to return false, got true")
+		assert.True(mt, mongo.IsTimeout(cs.Err()), "expected a timeout error, got %v", cs.Err())
+		assert.True(mt, time.Since(start) < 5*time.Second,
+			"expected Next to return after the client Timeout, but it took %v", time.Since(start))
+
+		// A second iteration gets its own Timeout, so it can run the resume aggregate and return the new event.
+		mt.ClearEvents()
+		generateEvents(mt, 1)
+		require.True(mt, cs.Next(context.Background()), "expected Next to return true; error: %v", cs.Err())
+		assert.Nil(mt, cs.Err(), "change stream error: %v", cs.Err())
+
+		var resumed bool
+		for _, evt := range mt.GetAllStartedEvents() {
+			if evt.CommandName == "aggregate" {
+				resumed = true
+			}
+		}
+		assert.True(mt, resumed, "expected the change stream to resume with an aggregate")
+	})
+	mt.RunOpts("checkpoint", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		// The checkpoint function should be called with the resume token of every second event and once more on Close.
+
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		var events []bson.D
+		for i := 1; i <= 5; i++ {
+			events = append(events, bson.D{{"_id", bson.D{{"_data", strconv.Itoa(i)}}}})
+		}
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(1, ns, mtest.FirstBatch, events...),
+			mtest.CreateSuccessResponse(), // killCursors
+		)
+
+		var tokens []string
+		checkpoint := func(token bson.Raw) error {
+			tokens = append(tokens, token.Lookup("_data").StringValue())
+			return nil
+		}
+		opts := options.ChangeStream().SetCheckpoint(2, checkpoint)
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+
+		for i := 0; i < len(events); i++ {
+			require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		}
+		err = cs.Close(context.Background())
+		require.NoError(mt, err, "Close error")
+
+		assert.Equal(mt, []string{"2", "4", "5"}, tokens, "expected checkpoint tokens to match")
+	})
+	mt.RunOpts("checkpoint error", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		// An error returned by the checkpoint function should stop the change stream.
+
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, ns, mtest.FirstBatch,
+			bson.D{{"_id", bson.D{{"_data", "1"}}}},
+			bson.D{{"_id", bson.D{{"_data", "2"}}}},
+		))
+
+		checkpointErr := errors.New("checkpoint error")
+		opts := options.ChangeStream().SetCheckpoint(1, func(bson.Raw) error {
+			return checkpointErr
+		})
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		assert.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+		assert.False(mt, cs.Next(context.Background()), "expected Next to return false after checkpoint error")
+		assert.Equal(mt, checkpointErr, cs.Err(), "expected error %v, got %v", checkpointErr, cs.Err())
+	})
+	mt.RunOpts("last raw error", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		// The raw reply of a failed getMore, including fields that are not part of the typed error, should be
+		// available from LastRawError.
+
+		ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+		getMoreErr := mtest.CreateCommandErrorResponse(mtest.CommandError{
+			Code:    2,
+			Message: "bad value",
+			Name:    "BadValue",
+		})
+		getMoreErr = append(getMoreErr, bson.E{"debugInfo", "custom deployment detail"})
+		mt.AddMockResponses(
+			mtest.CreateCursorResponse(1, ns, mtest.FirstBatch),
+			getMoreErr,
+			mtest.CreateSuccessResponse(), // killCursors
+		)
+
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+		assert.Nil(mt, cs.LastRawError(), "expected no raw error, got %v", cs.LastRawError())
+
+		assert.False(mt, cs.Next(context.Background()), "expected Next to return false")
+		assert.NotNil(mt, cs.Err(), "expected error, got nil")
+
+		raw := cs.LastRawError()
+		require.NotNil(mt, raw, "expected raw error, got nil")
+		code := raw.Lookup("code").Int32()
+		assert.Equal(mt, int32(2), code, "expected code 2, got %v", code)
+		msg := raw.Lookup("errmsg").StringValue()
+		assert.Equal(mt, "bad value", msg, "expected errmsg %q, got %q", "bad value", msg)
+		info := raw.Lookup("debugInfo").StringValue()
+		assert.Equal(mt, "custom deployment detail", info, "expected debugInfo to be preserved, got %v", raw)
+	})
+	mt.RunOpts("coalesce window", mtest.NewOptions().MinServerVersion("4.0"), func(mt *mtest.T) {
+		// Three quick updates to the same document should be returned as a single event for the last update.
+
+		_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"_id", 1}, {"x", 0}})
+		require.NoError(mt, err, "InsertOne error")
+
+		opts := options.ChangeStream().
+			SetCoalesceWindow(2 * time.Second).
+			SetMaxAwaitTime(100 * time.Millisecond)
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{}, opts)
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		for i := 1; i <= 3; i++ {
+			_, err = mt.Coll.UpdateOne(context.Background(), bson.D{{"_id", 1}}, bson.D{{"$set", bson.D{{"x", i}}}})
+			require.NoError(mt, err, "UpdateOne error")
+		}
+
+		require.True(mt, cs.NextCoalesced(context.Background()), "NextCoalesced error: %v", cs.Err())
+		x := cs.Current.Lookup("updateDescription", "updatedFields", "x").Int32()
+		assert.Equal(mt, int32(3), x, "expected coalesced event for the last update, got %v", cs.Current)
+
+		// The other updates should have been collapsed, so the next event is for a new document.
+		_, err = mt.Coll.InsertOne(context.Background(), bson.D{{"_id", 2}})
+		require.NoError(mt, err, "InsertOne error")
+		require.True(mt, cs.NextCoalesced(context.Background()), "NextCoalesced error: %v", cs.Err())
+		opType := cs.Current.Lookup("operationType").StringValue()
+		assert.Equal(mt, "insert", opType, "expected insert event, got %v", cs.Current)
+	})
+}
+
+func TestChangeStream_Sharded(t *testing.T) {
+	mtOpts := mtest.NewOptions().MinServerVersion(minChangeStreamVersion).CreateClient(false).Topologies(mtest.Sharded)
+	mt := mtest.New(t, mtOpts)
+	defer mt.Close()
+
+	mt.Run("documentKey contains shard key", func(mt *mtest.T) {
+		admin := mt.Client.Database("admin")
+		err := admin.RunCommand(context.Background(), bson.D{{"enableSharding", mt.DB.Name()}}).Err()
+		require.NoError(mt, err, "enableSharding error")
+		err = admin.RunCommand(context.Background(), bson.D{
+			{"shardCollection", mt.DB.Name() + "." + mt.Coll.Name()},
+			{"key", bson.D{{"region", 1}, {"_id", 1}}},
+		}).Err()
+		require.NoError(mt, err, "shardCollection error")
+
+		cs, err := mt.Coll.Watch(context.Background(), mongo.Pipeline{})
+		require.NoError(mt, err, "Watch error")
+		defer closeStream(cs)
+
+		_, err = mt.Coll.InsertOne(context.Background(), bson.D{{"_id", 1}, {"region", "us"}, {"x", 1}})
+		require.NoError(mt, err, "InsertOne error")
+		require.True(mt, cs.Next(context.Background()), "Next error: %v", cs.Err())
+
+		documentKey := cs.DocumentKey()
+		require.NotNil(mt, documentKey, "expected documentKey, got nil")
+		region, ok := documentKey.Lookup("region").StringValueOK()
+		assert.True(mt, ok, "expected documentKey %v to contain the shard key field region", documentKey)
+		assert.Equal(mt, "us", region, "expected region %q, got %q", "us", region)
+		_, err = documentKey.LookupErr("x")
+		assert.Error(mt, err, "expected documentKey %v to only contain the shard key fields", documentKey)
+
+		var key struct {
+			ID     int32  `bson:"_id"`
+			Region string `bson:"region"`
+		}
+		err = cs.DecodeDocumentKey(&key)
+		require.NoError(mt, err, "DecodeDocumentKey error")
+		assert.Equal(mt, int32(1), key.ID, "expected _id 1, got %v", key.ID)
+		assert.Equal(mt, "us", key.Region, "expected region %q, got %q", "us", key.Region)
+	})
 }
 
 func closeStream(cs *mongo.ChangeStream) {
@@ -7,11 +7,13 @@
 package integration
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net"
 	"os"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -28,6 +30,7 @@ import (
 	"go.mongodb.org/mongo-driver/internal/testutil/helpers"
 	"go.mongodb.org/mongo-driver/internal/testutil/monitor"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/description"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
@@ -519,6 +522,40 @@ func TestClient(t *testing.T) {
 		assert.Nil(t, err, "unexpected error calling Ping: %v", err)
 	})
 
+	zstdOpts := mtest.NewOptions().
+		ClientOptions(options.Client().SetCompressors([]string{"zstd"})).
+		Topologies(mtest.Single, mtest.ReplicaSet, mtest.Sharded)
+	mt.RunOpts("negotiated compressor", zstdOpts, func(mt *mtest.T) {
+		err := mt.Client.Ping(context.Background(), readpref.Primary())
+		assert.Nil(mt, err, "Ping error: %v", err)
+
+		var checked int
+		for _, desc := range getTopologyFromClient(mt.Client).Description().Servers {
+			if desc.Kind == description.Unknown {
+				continue
+			}
+			want := ""
+			for _, c := range desc.Compression {
+				if c == "zstd" {
+					want = "zstd"
+				}
+			}
+
+			got, ok := mt.Client.NegotiatedCompressor(desc.Addr.String())
+			assert.True(mt, ok, "expected a negotiated compressor to be reported for %v", desc.Addr)
+			assert.Equal(mt, want, got, "expected compressor %q for %v, got %q", want, desc.Addr, got)
+			if got == "zstd" {
+				checked++
+			}
+		}
+		if checked == 0 {
+			mt.Skip("skipping because the server does not support zstd compression")
+		}
+
+		_, ok := mt.Client.NegotiatedCompressor("unknown.invalid:27017")
+		assert.False(mt, ok, "expected no compressor to be reported for an unknown server")
+	})
+
 	mt.Run("minimum RTT is monitored", func(mt *mtest.T) {
 		mt.Parallel()
 
@@ -794,6 +831,75 @@ func TestClient(t *testing.T) {
 				"expected 'OP_MSG' OpCode in wire message, got %q", pair.Sent.OpCode.String())
 		}
 	})
+	mt.Run("Warmup", func(mt *mtest.T) {
+		tpm := monitor.NewTestPoolMonitor()
+		mt.ResetClient(options.Client().SetPoolMonitor(tpm.PoolMonitor).SetMaxPoolSize(3))
+		created := func() int {
+			return len(tpm.Events(func(e *event.PoolEvent) bool { return e.Type == event.ConnectionCreated }))
+		}
+
+		err := mt.Client.Warmup(context.Background(), 2)
+		assert.Nil(mt, err, "Warmup error: %v", err)
+		assert.Equal(mt, 2, created(), "expected 2 connections to be created, got %d", created())
+
+		// Connections that are already in the pool are reused.
+		err = mt.Client.Warmup(context.Background(), 1)
+		assert.Nil(mt, err, "Warmup error: %v", err)
+		assert.Equal(mt, 2, created(), "expected no new connections, got %d", created()-2)
+
+		// The number of connections is limited by maxPoolSize.
+		err = mt.Client.Warmup(context.Background(), 5)
+		assert.Nil(mt, err, "Warmup error: %v", err)
+		assert.Equal(mt, 3, created(), "expected 3 connections to be created, got %d", created())
+	})
+}
+
+func TestClient_LoggerWriter(t *testing.T) {
+	mt := mtest.New(t, noClientOpts)
+	defer mt.Close()
+
+	// commandLogLines runs a ping using a client configured with the given options and returns the log lines written
+	// for the ping command.
+	commandLogLines := func(mt *mtest.T, clientOpts *options.ClientOptions) []string {
+		mt.Helper()
+
+		buf := new(bytes.Buffer)
+		clientOpts.ApplyURI(mtest.ClusterURI()).SetLoggerWriter(buf, options.LogLevelInfo)
+		testutil.AddTestServerAPIVersion(clientOpts)
+
+		client, err := mongo.Connect(context.Background(), clientOpts)
+		require.NoError(mt, err, "Connect error")
+
+		err = client.Ping(context.Background(), mtest.PrimaryRp)
+		require.NoError(mt, err, "Ping error")
+
+		// Disconnect before reading the buffer so that no more log lines are written.
+		err = client.Disconnect(context.Background())
+		require.NoError(mt, err, "Disconnect error")
+
+		var lines []string
+		for _, line := range strings.Split(buf.String(), "\n") {
+			if strings.Contains(line, "commandName=ping") {
+				lines = append(lines, line)
+			}
+		}
+		return lines
+	}
+
+	mt.Run("debug messages are filtered at info level", func(mt *mtest.T) {
+		lines := commandLogLines(mt, options.Client())
+		assert.Len(mt, lines, 0, "expected no command log lines, got %v", lines)
+	})
+	mt.Run("component level overrides writer level", func(mt *mtest.T) {
+		loggerOpts := options.Logger().SetComponentLevel(options.LogComponentCommand, options.LogLevelDebug)
+		lines := commandLogLines(mt, options.Client().SetLoggerOptions(loggerOpts))
+
+		require.Len(mt, lines, 2, "expected started and succeeded log lines, got %v", lines)
+		started := regexp.MustCompile(`^\S+ DEBUG Command started `)
+		assert.True(mt, started.MatchString(lines[0]), "unexpected started log line: %q", lines[0])
+		succeeded := regexp.MustCompile(`^\S+ DEBUG Command succeeded `)
+		assert.True(mt, succeeded.MatchString(lines[1]), "unexpected succeeded log line: %q", lines[1])
+	})
 }
 
 func TestClient_BSONOptions(t *testing.T) {
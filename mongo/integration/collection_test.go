@@ -8,6 +8,7 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"testing"
 	"time"
@@ -1094,6 +1095,112 @@ func TestCollection(t *testing.T) {
 				return mt.Coll.Find(context.Background(), bson.D{}, options.Find().SetBatchSize(3))
 			})
 		})
+		mt.RunOpts("returnKey and showRecordId", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			ns := mt.Coll.Database().Name() + "." + mt.Coll.Name()
+			mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch,
+				bson.D{{"x", 1}, {"$recordId", int64(42)}},
+			))
+
+			opts := options.Find().SetReturnKey(true).SetShowRecordID(true)
+			cursor, err := mt.Coll.Find(context.Background(), bson.D{{"x", 1}}, opts)
+			assert.Nil(mt, err, "Find error: %v", err)
+
+			evt := mt.GetStartedEvent()
+			assert.NotNil(mt, evt, "expected find event, got nil")
+			for _, field := range []string{"returnKey", "showRecordId"} {
+				val, err := evt.Command.LookupErr(field)
+				assert.Nil(mt, err, "expected field %q in find command %v", field, evt.Command)
+				assert.True(mt, val.Boolean(), "expected %q to be true, got %v", field, val)
+			}
+
+			var results []struct {
+				X        int32 `bson:"x"`
+				RecordID int64 `bson:"$recordId"`
+			}
+			err = cursor.All(context.Background(), &results)
+			assert.Nil(mt, err, "All error: %v", err)
+			assert.Len(mt, results, 1, "expected 1 result, got %d", len(results))
+			assert.Equal(mt, int64(42), results[0].RecordID, "expected $recordId 42, got %v", results[0].RecordID)
+		})
+	})
+	mt.RunOpts("find chan", noClientOpts, func(mt *mtest.T) {
+		type result struct {
+			X int32
+		}
+
+		mt.Run("all documents", func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+
+			results := make(chan result)
+			opts := options.Find().SetSort(bson.D{{"x", 1}}).SetBatchSize(2)
+			errs := mt.Coll.FindChan(context.Background(), results, bson.D{}, opts)
+
+			var got []int32
+			for res := range results {
+				got = append(got, res.X)
+			}
+			err := <-errs
+			assert.Nil(mt, err, "FindChan error: %v", err)
+
+			expected := []int32{1, 2, 3, 4, 5}
+			assert.Equal(mt, expected, got, "expected results %v, got %v", expected, got)
+		})
+		mt.Run("pointer element type", func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+
+			results := make(chan *result)
+			errs := mt.Coll.FindChan(context.Background(), results, bson.D{{"x", 3}})
+
+			var got []*result
+			for res := range results {
+				got = append(got, res)
+			}
+			err := <-errs
+			assert.Nil(mt, err, "FindChan error: %v", err)
+
+			expected := []*result{{X: 3}}
+			assert.Equal(mt, expected, got, "expected results %v, got %v", expected, got)
+		})
+		mt.Run("context cancellation closes cursor", func(mt *mtest.T) {
+			initCollection(mt, mt.Coll)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			results := make(chan result)
+			opts := options.Find().SetSort(bson.D{{"x", 1}}).SetBatchSize(2)
+			errs := mt.Coll.FindChan(ctx, results, bson.D{}, opts)
+
+			first, ok := <-results
+			assert.True(mt, ok, "expected a result, got closed channel")
+			assert.Equal(mt, int32(1), first.X, "expected x 1, got %v", first.X)
+
+			mt.ClearEvents()
+			cancel()
+			for range results {
+			}
+			err := <-errs
+			assert.True(mt, errors.Is(err, context.Canceled), "expected error %v, got %v", context.Canceled, err)
+
+			evt := mt.GetStartedEvent()
+			for evt != nil && evt.CommandName != "killCursors" {
+				evt = mt.GetStartedEvent()
+			}
+			assert.NotNil(mt, evt, "expected killCursors to be sent after cancellation")
+		})
+		mt.Run("decode error", func(mt *mtest.T) {
+			_, err := mt.Coll.InsertOne(context.Background(), bson.D{{"x", "not an int"}})
+			assert.Nil(mt, err, "InsertOne error: %v", err)
+
+			results := make(chan result)
+			errs := mt.Coll.FindChan(context.Background(), results, bson.D{})
+
+			for range results {
+				mt.Fatal("expected no results to be sent")
+			}
+			err = <-errs
+			assert.NotNil(mt, err, "expected a decode error, got nil")
+		})
 	})
 	mt.RunOpts("find one", noClientOpts, func(mt *mtest.T) {
 		mt.Run("limit", func(mt *mtest.T) {
@@ -1858,6 +1965,33 @@ func TestCollection(t *testing.T) {
 				})
 			}
 		})
+		mt.RunOpts("operation time", mtest.NewOptions().Topologies(mtest.ReplicaSet).MinServerVersion("3.6"), func(mt *mtest.T) {
+			models := []mongo.WriteModel{
+				mongo.NewInsertOneModel().SetDocument(bson.D{{"_id", 1}}),
+				mongo.NewUpdateOneModel().SetFilter(bson.D{{"_id", 2}}).SetUpdate(bson.D{{"$set", bson.D{{"x", 1}}}}).SetUpsert(true),
+				mongo.NewDeleteOneModel().SetFilter(bson.D{{"_id", 1}}),
+			}
+			res, err := mt.Coll.BulkWrite(context.Background(), models)
+			assert.Nil(mt, err, "BulkWrite error: %v", err)
+			assert.NotNil(mt, res.OperationTime, "expected OperationTime to be set, got nil")
+		})
+		mt.RunOpts("operation time from mock", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+			insertTime := primitive.Timestamp{T: 1, I: 1}
+			deleteTime := primitive.Timestamp{T: 2, I: 1}
+			mt.AddMockResponses(
+				mtest.CreateSuccessResponse(bson.E{"n", 1}, bson.E{"operationTime", insertTime}),
+				mtest.CreateSuccessResponse(bson.E{"n", 1}, bson.E{"operationTime", deleteTime}),
+			)
+
+			models := []mongo.WriteModel{
+				mongo.NewInsertOneModel().SetDocument(bson.D{{"x", 1}}),
+				mongo.NewDeleteOneModel().SetFilter(bson.D{{"x", 1}}),
+			}
+			res, err := mt.Coll.BulkWrite(context.Background(), models)
+			assert.Nil(mt, err, "BulkWrite error: %v", err)
+			assert.NotNil(mt, res.OperationTime, "expected OperationTime to be set, got nil")
+			assert.Equal(mt, deleteTime, *res.OperationTime, "expected OperationTime %v, got %v", deleteTime, *res.OperationTime)
+		})
 	})
 }
 
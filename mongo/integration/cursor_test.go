@@ -15,6 +15,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 	"go.mongodb.org/mongo-driver/mongo/options"
@@ -178,6 +179,122 @@ func TestCursor(t *testing.T) {
 			assertCursorBatchLength(mt, cursor, len(getMoreBatch)-1)
 		})
 	})
+	mt.RunOpts("exhausted", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.DB.Name() + "." + mt.Coll.Name()
+
+		mt.Run("cursor ID is 0", func(mt *mtest.T) {
+			cursorID := int64(50)
+			find := mtest.CreateCursorResponse(cursorID, ns, mtest.FirstBatch, bson.D{{"x", 1}})
+			getMore := mtest.CreateCursorResponse(0, ns, mtest.NextBatch, bson.D{{"x", 2}})
+			mt.AddMockResponses(find, getMore)
+
+			cursor, err := mt.Coll.Find(context.Background(), bson.D{})
+			assert.Nil(mt, err, "Find error: %v", err)
+			defer cursor.Close(context.Background())
+
+			assert.False(mt, cursor.Exhausted(), "expected cursor to not be exhausted after find")
+			assert.True(mt, cursor.TryNext(context.Background()), "expected TryNext to return true, got false")
+			assert.False(mt, cursor.Exhausted(), "expected cursor to not be exhausted before getMore")
+
+			// The getMore returns a cursor ID of 0, so the cursor is exhausted even though a document is still
+			// available in the batch.
+			assert.True(mt, cursor.TryNext(context.Background()), "expected TryNext to return true, got false")
+			assert.True(mt, cursor.Exhausted(), "expected cursor to be exhausted after final getMore")
+			assert.False(mt, cursor.TryNext(context.Background()), "expected TryNext to return false, got true")
+			assert.Nil(mt, cursor.Err(), "cursor error: %v", cursor.Err())
+		})
+		mt.Run("tailable cursor", func(mt *mtest.T) {
+			cursorID := int64(50)
+			find := mtest.CreateCursorResponse(cursorID, ns, mtest.FirstBatch)
+			getMore := mtest.CreateCursorResponse(cursorID, ns, mtest.NextBatch)
+			killCursors := mtest.CreateSuccessResponse()
+			mt.AddMockResponses(find, getMore, killCursors)
+
+			findOpts := options.Find().SetCursorType(options.TailableAwait)
+			cursor, err := mt.Coll.Find(context.Background(), bson.D{}, findOpts)
+			assert.Nil(mt, err, "Find error: %v", err)
+			defer cursor.Close(context.Background())
+
+			// The first call to TryNext returns false without a getMore because the first batch is empty. The second
+			// call sends a getMore, which returns an empty batch with a non-zero cursor ID.
+			for i := 0; i < 2; i++ {
+				assert.False(mt, cursor.TryNext(context.Background()), "TryNext returned true on iteration %v", i)
+				assert.Nil(mt, cursor.Err(), "cursor error: %v", cursor.Err())
+				assert.False(mt, cursor.Exhausted(), "expected tailable cursor to not be exhausted on iteration %v", i)
+			}
+		})
+	})
+	mt.RunOpts("rewind", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.DB.Name() + "." + mt.Coll.Name()
+		docs := []bson.D{{{"x", int32(1)}}, {{"x", int32(2)}}}
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, ns, mtest.FirstBatch, docs...))
+
+		cursor, err := mt.Coll.Find(context.Background(), bson.D{})
+		assert.Nil(mt, err, "Find error: %v", err)
+		defer cursor.Close(context.Background())
+
+		decodeAll := func() []bson.Raw {
+			var got []bson.Raw
+			for cursor.Next(context.Background()) {
+				got = append(got, cursor.Current)
+			}
+			assert.Nil(mt, cursor.Err(), "cursor error: %v", cursor.Err())
+			return got
+		}
+		first := decodeAll()
+		assert.Equal(mt, 2, len(first), "expected 2 documents, got %v", len(first))
+
+		err = cursor.Rewind()
+		assert.Nil(mt, err, "Rewind error: %v", err)
+		second := decodeAll()
+		assert.Equal(mt, first, second, "expected documents %v, got %v", first, second)
+	})
+	mt.RunOpts("close info", mtest.NewOptions().ClientType(mtest.Mock), func(mt *mtest.T) {
+		ns := mt.DB.Name() + "." + mt.Coll.Name()
+		doc := bson.D{{"x", int32(1)}}
+
+		testCases := []struct {
+			name     string
+			cursorID int64
+			sent     bool
+		}{
+			{"exhausted cursor", 0, false},
+			{"live cursor", 1, true},
+		}
+		for _, tc := range testCases {
+			mt.Run(tc.name, func(mt *mtest.T) {
+				mt.AddMockResponses(
+					mtest.CreateCursorResponse(tc.cursorID, ns, mtest.FirstBatch, doc),
+					mtest.CreateSuccessResponse(), // killCursors
+				)
+				cursor, err := mt.Coll.Find(context.Background(), bson.D{})
+				assert.Nil(mt, err, "Find error: %v", err)
+
+				mt.ClearEvents()
+				sent, err := cursor.CloseInfo(context.Background())
+				assert.Nil(mt, err, "CloseInfo error: %v", err)
+				assert.Equal(mt, tc.sent, sent, "expected killCursorsSent %v, got %v", tc.sent, sent)
+
+				var killCursors int
+				for _, evt := range mt.GetAllStartedEvents() {
+					if evt.CommandName == "killCursors" {
+						killCursors++
+					}
+				}
+				want := 0
+				if tc.sent {
+					want = 1
+				}
+				assert.Equal(mt, want, killCursors, "expected %d killCursors commands, got %d", want, killCursors)
+
+				// The cursor is closed, so closing it again doesn't send another killCursors.
+				sent, err = cursor.CloseInfo(context.Background())
+				assert.Nil(mt, err, "CloseInfo error: %v", err)
+				assert.False(mt, sent, "expected no killCursors for a closed cursor")
+				mt.ClearMockResponses()
+			})
+		}
+	})
 	mt.RunOpts("all", noClientOpts, func(mt *mtest.T) {
 		failpointOpts := mtest.NewOptions().Topologies(mtest.ReplicaSet).MinServerVersion("4.0")
 		mt.RunOpts("getMore error", failpointOpts, func(mt *mtest.T) {
@@ -303,6 +420,30 @@ func TestCursor(t *testing.T) {
 		batchSize = sizeVal.Int32()
 		assert.Equal(mt, int32(4), batchSize, "expected batchSize 4, got %v", batchSize)
 	})
+	mt.RunOpts("command durations", mtest.NewOptions().MinServerVersion("3.2"), func(mt *mtest.T) {
+		initCollection(mt, mt.Coll)
+		mt.ClearEvents()
+
+		cursor, err := mt.Coll.Find(context.Background(), bson.D{}, options.Find().SetBatchSize(2))
+		require.NoError(mt, err, "Find error")
+		var docs []bson.Raw
+		require.NoError(mt, cursor.All(context.Background(), &docs), "All error")
+
+		// Durations are measured with the monotonic clock, so they must never be negative, and commands against a
+		// test deployment should complete well within the upper bound.
+		var numGetMores int
+		for _, evt := range mt.GetAllSucceededEvents() {
+			if evt.CommandName == "getMore" {
+				numGetMores++
+			}
+			assert.True(mt, evt.Duration >= 0, "expected non-negative %q duration, got %v", evt.CommandName, evt.Duration)
+			assert.True(mt, evt.Duration < 10*time.Second, "expected %q duration to be less than 10s, got %v",
+				evt.CommandName, evt.Duration)
+			assert.Equal(mt, evt.Duration.Nanoseconds(), evt.DurationNanos, "expected DurationNanos %v, got %v",
+				evt.Duration.Nanoseconds(), evt.DurationNanos)
+		}
+		assert.True(mt, numGetMores > 0, "expected at least one getMore event")
+	})
 }
 
 type tryNextCursor interface {
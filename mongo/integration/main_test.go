@@ -12,6 +12,7 @@ import (
 	"os"
 	"testing"
 
+	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
 )
 
@@ -25,6 +26,9 @@ func TestMain(m *testing.M) {
 		return
 	}
 
+	// The tests use heartbeat intervals below the minimum to collect RTT samples and detect topology changes quickly.
+	internal.AllowLowHeartbeatIntervals = true
+
 	if err := mtest.Setup(); err != nil {
 		log.Fatal(err)
 	}
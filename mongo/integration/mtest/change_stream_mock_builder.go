@@ -0,0 +1,64 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mtest
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// ChangeStreamMockBuilder builds the sequence of mock responses returned to a change stream, including the responses
+// to the commands it runs when it resumes. Each aggregate response has a new cursor ID, starting at 1. The responses
+// are added to a mock deployment with
+//
+//	mt.AddMockResponses(builder.Responses()...)
+type ChangeStreamMockBuilder struct {
+	ns        string
+	cursorID  int64
+	responses []bson.D
+}
+
+// NewChangeStreamMockBuilder creates a ChangeStreamMockBuilder for a change stream on the namespace ns, which has the
+// form "db.coll".
+func NewChangeStreamMockBuilder(ns string) *ChangeStreamMockBuilder {
+	return &ChangeStreamMockBuilder{ns: ns}
+}
+
+// Aggregate adds a response to the aggregate that opens the change stream. The response has a new cursor ID and docs
+// as its first batch.
+func (b *ChangeStreamMockBuilder) Aggregate(docs ...bson.D) *ChangeStreamMockBuilder {
+	b.cursorID++
+	b.responses = append(b.responses, CreateCursorResponse(b.cursorID, b.ns, FirstBatch, docs...))
+	return b
+}
+
+// GetMoreError adds a command error response to a getMore with the given error code and labels.
+func (b *ChangeStreamMockBuilder) GetMoreError(code int32, labels ...string) *ChangeStreamMockBuilder {
+	b.responses = append(b.responses, CreateCommandErrorResponse(CommandError{
+		Code:    code,
+		Name:    "foo",
+		Message: "bar",
+		Labels:  labels,
+	}))
+	return b
+}
+
+// KillCursorsSuccess adds a successful response to the killCursors command run before the change stream resumes.
+func (b *ChangeStreamMockBuilder) KillCursorsSuccess() *ChangeStreamMockBuilder {
+	b.responses = append(b.responses, CreateSuccessResponse())
+	return b
+}
+
+// ResumedAggregate adds a response to the aggregate run when the change stream resumes. The response has a new cursor
+// ID and docs as its first batch.
+func (b *ChangeStreamMockBuilder) ResumedAggregate(docs ...bson.D) *ChangeStreamMockBuilder {
+	return b.Aggregate(docs...)
+}
+
+// Responses returns the responses added to the builder, in order.
+func (b *ChangeStreamMockBuilder) Responses() []bson.D {
+	return b.responses
+}
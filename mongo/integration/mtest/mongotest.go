@@ -112,6 +112,7 @@ type T struct {
 	ssl               *bool
 	collCreateOpts    *options.CreateCollectionOptions
 	requireAPIVersion *bool
+	serverLogsStart   *int64 // totalLinesWritten when CaptureServerLogs was called
 
 	// options copied to sub-tests
 	clientType  ClientType
@@ -247,6 +248,9 @@ func (t *T) RunOpts(name string, opts *Options, callback func(*T)) {
 			conns := sub.NumberConnectionsCheckedOut()
 
 			if sub.clientType != Mock {
+				if sub.serverLogsStart != nil && sub.Failed() {
+					sub.logServerLogs()
+				}
 				sub.ClearFailPoints()
 				sub.ClearCollections()
 			}
@@ -596,6 +600,64 @@ func (t *T) ClearFailPoints() {
 	t.failPointNames = t.failPointNames[:0]
 }
 
+// CaptureServerLogs records the current position of the server's global log. If the test fails, the server log lines
+// written after this call are attached to the test output when the test finishes. This can be used to correlate driver
+// and server activity when debugging tests that run against a real deployment. It must not be called when running
+// against a mock deployment.
+func (t *T) CaptureServerLogs() {
+	if t.clientType == Mock {
+		t.Fatal("CaptureServerLogs cannot be used with a mock deployment")
+	}
+
+	_, total, err := getGlobalLog(t.Client)
+	if err != nil {
+		t.Fatalf("error getting server log: %v", err)
+	}
+	t.serverLogsStart = &total
+}
+
+// ServerLogs returns the server log lines written since CaptureServerLogs was called. The server only retains a
+// limited number of recent log lines, so lines may be missing if the server has logged heavily in the meantime.
+func (t *T) ServerLogs() []string {
+	if t.serverLogsStart == nil {
+		t.Fatal("ServerLogs called without a previous call to CaptureServerLogs")
+	}
+
+	lines, total, err := getGlobalLog(t.Client)
+	if err != nil {
+		t.Fatalf("error getting server log: %v", err)
+	}
+
+	// getLog only returns the most recent lines, so use the total line count to determine how many of them are new.
+	written := total - *t.serverLogsStart
+	if written <= 0 {
+		return nil
+	}
+	if written > int64(len(lines)) {
+		written = int64(len(lines))
+	}
+	return lines[int64(len(lines))-written:]
+}
+
+func (t *T) logServerLogs() {
+	lines := t.ServerLogs()
+	t.Logf("%d server log line(s) written during test:\n%s", len(lines), strings.Join(lines, "\n"))
+}
+
+// getGlobalLog runs the getLog command for the "global" log and returns the lines retained by the server and the
+// total number of lines the server has written.
+func getGlobalLog(client *mongo.Client) ([]string, int64, error) {
+	var res struct {
+		Log               []string `bson:"log"`
+		TotalLinesWritten int64    `bson:"totalLinesWritten"`
+	}
+	err := client.Database("admin").RunCommand(context.Background(), bson.D{{"getLog", "global"}}).Decode(&res)
+	if err != nil {
+		return nil, 0, err
+	}
+	return res.Log, res.TotalLinesWritten, nil
+}
+
 // CloneDatabase modifies the default database for this test to match the given options.
 func (t *T) CloneDatabase(opts *options.DatabaseOptions) {
 	t.DB = t.Client.Database(t.dbName, opts)
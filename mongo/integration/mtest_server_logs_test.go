@@ -0,0 +1,53 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package integration
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func TestCaptureServerLogs(t *testing.T) {
+	// Structured logging, which includes the command comment in slow query log lines, requires 4.4+. The profile
+	// command is only used on mongod.
+	mtOpts := mtest.NewOptions().MinServerVersion("4.4").Topologies(mtest.Single, mtest.ReplicaSet)
+	mt := mtest.New(t, mtOpts)
+	defer mt.Close()
+
+	mt.Run("captures logs written after the call", func(mt *mtest.T) {
+		// Log every operation as a slow query so the find below shows up in the server log.
+		err := mt.DB.RunCommand(context.Background(), bson.D{{"profile", 0}, {"slowms", -1}}).Err()
+		require.NoError(mt, err, "profile error")
+		defer func() {
+			err := mt.DB.RunCommand(context.Background(), bson.D{{"profile", 0}, {"slowms", 100}}).Err()
+			assert.Nil(mt, err, "profile error: %v", err)
+		}()
+
+		mt.CaptureServerLogs()
+
+		comment := primitive.NewObjectID().Hex()
+		_, err = mt.Coll.Find(context.Background(), bson.D{}, options.Find().SetComment(comment))
+		require.NoError(mt, err, "Find error")
+
+		var found bool
+		for _, line := range mt.ServerLogs() {
+			if strings.Contains(line, comment) {
+				found = true
+				break
+			}
+		}
+		assert.True(mt, found, "expected server logs to contain a line with comment %q", comment)
+	})
+}
@@ -425,6 +425,41 @@ func getPoolEventDocument(evt *event.PoolEvent, eventType monitoringEventType) b
 	return bson.Raw(bsonBuilder.Build())
 }
 
+// getServerHeartbeatEventDocument translates a ServerHeartbeatStartedEvent, ServerHeartbeatSucceededEvent, or
+// ServerHeartbeatFailedEvent into the document stored for it in an event list entity. It returns nil for any other
+// type.
+func getServerHeartbeatEventDocument(evt interface{}) bson.Raw {
+	var bsonBuilder *bsoncore.DocumentBuilder
+	switch evt := evt.(type) {
+	case *event.ServerHeartbeatStartedEvent:
+		bsonBuilder = bsoncore.NewDocumentBuilder().
+			AppendString("name", string(serverHeartbeatStartedEvent)).
+			AppendDouble("observedAt", getSecondsSinceEpoch()).
+			AppendString("connectionId", evt.ConnectionID).
+			AppendBoolean("awaited", evt.Awaited)
+	case *event.ServerHeartbeatSucceededEvent:
+		bsonBuilder = bsoncore.NewDocumentBuilder().
+			AppendString("name", string(serverHeartbeatSucceededEvent)).
+			AppendDouble("observedAt", getSecondsSinceEpoch()).
+			AppendString("connectionId", evt.ConnectionID).
+			AppendBoolean("awaited", evt.Awaited).
+			AppendInt64("durationNanos", evt.Duration.Nanoseconds())
+	case *event.ServerHeartbeatFailedEvent:
+		bsonBuilder = bsoncore.NewDocumentBuilder().
+			AppendString("name", string(serverHeartbeatFailedEvent)).
+			AppendDouble("observedAt", getSecondsSinceEpoch()).
+			AppendString("connectionId", evt.ConnectionID).
+			AppendBoolean("awaited", evt.Awaited).
+			AppendInt64("durationNanos", evt.Duration.Nanoseconds())
+		if evt.Failure != nil {
+			bsonBuilder.AppendString("failure", evt.Failure.Error())
+		}
+	default:
+		return nil
+	}
+	return bson.Raw(bsonBuilder.Build())
+}
+
 func (c *clientEntity) processPoolEvent(evt *event.PoolEvent) {
 	if !c.getRecordEvents() {
 		return
@@ -475,6 +510,13 @@ func (c *clientEntity) processServerHeartbeatFailedEvent(evt *event.ServerHeartb
 	}
 
 	c.addEventsCount(serverHeartbeatFailedEvent)
+
+	if eventListIDs, ok := c.storedEvents[serverHeartbeatFailedEvent]; ok {
+		eventBSON := getServerHeartbeatEventDocument(evt)
+		for _, id := range eventListIDs {
+			c.entityMap.appendEventsEntity(id, eventBSON)
+		}
+	}
 }
 
 func (c *clientEntity) processServerHeartbeatStartedEvent(evt *event.ServerHeartbeatStartedEvent) {
@@ -487,6 +529,13 @@ func (c *clientEntity) processServerHeartbeatStartedEvent(evt *event.ServerHeart
 	}
 
 	c.addEventsCount(serverHeartbeatStartedEvent)
+
+	if eventListIDs, ok := c.storedEvents[serverHeartbeatStartedEvent]; ok {
+		eventBSON := getServerHeartbeatEventDocument(evt)
+		for _, id := range eventListIDs {
+			c.entityMap.appendEventsEntity(id, eventBSON)
+		}
+	}
 }
 
 func (c *clientEntity) processServerHeartbeatSucceededEvent(evt *event.ServerHeartbeatSucceededEvent) {
@@ -499,6 +548,13 @@ func (c *clientEntity) processServerHeartbeatSucceededEvent(evt *event.ServerHea
 	}
 
 	c.addEventsCount(serverHeartbeatSucceededEvent)
+
+	if eventListIDs, ok := c.storedEvents[serverHeartbeatSucceededEvent]; ok {
+		eventBSON := getServerHeartbeatEventDocument(evt)
+		for _, id := range eventListIDs {
+			c.entityMap.appendEventsEntity(id, eventBSON)
+		}
+	}
 }
 
 func (c *clientEntity) processTopologyDescriptionChangedEvent(evt *event.TopologyDescriptionChangedEvent) {
@@ -64,10 +64,25 @@ type cmapEvent struct {
 	} `bson:"poolClearedEvent"`
 }
 
+type sdamEvent struct {
+	ServerHeartbeatStartedEvent *struct {
+		Awaited *bool `bson:"awaited"`
+	} `bson:"serverHeartbeatStartedEvent"`
+
+	ServerHeartbeatSucceededEvent *struct {
+		Awaited *bool `bson:"awaited"`
+	} `bson:"serverHeartbeatSucceededEvent"`
+
+	ServerHeartbeatFailedEvent *struct {
+		Awaited *bool `bson:"awaited"`
+	} `bson:"serverHeartbeatFailedEvent"`
+}
+
 type expectedEvents struct {
 	ClientID          string `bson:"client"`
 	CommandEvents     []commandMonitoringEvent
 	CMAPEvents        []cmapEvent
+	SDAMEvents        []sdamEvent
 	IgnoreExtraEvents *bool
 }
 
@@ -102,6 +117,8 @@ func (e *expectedEvents) UnmarshalBSON(data []byte) error {
 		target = &e.CommandEvents
 	case "cmap":
 		target = &e.CMAPEvents
+	case "sdam":
+		target = &e.SDAMEvents
 	default:
 		return fmt.Errorf("unrecognized 'eventType' value for expectedEvents: %q", temp.EventType)
 	}
@@ -127,6 +144,8 @@ func verifyEvents(ctx context.Context, expectedEvents *expectedEvents) error {
 		return verifyCommandEvents(ctx, client, expectedEvents)
 	case expectedEvents.CMAPEvents != nil:
 		return verifyCMAPEvents(client, expectedEvents)
+	case expectedEvents.SDAMEvents != nil:
+		return verifySDAMEvents(client, expectedEvents)
 	}
 	return nil
 }
@@ -342,6 +361,67 @@ func verifyCMAPEvents(client *clientEntity, expectedEvents *expectedEvents) erro
 	return nil
 }
 
+// verifySDAMEvents verifies the heartbeat events published for a client. The client entity records each heartbeat
+// event type separately, so each expected event is compared to the next unverified event of the same type and the
+// relative order of events of different types is not checked.
+func verifySDAMEvents(client *clientEntity, expectedEvents *expectedEvents) error {
+	started := client.serverHeartbeatStartedEvent
+	succeeded := client.serverHeartbeatSucceeded
+	failed := client.serverHeartbeatFailedEvent
+
+	for idx, evt := range expectedEvents.SDAMEvents {
+		switch {
+		case evt.ServerHeartbeatStartedEvent != nil:
+			if len(started) == 0 {
+				return newEventVerificationError(idx, client, "no %q event published", serverHeartbeatStartedEvent)
+			}
+			actual := started[0]
+			started = started[1:]
+
+			if err := verifyAwaited(evt.ServerHeartbeatStartedEvent.Awaited, actual.Awaited); err != nil {
+				return newEventVerificationError(idx, client, err.Error())
+			}
+		case evt.ServerHeartbeatSucceededEvent != nil:
+			if len(succeeded) == 0 {
+				return newEventVerificationError(idx, client, "no %q event published", serverHeartbeatSucceededEvent)
+			}
+			actual := succeeded[0]
+			succeeded = succeeded[1:]
+
+			if err := verifyAwaited(evt.ServerHeartbeatSucceededEvent.Awaited, actual.Awaited); err != nil {
+				return newEventVerificationError(idx, client, err.Error())
+			}
+		case evt.ServerHeartbeatFailedEvent != nil:
+			if len(failed) == 0 {
+				return newEventVerificationError(idx, client, "no %q event published", serverHeartbeatFailedEvent)
+			}
+			actual := failed[0]
+			failed = failed[1:]
+
+			if err := verifyAwaited(evt.ServerHeartbeatFailedEvent.Awaited, actual.Awaited); err != nil {
+				return newEventVerificationError(idx, client, err.Error())
+			}
+		default:
+			return newEventVerificationError(idx, client, "no expected event set on sdamEvent instance")
+		}
+	}
+
+	// Verify that there are no remaining events if ignoreExtraEvents is unset or false.
+	ignoreExtraEvents := expectedEvents.IgnoreExtraEvents != nil && *expectedEvents.IgnoreExtraEvents
+	if !ignoreExtraEvents && len(started)+len(succeeded)+len(failed) > 0 {
+		return fmt.Errorf("extra heartbeat events published: %d started, %d succeeded, %d failed",
+			len(started), len(succeeded), len(failed))
+	}
+	return nil
+}
+
+func verifyAwaited(expected *bool, actual bool) error {
+	if expected != nil && *expected != actual {
+		return fmt.Errorf("expected awaited %v, got %v", *expected, actual)
+	}
+	return nil
+}
+
 func getNextPoolEvent(events []*event.PoolEvent, expectedType string) (*event.PoolEvent, []*event.PoolEvent, error) {
 	if len(events) == 0 {
 		return nil, nil, fmt.Errorf("no %q event published", expectedType)
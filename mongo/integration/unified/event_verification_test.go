@@ -0,0 +1,175 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package unified
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/internal/require"
+)
+
+func TestServerHeartbeatEventDocument(t *testing.T) {
+	t.Parallel()
+
+	for _, awaited := range []bool{true, false} {
+		tests := []struct {
+			name string
+			evt  interface{}
+		}{
+			{
+				name: string(serverHeartbeatStartedEvent),
+				evt:  &event.ServerHeartbeatStartedEvent{ConnectionID: "localhost:27017", Awaited: awaited},
+			},
+			{
+				name: string(serverHeartbeatSucceededEvent),
+				evt:  &event.ServerHeartbeatSucceededEvent{ConnectionID: "localhost:27017", Awaited: awaited},
+			},
+			{
+				name: string(serverHeartbeatFailedEvent),
+				evt: &event.ServerHeartbeatFailedEvent{
+					ConnectionID: "localhost:27017",
+					Awaited:      awaited,
+					Failure:      errors.New("heartbeat failed"),
+				},
+			},
+		}
+
+		for _, tc := range tests {
+			tc := tc
+			awaited := awaited
+
+			t.Run(fmt.Sprintf("%s awaited %v", tc.name, awaited), func(t *testing.T) {
+				t.Parallel()
+
+				doc := getServerHeartbeatEventDocument(tc.evt)
+				require.NotNil(t, doc, "expected a document for %T", tc.evt)
+
+				name, ok := doc.Lookup("name").StringValueOK()
+				assert.True(t, ok, "expected name to be a string")
+				assert.Equal(t, tc.name, name, "expected name %q, got %q", tc.name, name)
+
+				got, ok := doc.Lookup("awaited").BooleanOK()
+				assert.True(t, ok, "expected awaited to be a boolean")
+				assert.Equal(t, awaited, got, "expected awaited %v, got %v", awaited, got)
+			})
+		}
+	}
+
+	t.Run("other event", func(t *testing.T) {
+		t.Parallel()
+
+		doc := getServerHeartbeatEventDocument(&event.PoolEvent{})
+		assert.Nil(t, doc, "expected no document, got %v", doc)
+	})
+}
+
+func TestVerifySDAMEvents(t *testing.T) {
+	t.Parallel()
+
+	client := &clientEntity{
+		serverHeartbeatStartedEvent: []*event.ServerHeartbeatStartedEvent{
+			{Awaited: false},
+			{Awaited: true},
+		},
+		serverHeartbeatSucceeded: []*event.ServerHeartbeatSucceededEvent{
+			{Awaited: false},
+		},
+	}
+
+	unmarshalExpected := func(t *testing.T, doc bson.D) *expectedEvents {
+		t.Helper()
+
+		data, err := bson.Marshal(doc)
+		require.NoError(t, err, "Marshal error")
+
+		expected := &expectedEvents{}
+		err = bson.Unmarshal(data, expected)
+		require.NoError(t, err, "Unmarshal error")
+		return expected
+	}
+
+	tests := []struct {
+		name    string
+		events  bson.A
+		ignore  bool
+		wantErr bool
+	}{
+		{
+			name: "awaited flags match",
+			events: bson.A{
+				bson.D{{"serverHeartbeatStartedEvent", bson.D{{"awaited", false}}}},
+				bson.D{{"serverHeartbeatSucceededEvent", bson.D{{"awaited", false}}}},
+				bson.D{{"serverHeartbeatStartedEvent", bson.D{{"awaited", true}}}},
+			},
+		},
+		{
+			name: "awaited flag not set",
+			events: bson.A{
+				bson.D{{"serverHeartbeatStartedEvent", bson.D{}}},
+				bson.D{{"serverHeartbeatStartedEvent", bson.D{}}},
+				bson.D{{"serverHeartbeatSucceededEvent", bson.D{}}},
+			},
+		},
+		{
+			name: "awaited flag mismatch",
+			events: bson.A{
+				bson.D{{"serverHeartbeatStartedEvent", bson.D{{"awaited", true}}}},
+			},
+			ignore:  true,
+			wantErr: true,
+		},
+		{
+			name: "missing event",
+			events: bson.A{
+				bson.D{{"serverHeartbeatFailedEvent", bson.D{}}},
+			},
+			ignore:  true,
+			wantErr: true,
+		},
+		{
+			name: "extra events",
+			events: bson.A{
+				bson.D{{"serverHeartbeatStartedEvent", bson.D{{"awaited", false}}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "extra events ignored",
+			events: bson.A{
+				bson.D{{"serverHeartbeatStartedEvent", bson.D{{"awaited", false}}}},
+			},
+			ignore: true,
+		},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			expected := unmarshalExpected(t, bson.D{
+				{"client", "client0"},
+				{"eventType", "sdam"},
+				{"events", tc.events},
+				{"ignoreExtraEvents", tc.ignore},
+			})
+
+			err := verifySDAMEvents(client, expected)
+			if tc.wantErr {
+				assert.NotNil(t, err, "expected an error, got nil")
+				return
+			}
+			assert.Nil(t, err, "verifySDAMEvents error: %v", err)
+		})
+	}
+}
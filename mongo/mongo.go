@@ -17,6 +17,7 @@ import (
 	"strconv"
 	"strings"
 
+	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/internal/codecutil"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
@@ -85,6 +86,15 @@ var bvwPool = bsonrw.NewBSONValueWriterPool()
 
 // getEncoder takes a writer, BSON options, and a BSON registry and returns a properly configured
 // bson.Encoder that writes to the given writer.
+// WithCommandMetadata returns a copy of ctx that carries metadata for request tracing. The metadata is set as the
+// Metadata field of the CommandStartedEvent published for every command run with the returned context or a context
+// derived from it, including the getMore commands sent while iterating a Cursor or ChangeStream. This can be used to
+// correlate application-level request information with driver commands in a CommandMonitor. The map is not copied, so
+// it must not be modified while commands are running.
+func WithCommandMetadata(ctx context.Context, metadata map[string]interface{}) context.Context {
+	return internal.WithCommandMetadata(ctx, metadata)
+}
+
 func getEncoder(
 	w io.Writer,
 	opts *options.BSONOptions,
@@ -121,6 +131,15 @@ func getEncoder(
 		if opts.UseJSONStructTags {
 			enc.UseJSONStructTags()
 		}
+		if opts.UseJSONMarshalers {
+			enc.UseJSONMarshalers()
+		}
+		if opts.ParseJSONRawMessage {
+			enc.ParseJSONRawMessage()
+		}
+		if opts.DurationUnit > 0 {
+			enc.DurationUnit(opts.DurationUnit)
+		}
 	}
 
 	if reg != nil {
@@ -7,8 +7,10 @@
 package mongo
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"testing"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -574,6 +576,101 @@ func TestMarshalValue(t *testing.T) {
 					Build(),
 			},
 		},
+		{
+			name: "integer sizes",
+			value: bson.D{
+				{"int", 1},
+				{"bigInt64", int64(math.MaxInt32 + 1)},
+				{"int64", int64(1)},
+				{"uint64", uint64(1)},
+				{"bigUint64", uint64(math.MaxInt32 + 1)},
+			},
+			want: bsoncore.Value{
+				Type: bson.TypeEmbeddedDocument,
+				Data: bsoncore.NewDocumentBuilder().
+					AppendInt32("int", 1).
+					AppendInt64("bigInt64", math.MaxInt32+1).
+					AppendInt64("int64", 1).
+					AppendInt64("uint64", 1).
+					AppendInt64("bigUint64", math.MaxInt32+1).
+					Build(),
+			},
+		},
+		{
+			name: "integer sizes with IntMinSize",
+			value: bson.D{
+				{"int", 1},
+				{"bigInt64", int64(math.MaxInt32 + 1)},
+				{"int64", int64(1)},
+				{"uint64", uint64(1)},
+				{"bigUint64", uint64(math.MaxInt32 + 1)},
+			},
+			bsonOpts: &options.BSONOptions{
+				IntMinSize: true,
+			},
+			want: bsoncore.Value{
+				Type: bson.TypeEmbeddedDocument,
+				Data: bsoncore.NewDocumentBuilder().
+					AppendInt32("int", 1).
+					AppendInt64("bigInt64", math.MaxInt32+1).
+					AppendInt32("int64", 1).
+					AppendInt32("uint64", 1).
+					AppendInt64("bigUint64", math.MaxInt32+1).
+					Build(),
+			},
+		},
+		{
+			name: "UseJSONMarshalers",
+			value: struct {
+				Raw json.RawMessage
+			}{
+				Raw: json.RawMessage(`{"x": 1}`),
+			},
+			bsonOpts: &options.BSONOptions{
+				UseJSONMarshalers: true,
+			},
+			want: bsoncore.Value{
+				Type: bson.TypeEmbeddedDocument,
+				Data: bsoncore.NewDocumentBuilder().
+					AppendDocument("raw", bsoncore.NewDocumentBuilder().
+						AppendInt32("x", 1).
+						Build()).
+					Build(),
+			},
+		},
+		{
+			name: "ParseJSONRawMessage",
+			value: struct {
+				Raw json.RawMessage
+			}{
+				Raw: json.RawMessage(`{"x": 1}`),
+			},
+			bsonOpts: &options.BSONOptions{
+				ParseJSONRawMessage: true,
+			},
+			want: bsoncore.Value{
+				Type: bson.TypeEmbeddedDocument,
+				Data: bsoncore.NewDocumentBuilder().
+					AppendDocument("raw", bsoncore.NewDocumentBuilder().
+						AppendInt32("x", 1).
+						Build()).
+					Build(),
+			},
+		},
+		{
+			name: "json.RawMessage without ParseJSONRawMessage",
+			value: struct {
+				Raw json.RawMessage
+			}{
+				Raw: json.RawMessage(`{"x": 1}`),
+			},
+			want: bsoncore.Value{
+				Type: bson.TypeEmbeddedDocument,
+				Data: bsoncore.NewDocumentBuilder().
+					AppendBinary("raw", bson.TypeBinaryGeneric, []byte(`{"x": 1}`)).
+					Build(),
+			},
+		},
 	}
 	for _, tc := range testCases {
 		tc := tc // Capture range variable.
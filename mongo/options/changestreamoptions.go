@@ -11,13 +11,83 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 // ChangeStreamOptions represents options that can be used to configure a Watch operation.
 type ChangeStreamOptions struct {
-	// The maximum number of documents to be included in each batch returned by the server.
+	// If true, the server can write temporary data to disk while executing the aggregate command that opens the change
+	// stream. This option must not be set if "allowDiskUse" is also set using Custom. The default value is nil, which
+	// means that the allowDiskUse option will not be sent and the server default will be used.
+	AllowDiskUse *bool
+
+	// Specifies whether the change stream reports changes to all databases in the deployment. This option can only be
+	// used with Client.Watch, which always reports changes for the whole deployment, so it must be true. Setting it for
+	// a change stream created with Database.Watch or Collection.Watch causes Watch to return an error. The default
+	// value is nil, which means that allChangesForCluster is sent as true for Client.Watch and omitted otherwise.
+	AllChangesForCluster *bool
+
+	// The maximum number of documents to be included in each batch returned by the server. A value of 0 means that
+	// the batchSize field is omitted from the aggregate and getMore commands, so the server uses its default batch
+	// size. Note that this differs from sending batchSize 0 to the server, which would make the aggregate return an
+	// empty first batch. A negative value causes Watch to return an error without contacting the server. The default
+	// value is nil, which also means that the server default is used.
 	BatchSize *int32
 
+	// If true, update events that do not have a fullDocumentBeforeChange field are given a lookedUpDocument field by
+	// looking up the changed document with a find on its documentKey before the event is returned. This is a
+	// best-effort client-side fallback for deployments where pre-images are not stored. The looked up document is not
+	// a pre-image: the lookup runs after the update was applied, so it returns the document as it is at the time of
+	// the lookup, which includes this update and any later writes. Use FullDocumentBeforeChange with pre-images enabled
+	// on the collection if the pre-update document is needed. The find uses the change stream's read preference and,
+	// if the change stream was created with an explicit session, that session. If the find returns no document, the
+	// event is returned unchanged. If it fails, the event is not consumed, iteration stops, and the error is returned
+	// by Err. The default value is nil, which means that no lookups are done.
+	BestEffortPreImageLookup *bool
+
+	// The maximum amount of time that ChangeStream.Close waits for the killCursors command that closes the server-side
+	// cursor. If set, the killCursors command is sent with a context derived from the one passed to Close that times
+	// out after this duration, or, if the passed context is already done, with a new context that only has this
+	// timeout. This lets Close clean up the server-side cursor when it is called with a cancelled context. The default
+	// value is nil, which means that the context passed to Close is used as is.
+	CloseTimeout *time.Duration
+
+	// The number of events after which CheckpointFunc is called with the current resume token. This option is ignored
+	// if CheckpointFunc is not set.
+	CheckpointInterval *int
+
+	// A function that is called with the current resume token once every CheckpointInterval events and once when the
+	// change stream is closed. Checkpoints are taken when Next or TryNext is called after the last event in an
+	// interval has been returned, so the application has finished processing every event covered by the token. If
+	// the function returns an error, the change stream stops and the error is returned by Err. The token passed to
+	// the function is a copy and may be retained.
+	CheckpointFunc func(token bson.Raw) error
+
+	// The window during which ChangeStream.NextCoalesced collapses multiple events for the same document into the
+	// most recent one. This option is applied by the driver and is not sent to the server. It does not affect Next,
+	// TryNext, or NextBatch. The default value is nil, which means that NextCoalesced does not coalesce events.
+	CoalesceWindow *time.Duration
+
+	// The minimum amount of time between the starts of two getMore commands issued by TryNext or NextBatch. If one of
+	// those methods is called before the interval has elapsed since the previous getMore and the current batch is
+	// empty, it returns no events without contacting the server. This is a client-side limit that prevents a loop
+	// calling TryNext from sending getMore commands back to back. It does not affect Next, or the aggregate run when
+	// the change stream resumes. The default value is nil, which means that getMore commands are not throttled.
+	MinGetMoreInterval *time.Duration
+
+	// A function that is called after each aggregate or getMore reply with the number of events in the batch and the
+	// postBatchResumeToken included in the reply, which is nil if the server did not include one. Unlike per-event
+	// callbacks, it is also called for empty batches, so it can be used to collect metrics or take checkpoints that are
+	// aligned with server batches. The token passed to the function is a copy and may be retained.
+	PostBatchHook func(batchLen int, pbrt bson.Raw)
+
+	// If true, Watch checks the stages of the pipeline before sending it and returns an error wrapping
+	// mongo.ErrMissingResumeToken if a stage removes or modifies the _id field of change events, which holds the resume
+	// token. The check recognizes the common ways of doing this with the $project, $unset, $addFields, $set,
+	// $replaceRoot, and $replaceWith stages, and accepts expressions it cannot analyze. The default value is nil,
+	// which means that the pipeline is not checked.
+	ValidatePipeline *bool
+
 	// Specifies a collation to use for string comparisons during the operation. This option is only valid for MongoDB
 	// versions >= 3.4. For previous server versions, the driver will return an error if this option is used. The
 	// default value is nil, which means the default collation of the collection will be used.
@@ -27,6 +97,15 @@ type ChangeStreamOptions struct {
 	// The default is nil, which means that no comment will be included in the logs.
 	Comment *string
 
+	// If true, the change stream will not return events for system namespaces. This is done by adding the following
+	// stage immediately after the $changeStream stage:
+	//
+	//	{$match: {"ns.db": {$nin: ["admin", "config", "local"]}, "ns.coll": {$not: /^system\./}}}
+	//
+	// Events without an "ns.coll" field, such as dropDatabase events for non-system databases, are still returned. The
+	// default value is false.
+	ExcludeSystemNamespaces *bool
+
 	// Specifies how the updated document should be returned in change notifications for update operations. The default
 	// is options.Default, which means that only partial update deltas will be included in the change notification.
 	FullDocument *FullDocument
@@ -35,9 +114,90 @@ type ChangeStreamOptions struct {
 	// is options.Off, which means that the pre-update document will not be included in the change notification.
 	FullDocumentBeforeChange *FullDocument
 
+	// If true, Collection.Watch enables changeStreamPreAndPostImages on the collection before opening the change
+	// stream if FullDocument or FullDocumentBeforeChange is set to options.WhenAvailable or options.Required and the
+	// collection does not already have it enabled. See SetEnsurePreAndPostImages for details. The default value is
+	// false.
+	EnsurePreAndPostImages *bool
+
+	// The read preference used to select a server for the aggregate that is run when the change stream resumes. A
+	// getMore is always sent to the server that holds the change stream's cursor, so after a resume, getMore commands
+	// also go to a server selected with this read preference. It is also sent to mongos with the resume aggregate.
+	// The read preference must have a valid mode; Watch returns an error without contacting the server otherwise. The
+	// default value is nil, which means that the read preference of the Client, Database, or Collection is used.
+	GetMoreReadPreference *readpref.ReadPref
+
+	// The index to use for the aggregate command that opens the change stream. This should either be the index name as a
+	// string or the index specification as a document. The driver will return an error if the hint parameter is a
+	// multi-key map. The default value is nil, which means that no hint will be sent.
+	Hint interface{}
+
 	// The maximum amount of time that the server should wait for new documents to satisfy a tailable cursor query.
 	MaxAwaitTime *time.Duration
 
+	// The maximum amount of time that an event's wallTime may be behind the local clock when the event is returned by
+	// Next, TryNext, or NextBatch. If an event is older than this, the change stream stops and Err returns a
+	// mongo.ChangeStreamStalenessError. The event is not consumed, so the cached resume token still points before it.
+	// Events are only checked if they contain a wallTime field, which is included by MongoDB versions >= 6.0. The
+	// default value is nil, which means that staleness is not checked.
+	MaxStaleness *time.Duration
+
+	// The maximum amount of time that the change stream stays open, measured from when it is created. Once it has
+	// elapsed, the next call to Next, TryNext, or NextBatch closes the change stream, killing the server-side cursor,
+	// and returns false with Err returning nil. A blocking Next that is waiting for events stops after the getMore
+	// in progress returns. The default value is nil, which means that the change stream stays open until it is
+	// closed or fails.
+	MaxStreamDuration *time.Duration
+
+	// NamespaceDBRegex and NamespaceCollRegex are regular expressions matched against the database and collection
+	// names of each event's namespace. If either is set, a $match stage using $regexMatch on "ns.db" and "ns.coll"
+	// is added immediately after the $changeStream stage (and after the ExcludeSystemNamespaces stage, if any), so
+	// only events for matching namespaces are returned. An empty pattern matches every name. Events without an
+	// "ns.coll" field, such as dropDatabase events, are filtered out if NamespaceCollRegex is not empty. The patterns
+	// must be valid Go regular expressions; the driver returns an error without contacting the server otherwise.
+	// This option is only valid for MongoDB versions >= 4.2.
+	NamespaceDBRegex   *string
+	NamespaceCollRegex *string
+
+	// SingleCollection is the name of a collection to restrict a Database.Watch change stream to. If set, a $match
+	// stage on "ns.coll" is added after the $changeStream stage (and after the ExcludeSystemNamespaces and namespace
+	// regex stages, if any), so the server only returns events for the named collection. Events without an "ns.coll"
+	// field, such as dropDatabase and invalidate events, are still returned, so the change stream keeps the
+	// invalidation behavior of a database change stream. The driver returns an error without contacting the server
+	// if the name is empty or the option is used with Client.Watch or Collection.Watch. The default value is nil,
+	// which means that events for all collections are returned.
+	SingleCollection *string
+
+	// Match is a query filter on the fields of the change event envelope, such as operationType, ns, documentKey,
+	// and updateDescription. If set, a $match stage with the filter is added after the $changeStream stage (and
+	// after the ExcludeSystemNamespaces, namespace regex, and SingleCollection stages, if any), before the stages of
+	// the user pipeline. The stage is sent again when the change stream resumes, so the resumed stream returns the
+	// same events. The filter must be a document whose field names are event envelope fields, optionally followed by
+	// a dotted path (e.g. "ns.coll"), or the $and, $or, and $nor operators with arrays of such documents. Filtering
+	// on _id, which holds the resume token, or on fullDocument and fullDocumentBeforeChange is rejected; the driver
+	// returns an error without contacting the server in these cases. The default value is nil, which means that no
+	// $match stage is added.
+	Match interface{}
+
+	// The names of the top-level fields of the fullDocument field of each event to return. If set, an $addFields
+	// stage that replaces fullDocument with a document containing only the listed fields and _id is added to the end
+	// of the pipeline. Unlike a $project stage in the pipeline, this only changes fullDocument, so the _id field of the
+	// event, which holds the resume token, and the other fields of the event are preserved. Events without a
+	// fullDocument, or with a null fullDocument, are not changed. Field names must not be empty, contain ".", or start
+	// with "$"; the driver returns an error without contacting the server otherwise. This option has no effect on
+	// change streams created with WatchRaw. The default value is nil, which means that fullDocument is not changed.
+	ProjectFullDocumentFields []string
+
+	// ResumableErrorClassifier is called with the error from a failed getMore to decide whether the change stream
+	// should resume. By default, it extends the driver's rules: an error is resumable if the classifier returns true or
+	// if the driver considers it resumable (e.g. it has the ResumableChangeStreamError label). If
+	// ReplaceDefaultResumableErrors is true, only the classifier is used. Neither option has an effect if
+	// ResumableErrorClassifier is nil. The error passed to the classifier can be inspected with errors.As, for example
+	// to find a mongo.CommandError. Errors with the NonResumableChangeStreamError label are never resumed and are not
+	// passed to the classifier.
+	ResumableErrorClassifier      func(err error) bool
+	ReplaceDefaultResumableErrors *bool
+
 	// A document specifying the logical starting point for the change stream. Only changes corresponding to an oplog
 	// entry immediately after the resume token will be returned. If this is specified, StartAtOperationTime and
 	// StartAfter must not be set.
@@ -77,12 +237,67 @@ func ChangeStream() *ChangeStreamOptions {
 	return cso
 }
 
+// SetAllowDiskUse sets the value for the AllowDiskUse field.
+func (cso *ChangeStreamOptions) SetAllowDiskUse(b bool) *ChangeStreamOptions {
+	cso.AllowDiskUse = &b
+	return cso
+}
+
+// SetAllChangesForCluster sets the value for the AllChangesForCluster field.
+func (cso *ChangeStreamOptions) SetAllChangesForCluster(b bool) *ChangeStreamOptions {
+	cso.AllChangesForCluster = &b
+	return cso
+}
+
 // SetBatchSize sets the value for the BatchSize field.
 func (cso *ChangeStreamOptions) SetBatchSize(i int32) *ChangeStreamOptions {
 	cso.BatchSize = &i
 	return cso
 }
 
+// SetBestEffortPreImageLookup sets the value for the BestEffortPreImageLookup field.
+func (cso *ChangeStreamOptions) SetBestEffortPreImageLookup(b bool) *ChangeStreamOptions {
+	cso.BestEffortPreImageLookup = &b
+	return cso
+}
+
+// SetCloseTimeout sets the value for the CloseTimeout field.
+func (cso *ChangeStreamOptions) SetCloseTimeout(d time.Duration) *ChangeStreamOptions {
+	cso.CloseTimeout = &d
+	return cso
+}
+
+// SetCheckpoint sets the value for the CheckpointInterval and CheckpointFunc fields.
+func (cso *ChangeStreamOptions) SetCheckpoint(interval int, fn func(token bson.Raw) error) *ChangeStreamOptions {
+	cso.CheckpointInterval = &interval
+	cso.CheckpointFunc = fn
+	return cso
+}
+
+// SetValidatePipeline sets the value for the ValidatePipeline field.
+func (cso *ChangeStreamOptions) SetValidatePipeline(b bool) *ChangeStreamOptions {
+	cso.ValidatePipeline = &b
+	return cso
+}
+
+// SetPostBatchHook sets the value for the PostBatchHook field.
+func (cso *ChangeStreamOptions) SetPostBatchHook(fn func(batchLen int, pbrt bson.Raw)) *ChangeStreamOptions {
+	cso.PostBatchHook = fn
+	return cso
+}
+
+// SetMinGetMoreInterval sets the value for the MinGetMoreInterval field.
+func (cso *ChangeStreamOptions) SetMinGetMoreInterval(d time.Duration) *ChangeStreamOptions {
+	cso.MinGetMoreInterval = &d
+	return cso
+}
+
+// SetCoalesceWindow sets the value for the CoalesceWindow field.
+func (cso *ChangeStreamOptions) SetCoalesceWindow(d time.Duration) *ChangeStreamOptions {
+	cso.CoalesceWindow = &d
+	return cso
+}
+
 // SetCollation sets the value for the Collation field.
 func (cso *ChangeStreamOptions) SetCollation(c Collation) *ChangeStreamOptions {
 	cso.Collation = &c
@@ -95,24 +310,103 @@ func (cso *ChangeStreamOptions) SetComment(comment string) *ChangeStreamOptions
 	return cso
 }
 
+// SetExcludeSystemNamespaces sets the value for the ExcludeSystemNamespaces field.
+func (cso *ChangeStreamOptions) SetExcludeSystemNamespaces(b bool) *ChangeStreamOptions {
+	cso.ExcludeSystemNamespaces = &b
+	return cso
+}
+
 // SetFullDocument sets the value for the FullDocument field.
 func (cso *ChangeStreamOptions) SetFullDocument(fd FullDocument) *ChangeStreamOptions {
 	cso.FullDocument = &fd
 	return cso
 }
 
+// SetEnsurePreAndPostImages sets the value for the EnsurePreAndPostImages field. If true, Collection.Watch checks the
+// collection's options with listCollections before opening a change stream that requests pre- or post-images and, if
+// changeStreamPreAndPostImages is not enabled, runs a collMod command to enable it. The user must be authorized to
+// run the listCollections and collMod actions on the collection (e.g. with the dbAdmin role). Enabling pre- and
+// post-images makes the server store document images for every later change to the collection, which uses
+// additional storage. This option is ignored by Client.Watch, Database.Watch, and Collection.WatchRaw. It requires
+// MongoDB 6.0 or later.
+func (cso *ChangeStreamOptions) SetEnsurePreAndPostImages(b bool) *ChangeStreamOptions {
+	cso.EnsurePreAndPostImages = &b
+	return cso
+}
+
 // SetFullDocumentBeforeChange sets the value for the FullDocumentBeforeChange field.
 func (cso *ChangeStreamOptions) SetFullDocumentBeforeChange(fdbc FullDocument) *ChangeStreamOptions {
 	cso.FullDocumentBeforeChange = &fdbc
 	return cso
 }
 
+// SetGetMoreReadPreference sets the value for the GetMoreReadPreference field.
+func (cso *ChangeStreamOptions) SetGetMoreReadPreference(rp *readpref.ReadPref) *ChangeStreamOptions {
+	cso.GetMoreReadPreference = rp
+	return cso
+}
+
+// SetHint sets the value for the Hint field.
+func (cso *ChangeStreamOptions) SetHint(h interface{}) *ChangeStreamOptions {
+	cso.Hint = h
+	return cso
+}
+
 // SetMaxAwaitTime sets the value for the MaxAwaitTime field.
 func (cso *ChangeStreamOptions) SetMaxAwaitTime(d time.Duration) *ChangeStreamOptions {
 	cso.MaxAwaitTime = &d
 	return cso
 }
 
+// SetMaxStaleness sets the value for the MaxStaleness field.
+func (cso *ChangeStreamOptions) SetMaxStaleness(d time.Duration) *ChangeStreamOptions {
+	cso.MaxStaleness = &d
+	return cso
+}
+
+// SetMaxStreamDuration sets the value for the MaxStreamDuration field.
+func (cso *ChangeStreamOptions) SetMaxStreamDuration(d time.Duration) *ChangeStreamOptions {
+	cso.MaxStreamDuration = &d
+	return cso
+}
+
+// SetNamespaceRegex sets the value for the NamespaceDBRegex and NamespaceCollRegex fields.
+func (cso *ChangeStreamOptions) SetNamespaceRegex(db, coll string) *ChangeStreamOptions {
+	cso.NamespaceDBRegex = &db
+	cso.NamespaceCollRegex = &coll
+	return cso
+}
+
+// SetSingleCollection sets the value for the SingleCollection field.
+func (cso *ChangeStreamOptions) SetSingleCollection(name string) *ChangeStreamOptions {
+	cso.SingleCollection = &name
+	return cso
+}
+
+// SetMatch sets the value for the Match field.
+func (cso *ChangeStreamOptions) SetMatch(filter interface{}) *ChangeStreamOptions {
+	cso.Match = filter
+	return cso
+}
+
+// SetProjectFullDocumentFields sets the value for the ProjectFullDocumentFields field.
+func (cso *ChangeStreamOptions) SetProjectFullDocumentFields(include []string) *ChangeStreamOptions {
+	cso.ProjectFullDocumentFields = include
+	return cso
+}
+
+// SetResumableErrorClassifier sets the value for the ResumableErrorClassifier field.
+func (cso *ChangeStreamOptions) SetResumableErrorClassifier(fn func(err error) bool) *ChangeStreamOptions {
+	cso.ResumableErrorClassifier = fn
+	return cso
+}
+
+// SetReplaceDefaultResumableErrors sets the value for the ReplaceDefaultResumableErrors field.
+func (cso *ChangeStreamOptions) SetReplaceDefaultResumableErrors(b bool) *ChangeStreamOptions {
+	cso.ReplaceDefaultResumableErrors = &b
+	return cso
+}
+
 // SetResumeAfter sets the value for the ResumeAfter field.
 func (cso *ChangeStreamOptions) SetResumeAfter(rt interface{}) *ChangeStreamOptions {
 	cso.ResumeAfter = rt
@@ -165,24 +459,93 @@ func MergeChangeStreamOptions(opts ...*ChangeStreamOptions) *ChangeStreamOptions
 		if cso == nil {
 			continue
 		}
+		if cso.AllowDiskUse != nil {
+			csOpts.AllowDiskUse = cso.AllowDiskUse
+		}
+		if cso.AllChangesForCluster != nil {
+			csOpts.AllChangesForCluster = cso.AllChangesForCluster
+		}
 		if cso.BatchSize != nil {
 			csOpts.BatchSize = cso.BatchSize
 		}
+		if cso.BestEffortPreImageLookup != nil {
+			csOpts.BestEffortPreImageLookup = cso.BestEffortPreImageLookup
+		}
+		if cso.CloseTimeout != nil {
+			csOpts.CloseTimeout = cso.CloseTimeout
+		}
+		if cso.CheckpointInterval != nil {
+			csOpts.CheckpointInterval = cso.CheckpointInterval
+		}
+		if cso.CheckpointFunc != nil {
+			csOpts.CheckpointFunc = cso.CheckpointFunc
+		}
+		if cso.CoalesceWindow != nil {
+			csOpts.CoalesceWindow = cso.CoalesceWindow
+		}
+		if cso.MinGetMoreInterval != nil {
+			csOpts.MinGetMoreInterval = cso.MinGetMoreInterval
+		}
+		if cso.PostBatchHook != nil {
+			csOpts.PostBatchHook = cso.PostBatchHook
+		}
+		if cso.ValidatePipeline != nil {
+			csOpts.ValidatePipeline = cso.ValidatePipeline
+		}
 		if cso.Collation != nil {
 			csOpts.Collation = cso.Collation
 		}
 		if cso.Comment != nil {
 			csOpts.Comment = cso.Comment
 		}
+		if cso.ExcludeSystemNamespaces != nil {
+			csOpts.ExcludeSystemNamespaces = cso.ExcludeSystemNamespaces
+		}
 		if cso.FullDocument != nil {
 			csOpts.FullDocument = cso.FullDocument
 		}
 		if cso.FullDocumentBeforeChange != nil {
 			csOpts.FullDocumentBeforeChange = cso.FullDocumentBeforeChange
 		}
+		if cso.GetMoreReadPreference != nil {
+			csOpts.GetMoreReadPreference = cso.GetMoreReadPreference
+		}
+		if cso.Hint != nil {
+			csOpts.Hint = cso.Hint
+		}
 		if cso.MaxAwaitTime != nil {
 			csOpts.MaxAwaitTime = cso.MaxAwaitTime
 		}
+		if cso.MaxStaleness != nil {
+			csOpts.MaxStaleness = cso.MaxStaleness
+		}
+		if cso.MaxStreamDuration != nil {
+			csOpts.MaxStreamDuration = cso.MaxStreamDuration
+		}
+		if cso.NamespaceDBRegex != nil {
+			csOpts.NamespaceDBRegex = cso.NamespaceDBRegex
+		}
+		if cso.NamespaceCollRegex != nil {
+			csOpts.NamespaceCollRegex = cso.NamespaceCollRegex
+		}
+		if cso.EnsurePreAndPostImages != nil {
+			csOpts.EnsurePreAndPostImages = cso.EnsurePreAndPostImages
+		}
+		if cso.ProjectFullDocumentFields != nil {
+			csOpts.ProjectFullDocumentFields = cso.ProjectFullDocumentFields
+		}
+		if cso.SingleCollection != nil {
+			csOpts.SingleCollection = cso.SingleCollection
+		}
+		if cso.Match != nil {
+			csOpts.Match = cso.Match
+		}
+		if cso.ResumableErrorClassifier != nil {
+			csOpts.ResumableErrorClassifier = cso.ResumableErrorClassifier
+		}
+		if cso.ReplaceDefaultResumableErrors != nil {
+			csOpts.ReplaceDefaultResumableErrors = cso.ReplaceDefaultResumableErrors
+		}
 		if cso.ResumeAfter != nil {
 			csOpts.ResumeAfter = cso.ResumeAfter
 		}
@@ -8,15 +8,21 @@ package options
 
 import (
 	"testing"
+	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
 func TestMergeChangeStreamOptions(t *testing.T) {
 	t.Parallel()
 
+	boolP := func(x bool) *bool { return &x }
 	fullDocumentP := func(x FullDocument) *FullDocument { return &x }
 	int32P := func(x int32) *int32 { return &x }
+	stringP := func(x string) *string { return &x }
+	durationP := func(x time.Duration) *time.Duration { return &x }
 
 	testCases := []struct {
 		description string
@@ -55,6 +61,166 @@ func TestMergeChangeStreamOptions(t *testing.T) {
 				BatchSize:                int32P(10),
 			},
 		},
+		{
+			description: "NamespaceRegex",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetNamespaceRegex("^app", "^events_"),
+			},
+			want: &ChangeStreamOptions{
+				NamespaceDBRegex:   stringP("^app"),
+				NamespaceCollRegex: stringP("^events_"),
+			},
+		},
+		{
+			description: "last MaxStaleness wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetMaxStaleness(time.Second),
+				ChangeStream().SetMaxStaleness(time.Minute),
+			},
+			want: &ChangeStreamOptions{
+				MaxStaleness: durationP(time.Minute),
+			},
+		},
+		{
+			description: "last MaxStreamDuration wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetMaxStreamDuration(time.Second),
+				ChangeStream().SetMaxStreamDuration(time.Minute),
+			},
+			want: &ChangeStreamOptions{
+				MaxStreamDuration: durationP(time.Minute),
+			},
+		},
+		{
+			description: "last CoalesceWindow wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetCoalesceWindow(time.Second),
+				ChangeStream().SetCoalesceWindow(time.Minute),
+			},
+			want: &ChangeStreamOptions{
+				CoalesceWindow: durationP(time.Minute),
+			},
+		},
+		{
+			description: "last AllChangesForCluster wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetAllChangesForCluster(false),
+				ChangeStream().SetAllChangesForCluster(true),
+			},
+			want: &ChangeStreamOptions{
+				AllChangesForCluster: boolP(true),
+			},
+		},
+		{
+			description: "last ValidatePipeline wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetValidatePipeline(true),
+				ChangeStream().SetValidatePipeline(false),
+			},
+			want: &ChangeStreamOptions{
+				ValidatePipeline: boolP(false),
+			},
+		},
+		{
+			description: "last MinGetMoreInterval wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetMinGetMoreInterval(time.Second),
+				ChangeStream().SetMinGetMoreInterval(time.Minute),
+			},
+			want: &ChangeStreamOptions{
+				MinGetMoreInterval: durationP(time.Minute),
+			},
+		},
+		{
+			description: "last CloseTimeout wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetCloseTimeout(time.Second),
+				ChangeStream().SetCloseTimeout(time.Minute),
+			},
+			want: &ChangeStreamOptions{
+				CloseTimeout: durationP(time.Minute),
+			},
+		},
+		{
+			description: "last GetMoreReadPreference wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetGetMoreReadPreference(readpref.Primary()),
+				ChangeStream().SetGetMoreReadPreference(readpref.Secondary()),
+			},
+			want: &ChangeStreamOptions{
+				GetMoreReadPreference: readpref.Secondary(),
+			},
+		},
+		{
+			description: "last EnsurePreAndPostImages wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetEnsurePreAndPostImages(true),
+				ChangeStream().SetEnsurePreAndPostImages(false),
+			},
+			want: &ChangeStreamOptions{
+				EnsurePreAndPostImages: boolP(false),
+			},
+		},
+		{
+			description: "last SingleCollection wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetSingleCollection("a"),
+				ChangeStream().SetSingleCollection("b"),
+			},
+			want: &ChangeStreamOptions{
+				SingleCollection: stringP("b"),
+			},
+		},
+		{
+			description: "last Match wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetMatch(bson.D{{"operationType", "insert"}}),
+				ChangeStream().SetMatch(bson.D{{"operationType", "delete"}}),
+			},
+			want: &ChangeStreamOptions{
+				Match: bson.D{{"operationType", "delete"}},
+			},
+		},
+		{
+			description: "last ProjectFullDocumentFields wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetProjectFullDocumentFields([]string{"a"}),
+				ChangeStream().SetProjectFullDocumentFields([]string{"b", "c"}),
+			},
+			want: &ChangeStreamOptions{
+				ProjectFullDocumentFields: []string{"b", "c"},
+			},
+		},
+		{
+			description: "last BestEffortPreImageLookup wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetBestEffortPreImageLookup(true),
+				ChangeStream().SetBestEffortPreImageLookup(false),
+			},
+			want: &ChangeStreamOptions{
+				BestEffortPreImageLookup: boolP(false),
+			},
+		},
+		{
+			description: "last AllowDiskUse wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetAllowDiskUse(true),
+				ChangeStream().SetAllowDiskUse(false),
+			},
+			want: &ChangeStreamOptions{
+				AllowDiskUse: boolP(false),
+			},
+		},
+		{
+			description: "last Hint wins",
+			input: []*ChangeStreamOptions{
+				ChangeStream().SetHint("a_1"),
+				ChangeStream().SetHint(bson.D{{"b", 1}}),
+			},
+			want: &ChangeStreamOptions{
+				Hint: bson.D{{"b", 1}},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -68,3 +234,41 @@ func TestMergeChangeStreamOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeChangeStreamOptionsResumableErrorClassifier(t *testing.T) {
+	t.Parallel()
+
+	// Functions can't be compared, so check which classifier was kept by calling it.
+	var called string
+	first := ChangeStream().SetResumableErrorClassifier(func(error) bool {
+		called = "first"
+		return false
+	})
+	second := ChangeStream().
+		SetResumableErrorClassifier(func(error) bool {
+			called = "second"
+			return true
+		}).
+		SetReplaceDefaultResumableErrors(true)
+
+	got := MergeChangeStreamOptions(first, second, ChangeStream())
+	assert.NotNil(t, got.ResumableErrorClassifier, "expected ResumableErrorClassifier to be set")
+	assert.True(t, got.ResumableErrorClassifier(nil), "expected second classifier to return true")
+	assert.Equal(t, "second", called, "expected the last classifier to be kept, got %q", called)
+	assert.NotNil(t, got.ReplaceDefaultResumableErrors, "expected ReplaceDefaultResumableErrors to be set")
+	assert.True(t, *got.ReplaceDefaultResumableErrors, "expected ReplaceDefaultResumableErrors to be true")
+}
+
+func TestMergeChangeStreamOptionsPostBatchHook(t *testing.T) {
+	t.Parallel()
+
+	// Functions can't be compared, so check which hook was kept by calling it.
+	var called string
+	first := ChangeStream().SetPostBatchHook(func(int, bson.Raw) { called = "first" })
+	second := ChangeStream().SetPostBatchHook(func(int, bson.Raw) { called = "second" })
+
+	got := MergeChangeStreamOptions(first, second, ChangeStream())
+	assert.NotNil(t, got.PostBatchHook, "expected PostBatchHook to be set")
+	got.PostBatchHook(0, nil)
+	assert.Equal(t, "second", called, "expected the last hook to be kept, got %q", called)
+}
@@ -14,6 +14,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"net/http"
@@ -24,6 +25,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson/bsoncodec"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal"
+	"go.mongodb.org/mongo-driver/internal/logger"
 	"go.mongodb.org/mongo-driver/mongo/readconcern"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/mongo/writeconcern"
@@ -33,6 +35,11 @@ import (
 	"go.mongodb.org/mongo-driver/x/mongo/driver/wiremessage"
 )
 
+// MinHeartbeatInterval is the smallest heartbeat interval that can be set on a Client. The server monitors never check
+// a server more often than this, so smaller values would only shorten the time the streaming protocol waits for a
+// server response.
+const MinHeartbeatInterval = internal.MinHeartbeatInterval
+
 // ContextDialer is an interface that can be implemented by types that can create connections. It should be used to
 // provide a custom dialer when configuring a Client.
 //
@@ -98,6 +105,19 @@ type BSONOptions struct {
 	// struct tag if a "bson" struct tag is not specified.
 	UseJSONStructTags bool
 
+	// UseJSONMarshalers causes the driver to marshal values that implement
+	// json.Marshaler, but not bson.Marshaler or bson.ValueMarshaler, by
+	// converting the output of their MarshalJSON method to BSON. The JSON is
+	// parsed as relaxed Extended JSON. Implementations of bson.Marshaler and
+	// bson.ValueMarshaler and encoders registered for a specific type always
+	// take precedence.
+	UseJSONMarshalers bool
+
+	// ParseJSONRawMessage causes the driver to marshal json.RawMessage values
+	// by parsing them as relaxed Extended JSON instead of as BSON binary
+	// values. Marshaling fails if a json.RawMessage contains invalid JSON.
+	ParseJSONRawMessage bool
+
 	// ErrorOnInlineDuplicates causes the driver to return an error if there is
 	// a duplicate field in the marshaled BSON when the "inline" struct tag
 	// option is set.
@@ -160,6 +180,12 @@ type BSONOptions struct {
 	// "interface{}" or "map[string]interface{}".
 	DefaultDocumentM bool
 
+	// ErrorOnUnknownFields causes the driver to return an error when
+	// unmarshaling a BSON document into a Go struct if the document contains a
+	// field that does not map to a struct field. Fields collected by an
+	// "inline" map are not considered unknown.
+	ErrorOnUnknownFields bool
+
 	// UseLocalTimeZone causes the driver to unmarshal time.Time values in the
 	// local timezone instead of the UTC timezone.
 	UseLocalTimeZone bool
@@ -172,6 +198,18 @@ type BSONOptions struct {
 	// structs in the destination value before unmarshaling BSON documents into
 	// them.
 	ZeroStructs bool
+
+	// DurationUnit is the unit used to marshal Go time.Duration values to BSON
+	// int64 values and to unmarshal BSON integer values into time.Duration
+	// values (e.g. time.Millisecond or time.Second). When marshaling, any
+	// remainder smaller than the unit is truncated. The default value is 0,
+	// which means that durations are stored as nanoseconds.
+	DurationUnit time.Duration
+
+	// CanonicalJSONRawMessage causes the driver to unmarshal BSON values into
+	// json.RawMessage values as canonical Extended JSON instead of relaxed
+	// Extended JSON.
+	CanonicalJSONRawMessage bool
 }
 
 // ClientOptions contains options to configure a Client instance. Each option can be set through setter functions. See
@@ -195,6 +233,7 @@ type ClientOptions struct {
 	MaxPoolSize              *uint64
 	MinPoolSize              *uint64
 	MaxConnecting            *uint64
+	MaxRetryDuration         *time.Duration
 	PoolMonitor              *event.PoolMonitor
 	Monitor                  *event.CommandMonitor
 	ServerMonitor            *event.ServerMonitor
@@ -207,6 +246,7 @@ type ClientOptions struct {
 	RetryWrites              *bool
 	ServerAPIOptions         *ServerAPIOptions
 	ServerSelectionTimeout   *time.Duration
+	ServerSelectionTryOnce   *bool
 	SRVMaxHosts              *int
 	SRVServiceName           *string
 	Timeout                  *time.Duration
@@ -244,6 +284,26 @@ type ClientOptions struct {
 	// may be used in its place to control the amount of time that a single operation can run before returning
 	// an error. Setting SocketTimeout and Timeout on a single client will result in undefined behavior.
 	SocketTimeout *time.Duration
+
+	// ConnectionIdleTimeoutFunc specifies a function that returns the maximum amount of time that a connection
+	// will remain idle in a connection pool. See SetConnectionIdleTimeoutFunc for details.
+	ConnectionIdleTimeoutFunc func() time.Duration
+
+	// RequestIDFunc specifies a function that returns a request ID to add to the comment of each command sent by
+	// the driver. See SetRequestIDFunc for details.
+	RequestIDFunc func() string
+
+	// DefaultCausalConsistencyForWatch specifies whether change streams created without an explicit session use a
+	// causally consistent implicit session. See SetDefaultCausalConsistencyForWatch for details.
+	DefaultCausalConsistencyForWatch *bool
+
+	// TracerProvider provides the Tracer used to create spans for change stream operations. See SetTracerProvider
+	// for details.
+	TracerProvider event.TracerProvider
+
+	// MaxConcurrentStreamingGetMores specifies the maximum number of change stream getMore commands that may run
+	// concurrently against each server. See SetMaxConcurrentStreamingGetMores for details.
+	MaxConcurrentStreamingGetMores *int
 }
 
 // Client creates a new ClientOptions instance.
@@ -273,6 +333,14 @@ func (c *ClientOptions) validate() error {
 		}
 	}
 
+	if c.HeartbeatInterval != nil && *c.HeartbeatInterval < MinHeartbeatInterval && !internal.AllowLowHeartbeatIntervals {
+		return fmt.Errorf("heartbeatInterval must be at least %v, got %v", MinHeartbeatInterval, *c.HeartbeatInterval)
+	}
+
+	if c.MaxConcurrentStreamingGetMores != nil && *c.MaxConcurrentStreamingGetMores < 0 {
+		return fmt.Errorf("maxConcurrentStreamingGetMores must not be negative, got %d", *c.MaxConcurrentStreamingGetMores)
+	}
+
 	if c.MaxPoolSize != nil && c.MinPoolSize != nil && *c.MaxPoolSize != 0 && *c.MinPoolSize > *c.MaxPoolSize {
 		return fmt.Errorf("minPoolSize must be less than or equal to maxPoolSize, got minPoolSize=%d maxPoolSize=%d", *c.MinPoolSize, *c.MaxPoolSize)
 	}
@@ -595,6 +663,19 @@ func (c *ClientOptions) SetConnectTimeout(d time.Duration) *ClientOptions {
 	return c
 }
 
+// SetDefaultCausalConsistencyForWatch specifies whether change streams created by Client.Watch, Database.Watch, or
+// Collection.Watch without an explicit session run in a causally consistent implicit session. If true, the implicit
+// session starts at the latest cluster time seen by the Client, so the aggregate that opens the change stream
+// includes that time as the afterClusterTime read concern. This ensures that the change stream observes data at
+// least as recent as the writes that the application previously made through the same Client, and that the
+// aggregate run when the change stream resumes includes the operationTime of the most recent command run by the
+// change stream. Change streams created with an explicit session use that session's causal consistency setting. The
+// default is false.
+func (c *ClientOptions) SetDefaultCausalConsistencyForWatch(b bool) *ClientOptions {
+	c.DefaultCausalConsistencyForWatch = &b
+	return c
+}
+
 // SetDialer specifies a custom ContextDialer to be used to create new connections to the server. The default is a
 // net.Dialer with the Timeout field set to ConnectTimeout. See https://golang.org/pkg/net/#Dialer for more information
 // about the net.Dialer type.
@@ -625,6 +706,7 @@ func (c *ClientOptions) SetDirect(b bool) *ClientOptions {
 
 // SetHeartbeatInterval specifies the amount of time to wait between periodic background server checks. This can also be
 // set through the "heartbeatIntervalMS" URI option (e.g. "heartbeatIntervalMS=10000"). The default is 10 seconds.
+// The interval must be at least MinHeartbeatInterval, or Validate and NewClient will return an error.
 func (c *ClientOptions) SetHeartbeatInterval(d time.Duration) *ClientOptions {
 	c.HeartbeatInterval = &d
 	return c
@@ -672,6 +754,33 @@ func (c *ClientOptions) SetLoggerOptions(opts *LoggerOptions) *ClientOptions {
 	return c
 }
 
+// SetLoggerWriter configures the client to write human-readable log lines to w for every log component at the given
+// level. Each line has the form "<timestamp> <LEVEL> <message> key=value ...". Levels that were already set for
+// specific components using SetLoggerOptions take precedence over level, so a single component can be made more or
+// less verbose. This replaces any LogSink previously configured with SetLoggerOptions.
+func (c *ClientOptions) SetLoggerWriter(w io.Writer, level LogLevel) *ClientOptions {
+	if c.LoggerOptions == nil {
+		c.LoggerOptions = Logger()
+	}
+	if c.LoggerOptions.ComponentLevels == nil {
+		c.LoggerOptions.ComponentLevels = map[LogComponent]LogLevel{}
+	}
+
+	c.LoggerOptions.SetSink(logger.NewTextSink(w))
+	for _, component := range []LogComponent{
+		LogComponentCommand,
+		LogComponentTopology,
+		LogComponentServerSelection,
+		LogComponentConnection,
+	} {
+		if _, ok := c.LoggerOptions.ComponentLevels[component]; !ok {
+			c.LoggerOptions.ComponentLevels[component] = level
+		}
+	}
+
+	return c
+}
+
 // SetMaxConnIdleTime specifies the maximum amount of time that a connection will remain idle in a connection pool
 // before it is removed from the pool and closed. This can also be set through the "maxIdleTimeMS" URI option (e.g.
 // "maxIdleTimeMS=10000"). The default is 0, meaning a connection can remain unused indefinitely.
@@ -680,6 +789,55 @@ func (c *ClientOptions) SetMaxConnIdleTime(d time.Duration) *ClientOptions {
 	return c
 }
 
+// SetConnectionIdleTimeoutFunc specifies a function that returns the maximum amount of time that a connection will
+// remain idle in a connection pool before it is removed from the pool and closed. Unlike SetMaxConnIdleTime, the
+// function is called each time the pool checks whether an idle connection has expired, so the idle timeout can be
+// adjusted while the client is running (e.g. to shrink the pool under low load). The function may be called
+// concurrently and should return quickly. A return value of 0 means a connection can remain unused indefinitely. If
+// set, this option takes precedence over SetMaxConnIdleTime and the "maxIdleTimeMS" URI option. Connections removed
+// because of this function are reported to the PoolMonitor as ConnectionClosed events with reason "idle".
+func (c *ClientOptions) SetConnectionIdleTimeoutFunc(fn func() time.Duration) *ClientOptions {
+	c.ConnectionIdleTimeoutFunc = fn
+	return c
+}
+
+// SetRequestIDFunc specifies a function that returns an application-defined request ID, such as a trace ID, for
+// end-to-end tracing. The function is called once for each command the driver runs on behalf of the application,
+// including the getMore and killCursors commands run by cursors and change streams. Retries of a command use the
+// request ID of the original attempt. The request ID is added to the command's "comment" field, so it is included in the
+// CommandStartedEvent and in the server's logs and profiler output.
+//
+// If the command has a comment set by the application (e.g. using FindOptions.SetComment), the comment sent to the
+// server is {requestId: <request ID>, comment: <comment>}. Otherwise, it is {requestId: <request ID>}. If the function
+// returns an empty string, the command is sent unchanged.
+//
+// The request ID is only added for server versions 4.4 and above, because older servers reject a comment on most
+// commands. The default is nil, meaning no request ID is added.
+func (c *ClientOptions) SetRequestIDFunc(fn func() string) *ClientOptions {
+	c.RequestIDFunc = fn
+	return c
+}
+
+// SetMaxConcurrentStreamingGetMores specifies the maximum number of getMore commands that change streams created by the
+// Client may run concurrently against each server. A getMore that would exceed the limit waits until another change
+// stream's getMore finishes or its context is done. This bounds the connections and server resources used by
+// applications that run many change streams. If this is 0, the number of concurrent getMores is not limited. The
+// default is 0.
+func (c *ClientOptions) SetMaxConcurrentStreamingGetMores(n int) *ClientOptions {
+	c.MaxConcurrentStreamingGetMores = &n
+	return c
+}
+
+// SetMaxRetryDuration specifies the maximum amount of time that can be spent retrying a retryable read or write
+// operation, measured from the first failed attempt. Once exceeded, no further retries are attempted and the most recent
+// error is returned, even if the operation's Timeout has not expired. This prevents a long Timeout from being fully
+// consumed by retries. The default is nil, meaning retries are only limited by the operation's Timeout and the
+// RetryReads and RetryWrites options.
+func (c *ClientOptions) SetMaxRetryDuration(d time.Duration) *ClientOptions {
+	c.MaxRetryDuration = &d
+	return c
+}
+
 // SetMaxPoolSize specifies that maximum number of connections allowed in the driver's connection pool to each server.
 // Requests to a server will block if this maximum is reached. This can also be set through the "maxPoolSize" URI option
 // (e.g. "maxPoolSize=100"). If this is 0, maximum connection pool size is not limited. The default is 100.
@@ -813,6 +971,17 @@ func (c *ClientOptions) SetServerSelectionTimeout(d time.Duration) *ClientOption
 	return c
 }
 
+// SetServerSelectionTryOnce specifies whether server selection should fail immediately if no suitable server is
+// available, instead of waiting up to ServerSelectionTimeout for one to become available. This is useful for
+// latency-sensitive applications that would rather handle an error than block. Servers whose initial check has not
+// completed yet, such as all servers right after Connect, are not considered unsuitable, so selection still waits up to
+// ServerSelectionTimeout for those checks to complete. Operations such as Watch that select a server for their initial
+// command are also affected. The default value is false.
+func (c *ClientOptions) SetServerSelectionTryOnce(b bool) *ClientOptions {
+	c.ServerSelectionTryOnce = &b
+	return c
+}
+
 // SetSocketTimeout specifies how long the driver will wait for a socket read or write to return before returning a
 // network error. This can also be set through the "socketTimeoutMS" URI option (e.g. "socketTimeoutMS=1000"). The
 // default value is 0, meaning no timeout is used and socket operations can block indefinitely.
@@ -825,6 +994,18 @@ func (c *ClientOptions) SetSocketTimeout(d time.Duration) *ClientOptions {
 	return c
 }
 
+// SetTracerProvider specifies a TracerProvider used to trace change stream operations. If set, each change stream
+// created by the Client records a span named "changeStream.aggregate" for the aggregate that opens it, a span named
+// "changeStream.resume" for each aggregate that resumes it, and a span named "changeStream.getMore" for each getMore
+// it runs. Spans have the database name, collection name, and operation attributes, plus the number of events
+// returned in total and per operation type (see the SpanAttribute constants in the event package). The Tracer is
+// requested from tp once, with the name "go.mongodb.org/mongo-driver". The default is nil, which means that no spans
+// are created.
+func (c *ClientOptions) SetTracerProvider(tp event.TracerProvider) *ClientOptions {
+	c.TracerProvider = tp
+	return c
+}
+
 // SetTimeout specifies the amount of time that a single operation run on this Client can execute before returning an error.
 // The deadline of any operation run through the Client will be honored above any Timeout set on the Client; Timeout will only
 // be honored if there is no deadline on the operation Context. Timeout can also be set through the "timeoutMS" URI option
@@ -997,6 +1178,12 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.Crypt != nil {
 			c.Crypt = opt.Crypt
 		}
+		if opt.DefaultCausalConsistencyForWatch != nil {
+			c.DefaultCausalConsistencyForWatch = opt.DefaultCausalConsistencyForWatch
+		}
+		if opt.TracerProvider != nil {
+			c.TracerProvider = opt.TracerProvider
+		}
 		if opt.HeartbeatInterval != nil {
 			c.HeartbeatInterval = opt.HeartbeatInterval
 		}
@@ -1015,15 +1202,27 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.MaxConnIdleTime != nil {
 			c.MaxConnIdleTime = opt.MaxConnIdleTime
 		}
+		if opt.ConnectionIdleTimeoutFunc != nil {
+			c.ConnectionIdleTimeoutFunc = opt.ConnectionIdleTimeoutFunc
+		}
+		if opt.RequestIDFunc != nil {
+			c.RequestIDFunc = opt.RequestIDFunc
+		}
 		if opt.MaxPoolSize != nil {
 			c.MaxPoolSize = opt.MaxPoolSize
 		}
+		if opt.MaxRetryDuration != nil {
+			c.MaxRetryDuration = opt.MaxRetryDuration
+		}
 		if opt.MinPoolSize != nil {
 			c.MinPoolSize = opt.MinPoolSize
 		}
 		if opt.MaxConnecting != nil {
 			c.MaxConnecting = opt.MaxConnecting
 		}
+		if opt.MaxConcurrentStreamingGetMores != nil {
+			c.MaxConcurrentStreamingGetMores = opt.MaxConcurrentStreamingGetMores
+		}
 		if opt.PoolMonitor != nil {
 			c.PoolMonitor = opt.PoolMonitor
 		}
@@ -1060,6 +1259,9 @@ func MergeClientOptions(opts ...*ClientOptions) *ClientOptions {
 		if opt.ServerSelectionTimeout != nil {
 			c.ServerSelectionTimeout = opt.ServerSelectionTimeout
 		}
+		if opt.ServerSelectionTryOnce != nil {
+			c.ServerSelectionTryOnce = opt.ServerSelectionTryOnce
+		}
 		if opt.Direct != nil {
 			c.Direct = opt.Direct
 		}
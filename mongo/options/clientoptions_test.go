@@ -69,6 +69,7 @@ func TestClientOptions(t *testing.T) {
 			{"Auth", (*ClientOptions).SetAuth, Credential{Username: "foo", Password: "bar"}, "Auth", true},
 			{"Compressors", (*ClientOptions).SetCompressors, []string{"zstd", "snappy", "zlib"}, "Compressors", true},
 			{"ConnectTimeout", (*ClientOptions).SetConnectTimeout, 5 * time.Second, "ConnectTimeout", true},
+			{"DefaultCausalConsistencyForWatch", (*ClientOptions).SetDefaultCausalConsistencyForWatch, true, "DefaultCausalConsistencyForWatch", true},
 			{"Dialer", (*ClientOptions).SetDialer, testDialer{Num: 12345}, "Dialer", true},
 			{"HeartbeatInterval", (*ClientOptions).SetHeartbeatInterval, 5 * time.Second, "HeartbeatInterval", true},
 			{"Hosts", (*ClientOptions).SetHosts, []string{"localhost:27017", "localhost:27018", "localhost:27019"}, "Hosts", true},
@@ -77,6 +78,8 @@ func TestClientOptions(t *testing.T) {
 			{"MaxPoolSize", (*ClientOptions).SetMaxPoolSize, uint64(250), "MaxPoolSize", true},
 			{"MinPoolSize", (*ClientOptions).SetMinPoolSize, uint64(10), "MinPoolSize", true},
 			{"MaxConnecting", (*ClientOptions).SetMaxConnecting, uint64(10), "MaxConnecting", true},
+			{"MaxConcurrentStreamingGetMores", (*ClientOptions).SetMaxConcurrentStreamingGetMores, 4, "MaxConcurrentStreamingGetMores", true},
+			{"MaxRetryDuration", (*ClientOptions).SetMaxRetryDuration, 5 * time.Second, "MaxRetryDuration", true},
 			{"PoolMonitor", (*ClientOptions).SetPoolMonitor, &event.PoolMonitor{}, "PoolMonitor", false},
 			{"Monitor", (*ClientOptions).SetMonitor, &event.CommandMonitor{}, "Monitor", false},
 			{"ReadConcern", (*ClientOptions).SetReadConcern, readconcern.Majority(), "ReadConcern", false},
@@ -85,6 +88,7 @@ func TestClientOptions(t *testing.T) {
 			{"ReplicaSet", (*ClientOptions).SetReplicaSet, "example-replicaset", "ReplicaSet", true},
 			{"RetryWrites", (*ClientOptions).SetRetryWrites, true, "RetryWrites", true},
 			{"ServerSelectionTimeout", (*ClientOptions).SetServerSelectionTimeout, 5 * time.Second, "ServerSelectionTimeout", true},
+			{"ServerSelectionTryOnce", (*ClientOptions).SetServerSelectionTryOnce, true, "ServerSelectionTryOnce", true},
 			{"Direct", (*ClientOptions).SetDirect, true, "Direct", true},
 			{"SocketTimeout", (*ClientOptions).SetSocketTimeout, 5 * time.Second, "SocketTimeout", true},
 			{"TLSConfig", (*ClientOptions).SetTLSConfig, &tls.Config{}, "TLSConfig", false},
@@ -92,6 +96,7 @@ func TestClientOptions(t *testing.T) {
 			{"ZlibLevel", (*ClientOptions).SetZlibLevel, 6, "ZlibLevel", true},
 			{"DisableOCSPEndpointCheck", (*ClientOptions).SetDisableOCSPEndpointCheck, true, "DisableOCSPEndpointCheck", true},
 			{"LoadBalanced", (*ClientOptions).SetLoadBalanced, true, "LoadBalanced", true},
+			{"TracerProvider", (*ClientOptions).SetTracerProvider, testTracerProvider{Name: "test"}, "TracerProvider", true},
 		}
 
 		opt1, opt2, optResult := Client(), Client(), Client()
@@ -194,6 +199,42 @@ func TestClientOptions(t *testing.T) {
 				t.Errorf("Merged client options do not match. got %v; want %v", got.uri, opt1.uri)
 			}
 		})
+
+		// go-cmp only considers nil functions equal, so check the merged function by calling it.
+		t.Run("MergeClientOptions/ConnectionIdleTimeoutFunc", func(t *testing.T) {
+			opt1 := Client().SetConnectionIdleTimeoutFunc(func() time.Duration { return time.Second })
+			opt2 := Client().SetConnectionIdleTimeoutFunc(func() time.Duration { return time.Minute })
+
+			got := MergeClientOptions(opt1, opt2)
+			if got.ConnectionIdleTimeoutFunc == nil {
+				t.Fatal("expected ConnectionIdleTimeoutFunc to be set")
+			}
+			if d := got.ConnectionIdleTimeoutFunc(); d != time.Minute {
+				t.Errorf("Merged client options do not match. got %v; want %v", d, time.Minute)
+			}
+
+			got = MergeClientOptions(opt1, Client())
+			if d := got.ConnectionIdleTimeoutFunc(); d != time.Second {
+				t.Errorf("Merged client options do not match. got %v; want %v", d, time.Second)
+			}
+		})
+		t.Run("MergeClientOptions/RequestIDFunc", func(t *testing.T) {
+			opt1 := Client().SetRequestIDFunc(func() string { return "foo" })
+			opt2 := Client().SetRequestIDFunc(func() string { return "bar" })
+
+			got := MergeClientOptions(opt1, opt2)
+			if got.RequestIDFunc == nil {
+				t.Fatal("expected RequestIDFunc to be set")
+			}
+			if id := got.RequestIDFunc(); id != "bar" {
+				t.Errorf("Merged client options do not match. got %v; want %v", id, "bar")
+			}
+
+			got = MergeClientOptions(opt1, Client())
+			if id := got.RequestIDFunc(); id != "foo" {
+				t.Errorf("Merged client options do not match. got %v; want %v", id, "foo")
+			}
+		})
 	})
 	t.Run("ApplyURI", func(t *testing.T) {
 		baseClient := func() *ClientOptions {
@@ -613,6 +654,42 @@ func TestClientOptions(t *testing.T) {
 			})
 		}
 	})
+	t.Run("SetLoggerWriter", func(t *testing.T) {
+		t.Run("sets all component levels", func(t *testing.T) {
+			opts := Client().SetLoggerWriter(new(bytes.Buffer), LogLevelInfo)
+
+			assert.NotNil(t, opts.LoggerOptions, "expected LoggerOptions to be set")
+			assert.NotNil(t, opts.LoggerOptions.Sink, "expected Sink to be set")
+
+			want := map[LogComponent]LogLevel{
+				LogComponentCommand:         LogLevelInfo,
+				LogComponentTopology:        LogLevelInfo,
+				LogComponentServerSelection: LogLevelInfo,
+				LogComponentConnection:      LogLevelInfo,
+			}
+			assert.Equal(t, want, opts.LoggerOptions.ComponentLevels,
+				"expected component levels %v, got %v", want, opts.LoggerOptions.ComponentLevels)
+		})
+		t.Run("preserves existing component levels", func(t *testing.T) {
+			loggerOpts := Logger().
+				SetComponentLevel(LogComponentCommand, LogLevelDebug).
+				SetMaxDocumentLength(10)
+			opts := Client().
+				SetLoggerOptions(loggerOpts).
+				SetLoggerWriter(new(bytes.Buffer), LogLevelInfo)
+
+			want := map[LogComponent]LogLevel{
+				LogComponentCommand:         LogLevelDebug,
+				LogComponentTopology:        LogLevelInfo,
+				LogComponentServerSelection: LogLevelInfo,
+				LogComponentConnection:      LogLevelInfo,
+			}
+			assert.Equal(t, want, opts.LoggerOptions.ComponentLevels,
+				"expected component levels %v, got %v", want, opts.LoggerOptions.ComponentLevels)
+			assert.Equal(t, uint(10), opts.LoggerOptions.MaxDocumentLength,
+				"expected MaxDocumentLength 10, got %d", opts.LoggerOptions.MaxDocumentLength)
+		})
+	})
 	t.Run("direct connection validation", func(t *testing.T) {
 		t.Run("multiple hosts", func(t *testing.T) {
 			expectedErr := errors.New("a direct connection cannot be made if multiple hosts are specified")
@@ -704,6 +781,58 @@ func TestClientOptions(t *testing.T) {
 			})
 		}
 	})
+	t.Run("heartbeatInterval validation", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			opts *ClientOptions
+			err  error
+		}{
+			{"default", Client(), nil},
+			{"minimum", Client().SetHeartbeatInterval(MinHeartbeatInterval), nil},
+			{
+				"below minimum",
+				Client().SetHeartbeatInterval(499 * time.Millisecond),
+				errors.New("heartbeatInterval must be at least 500ms, got 499ms"),
+			},
+			{
+				"zero",
+				Client().SetHeartbeatInterval(0),
+				errors.New("heartbeatInterval must be at least 500ms, got 0s"),
+			},
+			{
+				"URI below minimum",
+				Client().ApplyURI("mongodb://localhost/?heartbeatFrequencyMS=100"),
+				errors.New("heartbeatInterval must be at least 500ms, got 100ms"),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.opts.Validate()
+				assert.Equal(t, tc.err, err, "expected error %v, got %v", tc.err, err)
+			})
+		}
+	})
+	t.Run("maxConcurrentStreamingGetMores validation", func(t *testing.T) {
+		testCases := []struct {
+			name string
+			opts *ClientOptions
+			err  error
+		}{
+			{"zero", Client().SetMaxConcurrentStreamingGetMores(0), nil},
+			{"positive", Client().SetMaxConcurrentStreamingGetMores(10), nil},
+			{
+				"negative",
+				Client().SetMaxConcurrentStreamingGetMores(-1),
+				errors.New("maxConcurrentStreamingGetMores must not be negative, got -1"),
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				err := tc.opts.Validate()
+				assert.Equal(t, tc.err, err, "expected error %v, got %v", tc.err, err)
+			})
+		}
+	})
 	t.Run("srvMaxHosts validation", func(t *testing.T) {
 		testCases := []struct {
 			name string
@@ -800,6 +929,14 @@ func (testDialer) DialContext(context.Context, string, string) (net.Conn, error)
 	return nil, nil
 }
 
+type testTracerProvider struct {
+	Name string
+}
+
+func (testTracerProvider) Tracer(string) event.Tracer {
+	return nil
+}
+
 func compareTLSConfig(cfg1, cfg2 *tls.Config) bool {
 	if cfg1 == nil && cfg2 == nil {
 		return true
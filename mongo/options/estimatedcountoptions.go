@@ -10,6 +10,17 @@ import "time"
 
 // EstimatedDocumentCountOptions represents options that can be used to configure an EstimatedDocumentCount operation.
 type EstimatedDocumentCountOptions struct {
+	// The maximum age of a cached count that can be returned instead of running the command. If the Collection
+	// instance returned a count from the server less than CacheTTL ago, that count is returned without contacting
+	// the server. This trades freshness for fewer commands, which can help applications such as dashboards that call
+	// EstimatedDocumentCount frequently. The cache is kept per Collection instance and is not shared with other
+	// Collection instances for the same namespace, including those created with Collection.Clone, so cached counts
+	// were always read with the read concern and read preference of the Collection. The Comment and MaxTime options do
+	// not affect caching. If ctx contains a session, e.g. inside a transaction or a causally consistent session, the
+	// command is always run and its result is not cached. Errors are not cached. The default value is nil, which means
+	// that the command is always run and its result is not cached.
+	CacheTTL *time.Duration
+
 	// A string or document that will be included in server logs, profiling logs, and currentOp queries to help trace
 	// the operation.  The default is nil, which means that no comment will be included in the logs.
 	Comment interface{}
@@ -28,6 +39,12 @@ func EstimatedDocumentCount() *EstimatedDocumentCountOptions {
 	return &EstimatedDocumentCountOptions{}
 }
 
+// SetCacheTTL sets the value for the CacheTTL field.
+func (eco *EstimatedDocumentCountOptions) SetCacheTTL(d time.Duration) *EstimatedDocumentCountOptions {
+	eco.CacheTTL = &d
+	return eco
+}
+
 // SetComment sets the value for the Comment field.
 func (eco *EstimatedDocumentCountOptions) SetComment(comment interface{}) *EstimatedDocumentCountOptions {
 	eco.Comment = comment
@@ -55,6 +72,9 @@ func MergeEstimatedDocumentCountOptions(opts ...*EstimatedDocumentCountOptions)
 		if opt == nil {
 			continue
 		}
+		if opt.CacheTTL != nil {
+			e.CacheTTL = opt.CacheTTL
+		}
 		if opt.Comment != nil {
 			e.Comment = opt.Comment
 		}
@@ -72,6 +72,16 @@ type InsertManyOptions struct {
 
 	// If true, no writes will be executed after one fails. The default value is true.
 	Ordered *bool
+
+	// The maximum combined size in bytes of the documents sent in a single insert command. If set, the documents are
+	// split into several insert commands that are run one after another, and the inserted IDs and write errors of
+	// all commands are combined into one result. A document that is larger than the limit on its own is sent in a
+	// separate insert command. If Ordered is true, no commands are run after one that reports a write error. The
+	// commands are not atomic as a group. If a command fails with an error other than a write error, e.g. a network
+	// error, no further commands are run and the result only contains the IDs of the documents sent by the commands
+	// that succeeded before it; documents sent by the failed command may or may not have been inserted. The default
+	// value is nil, which means that the documents are only split to stay under the server's maximum message size.
+	MaxBatchBytes *int
 }
 
 // InsertMany creates a new InsertManyOptions instance.
@@ -99,6 +109,12 @@ func (imo *InsertManyOptions) SetOrdered(b bool) *InsertManyOptions {
 	return imo
 }
 
+// SetMaxBatchBytes sets the value for the MaxBatchBytes field.
+func (imo *InsertManyOptions) SetMaxBatchBytes(n int) *InsertManyOptions {
+	imo.MaxBatchBytes = &n
+	return imo
+}
+
 // MergeInsertManyOptions combines the given InsertManyOptions instances into a single InsertManyOptions in a last one
 // wins fashion.
 //
@@ -119,6 +135,9 @@ func MergeInsertManyOptions(opts ...*InsertManyOptions) *InsertManyOptions {
 		if imo.Ordered != nil {
 			imOpts.Ordered = imo.Ordered
 		}
+		if imo.MaxBatchBytes != nil {
+			imOpts.MaxBatchBytes = imo.MaxBatchBytes
+		}
 	}
 
 	return imOpts
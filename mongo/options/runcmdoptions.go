@@ -7,6 +7,8 @@
 package options
 
 import (
+	"time"
+
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
@@ -15,6 +17,19 @@ type RunCmdOptions struct {
 	// The read preference to use for the operation. The default value is nil, which means that the primary read
 	// preference will be used.
 	ReadPreference *readpref.ReadPref
+
+	// CursorType is the type of cursor created by the command passed to Database.RunCommandCursor. The driver does
+	// not modify the command, so a command that creates a tailable cursor must request it itself, e.g. with
+	// "tailable: true" and "awaitData: true" for a find on a capped collection. Setting CursorType tells the driver how
+	// to iterate the cursor. The default is NonTailable. This option is ignored by Database.RunCommand.
+	CursorType *CursorType
+
+	// MaxAwaitTime is the maximum amount of time that the server should wait for new documents on each getMore sent
+	// by a cursor created with Database.RunCommandCursor. It is sent as the maxTimeMS field of getMore commands, which
+	// the server only allows for tailable await cursors, so Database.RunCommandCursor returns an error if it is set
+	// and CursorType is not TailableAwait. The default value is nil, which means that maxTimeMS is not sent. This
+	// option is ignored by Database.RunCommand.
+	MaxAwaitTime *time.Duration
 }
 
 // RunCmd creates a new RunCmdOptions instance.
@@ -28,6 +43,18 @@ func (rc *RunCmdOptions) SetReadPreference(rp *readpref.ReadPref) *RunCmdOptions
 	return rc
 }
 
+// SetCursorType sets value for the CursorType field.
+func (rc *RunCmdOptions) SetCursorType(ct CursorType) *RunCmdOptions {
+	rc.CursorType = &ct
+	return rc
+}
+
+// SetMaxAwaitTime sets value for the MaxAwaitTime field.
+func (rc *RunCmdOptions) SetMaxAwaitTime(d time.Duration) *RunCmdOptions {
+	rc.MaxAwaitTime = &d
+	return rc
+}
+
 // MergeRunCmdOptions combines the given RunCmdOptions instances into one *RunCmdOptions in a last-one-wins fashion.
 //
 // Deprecated: Merging options structs will not be supported in Go Driver 2.0. Users should create a
@@ -41,6 +68,12 @@ func MergeRunCmdOptions(opts ...*RunCmdOptions) *RunCmdOptions {
 		if opt.ReadPreference != nil {
 			rc.ReadPreference = opt.ReadPreference
 		}
+		if opt.CursorType != nil {
+			rc.CursorType = opt.CursorType
+		}
+		if opt.MaxAwaitTime != nil {
+			rc.MaxAwaitTime = opt.MaxAwaitTime
+		}
 	}
 
 	return rc
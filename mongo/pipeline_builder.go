@@ -0,0 +1,209 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+)
+
+// ErrChangeStreamResumeTokenRemoved is returned by ChangeStreamPipelineBuilder.Build if a stage in the pipeline
+// removes or replaces the "_id" field of change events. The "_id" field holds the resume token, so change streams
+// using such a pipeline cannot be resumed and the server returns an error for every event.
+var ErrChangeStreamResumeTokenRemoved = errors.New("change stream pipeline must not remove or replace _id")
+
+// PipelineBuilder builds an aggregation Pipeline one stage at a time.
+//
+// Example usage:
+//
+//	pipeline := mongo.NewPipelineBuilder().
+//		Match(bson.D{{"status", "A"}}).
+//		Group(bson.D{{"_id", "$cust_id"}, {"total", bson.D{{"$sum", "$amount"}}}}).
+//		Sort(bson.D{{"total", -1}}).
+//		Build()
+type PipelineBuilder struct {
+	stages Pipeline
+}
+
+// NewPipelineBuilder creates a new, empty PipelineBuilder.
+func NewPipelineBuilder() *PipelineBuilder {
+	return &PipelineBuilder{}
+}
+
+// Stage appends a stage with the given name (e.g. "$sample") and value. It can be used for stages that do not have
+// a dedicated method.
+func (pb *PipelineBuilder) Stage(name string, value interface{}) *PipelineBuilder {
+	pb.stages = append(pb.stages, bson.D{{name, value}})
+	return pb
+}
+
+// Match appends a $match stage with the given filter.
+func (pb *PipelineBuilder) Match(filter interface{}) *PipelineBuilder {
+	return pb.Stage("$match", filter)
+}
+
+// Project appends a $project stage with the given projection.
+func (pb *PipelineBuilder) Project(projection interface{}) *PipelineBuilder {
+	return pb.Stage("$project", projection)
+}
+
+// Group appends a $group stage with the given group specification, which must include an "_id" field.
+func (pb *PipelineBuilder) Group(group interface{}) *PipelineBuilder {
+	return pb.Stage("$group", group)
+}
+
+// Sort appends a $sort stage with the given sort specification.
+func (pb *PipelineBuilder) Sort(sort interface{}) *PipelineBuilder {
+	return pb.Stage("$sort", sort)
+}
+
+// Limit appends a $limit stage.
+func (pb *PipelineBuilder) Limit(limit int64) *PipelineBuilder {
+	return pb.Stage("$limit", limit)
+}
+
+// Skip appends a $skip stage.
+func (pb *PipelineBuilder) Skip(skip int64) *PipelineBuilder {
+	return pb.Stage("$skip", skip)
+}
+
+// Unwind appends a $unwind stage for the given field path (e.g. "$sizes").
+func (pb *PipelineBuilder) Unwind(path string) *PipelineBuilder {
+	return pb.Stage("$unwind", path)
+}
+
+// AddFields appends an $addFields stage with the given fields.
+func (pb *PipelineBuilder) AddFields(fields interface{}) *PipelineBuilder {
+	return pb.Stage("$addFields", fields)
+}
+
+// Lookup appends a $lookup stage that performs an equality match between localField and foreignField in the from
+// collection and stores the matching documents in the as field.
+func (pb *PipelineBuilder) Lookup(from, localField, foreignField, as string) *PipelineBuilder {
+	return pb.Stage("$lookup", bson.D{
+		{"from", from},
+		{"localField", localField},
+		{"foreignField", foreignField},
+		{"as", as},
+	})
+}
+
+// Count appends a $count stage that stores the number of documents in the given field.
+func (pb *PipelineBuilder) Count(field string) *PipelineBuilder {
+	return pb.Stage("$count", field)
+}
+
+// Build returns the Pipeline. The returned Pipeline does not share memory with the builder, so the builder can
+// continue to be used.
+func (pb *PipelineBuilder) Build() Pipeline {
+	pipeline := make(Pipeline, len(pb.stages))
+	copy(pipeline, pb.stages)
+	return pipeline
+}
+
+// ChangeStreamPipelineBuilder builds a Pipeline for use with the Watch functions. It only provides methods for the
+// stages that the server allows in a change stream pipeline. The $changeStream stage is always added as the first
+// stage by Watch and cannot be added through the builder.
+//
+// Build returns an error if a $project, $unset, $addFields, or $set stage removes the "_id" field, which holds the
+// resume token for each change event.
+type ChangeStreamPipelineBuilder struct {
+	pb  PipelineBuilder
+	err error
+}
+
+// NewChangeStreamPipelineBuilder creates a new, empty ChangeStreamPipelineBuilder.
+func NewChangeStreamPipelineBuilder() *ChangeStreamPipelineBuilder {
+	return &ChangeStreamPipelineBuilder{}
+}
+
+// Match appends a $match stage with the given filter.
+func (cspb *ChangeStreamPipelineBuilder) Match(filter interface{}) *ChangeStreamPipelineBuilder {
+	cspb.pb.Match(filter)
+	return cspb
+}
+
+// Project appends a $project stage with the given projection. The projection must not exclude or replace the "_id"
+// field.
+func (cspb *ChangeStreamPipelineBuilder) Project(projection interface{}) *ChangeStreamPipelineBuilder {
+	cspb.checkID("$project", projection, isExcludedProjection)
+	cspb.pb.Project(projection)
+	return cspb
+}
+
+// AddFields appends an $addFields stage with the given fields. The fields must not overwrite the "_id" field.
+func (cspb *ChangeStreamPipelineBuilder) AddFields(fields interface{}) *ChangeStreamPipelineBuilder {
+	cspb.checkID("$addFields", fields, isPresent)
+	cspb.pb.AddFields(fields)
+	return cspb
+}
+
+// Set appends a $set stage with the given fields. The fields must not overwrite the "_id" field.
+func (cspb *ChangeStreamPipelineBuilder) Set(fields interface{}) *ChangeStreamPipelineBuilder {
+	cspb.checkID("$set", fields, isPresent)
+	cspb.pb.Stage("$set", fields)
+	return cspb
+}
+
+// Unset appends an $unset stage that removes the given fields. The fields must not include "_id".
+func (cspb *ChangeStreamPipelineBuilder) Unset(fields ...string) *ChangeStreamPipelineBuilder {
+	for _, field := range fields {
+		if field == "_id" && cspb.err == nil {
+			cspb.err = fmt.Errorf("invalid $unset stage: %w", ErrChangeStreamResumeTokenRemoved)
+		}
+	}
+	cspb.pb.Stage("$unset", fields)
+	return cspb
+}
+
+// Build returns the Pipeline or the first error encountered while adding stages.
+func (cspb *ChangeStreamPipelineBuilder) Build() (Pipeline, error) {
+	if cspb.err != nil {
+		return nil, cspb.err
+	}
+	return cspb.pb.Build(), nil
+}
+
+// checkID records an error if the "_id" field of the given stage value matches the removes function.
+func (cspb *ChangeStreamPipelineBuilder) checkID(stage string, value interface{}, removes func(bson.RawValue) bool) {
+	if cspb.err != nil {
+		return
+	}
+
+	doc, err := bson.Marshal(value)
+	if err != nil {
+		cspb.err = fmt.Errorf("error marshalling %s stage: %w", stage, err)
+		return
+	}
+	id, err := bson.Raw(doc).LookupErr("_id")
+	if err != nil {
+		return
+	}
+	if removes(id) {
+		cspb.err = fmt.Errorf("invalid %s stage: %w", stage, ErrChangeStreamResumeTokenRemoved)
+	}
+}
+
+// isExcludedProjection returns true unless the given projection value includes the field as-is (i.e. it is true or
+// a non-zero number). Projecting "_id" to any other value, such as an expression, replaces the resume token.
+func isExcludedProjection(val bson.RawValue) bool {
+	switch val.Type {
+	case bsontype.Boolean:
+		return !val.Boolean()
+	case bsontype.Int32, bsontype.Int64, bsontype.Double:
+		return val.IsZero()
+	}
+	return true
+}
+
+// isPresent always returns true. It is used for stages where any value for "_id" would replace the resume token.
+func isPresent(bson.RawValue) bool {
+	return true
+}
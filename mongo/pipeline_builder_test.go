@@ -0,0 +1,116 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestPipelineBuilder(t *testing.T) {
+	t.Run("stages match hand-written pipeline", func(t *testing.T) {
+		got := NewPipelineBuilder().
+			Match(bson.D{{"status", "A"}}).
+			Lookup("orders", "_id", "cust_id", "orders").
+			Unwind("$orders").
+			AddFields(bson.D{{"amount", "$orders.amount"}}).
+			Group(bson.D{{"_id", "$cust_id"}, {"total", bson.D{{"$sum", "$amount"}}}}).
+			Project(bson.D{{"total", 1}}).
+			Sort(bson.D{{"total", -1}}).
+			Skip(10).
+			Limit(5).
+			Stage("$sample", bson.D{{"size", 3}}).
+			Count("n").
+			Build()
+
+		want := Pipeline{
+			{{"$match", bson.D{{"status", "A"}}}},
+			{{"$lookup", bson.D{
+				{"from", "orders"},
+				{"localField", "_id"},
+				{"foreignField", "cust_id"},
+				{"as", "orders"},
+			}}},
+			{{"$unwind", "$orders"}},
+			{{"$addFields", bson.D{{"amount", "$orders.amount"}}}},
+			{{"$group", bson.D{{"_id", "$cust_id"}, {"total", bson.D{{"$sum", "$amount"}}}}}},
+			{{"$project", bson.D{{"total", 1}}}},
+			{{"$sort", bson.D{{"total", -1}}}},
+			{{"$skip", int64(10)}},
+			{{"$limit", int64(5)}},
+			{{"$sample", bson.D{{"size", 3}}}},
+			{{"$count", "n"}},
+		}
+		assert.Equal(t, want, got, "expected pipelines to be equal")
+	})
+	t.Run("Build returns a copy", func(t *testing.T) {
+		pb := NewPipelineBuilder().Match(bson.D{{"x", 1}})
+		first := pb.Build()
+		second := pb.Limit(1).Build()
+
+		assert.Len(t, first, 1, "expected first pipeline to be unchanged")
+		assert.Len(t, second, 2, "expected second pipeline to include the $limit stage")
+	})
+	t.Run("empty", func(t *testing.T) {
+		got := NewPipelineBuilder().Build()
+		assert.Equal(t, Pipeline{}, got, "expected empty pipeline")
+	})
+}
+
+func TestChangeStreamPipelineBuilder(t *testing.T) {
+	t.Run("stages match hand-written pipeline", func(t *testing.T) {
+		got, err := NewChangeStreamPipelineBuilder().
+			Match(bson.D{{"operationType", "insert"}}).
+			Project(bson.D{{"_id", 1}, {"fullDocument", 1}}).
+			AddFields(bson.D{{"seen", true}}).
+			Set(bson.D{{"source", "cdc"}}).
+			Unset("ns", "documentKey").
+			Build()
+		assert.Nil(t, err, "Build error: %v", err)
+
+		want := Pipeline{
+			{{"$match", bson.D{{"operationType", "insert"}}}},
+			{{"$project", bson.D{{"_id", 1}, {"fullDocument", 1}}}},
+			{{"$addFields", bson.D{{"seen", true}}}},
+			{{"$set", bson.D{{"source", "cdc"}}}},
+			{{"$unset", []string{"ns", "documentKey"}}},
+		}
+		assert.Equal(t, want, got, "expected pipelines to be equal")
+	})
+
+	testCases := []struct {
+		name    string
+		builder *ChangeStreamPipelineBuilder
+	}{
+		{"$project excludes _id with 0", NewChangeStreamPipelineBuilder().Project(bson.D{{"_id", 0}})},
+		{"$project excludes _id with false", NewChangeStreamPipelineBuilder().Project(bson.M{"_id": false})},
+		{"$project replaces _id", NewChangeStreamPipelineBuilder().Project(bson.D{{"_id", "$documentKey"}})},
+		{"$addFields replaces _id", NewChangeStreamPipelineBuilder().AddFields(bson.D{{"_id", "x"}})},
+		{"$set replaces _id", NewChangeStreamPipelineBuilder().Set(bson.D{{"_id", "x"}})},
+		{"$unset removes _id", NewChangeStreamPipelineBuilder().Unset("ns", "_id")},
+		{
+			"error after valid stages",
+			NewChangeStreamPipelineBuilder().Match(bson.D{{"x", 1}}).Project(bson.D{{"_id", 0}}).Match(bson.D{}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.builder.Build()
+			assert.True(t, errors.Is(err, ErrChangeStreamResumeTokenRemoved),
+				"expected error %v, got %v", ErrChangeStreamResumeTokenRemoved, err)
+			assert.Nil(t, got, "expected nil pipeline, got %v", got)
+		})
+	}
+
+	t.Run("marshal error", func(t *testing.T) {
+		_, err := NewChangeStreamPipelineBuilder().Project(42).Build()
+		assert.NotNil(t, err, "expected error marshalling non-document projection")
+	})
+}
@@ -0,0 +1,109 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// RedactRaw returns a copy of r in which the value of every field at one of fieldPaths is replaced by replacement. It
+// can be used to remove sensitive data from change events or other documents before they are logged. r is not
+// modified.
+//
+// Field paths use dot notation, e.g. "fullDocument.address.street". When a path reaches an array, a numeric path
+// segment selects the array element with that index, and any other segment is applied to every element of the array
+// that is a document, so "fullDocument.contacts.phone" redacts the phone field of every document in the contacts
+// array. Paths that do not exist in r are ignored, and all fields that are not redacted keep their values and order.
+//
+// An error is returned if r is not a valid BSON document, a path is empty or has an empty segment, or replacement is
+// not a valid BSON value.
+func RedactRaw(r bson.Raw, fieldPaths []string, replacement bson.RawValue) (bson.Raw, error) {
+	if err := r.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+	repl := bsoncore.Value{Type: replacement.Type, Data: replacement.Value}
+	if replacement.Type == bsontype.Type(0) {
+		return nil, errors.New("invalid replacement: the replacement value must have a BSON type")
+	}
+	if err := repl.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid replacement: %w", err)
+	}
+
+	paths := make([][]string, 0, len(fieldPaths))
+	for _, fp := range fieldPaths {
+		segments := strings.Split(fp, ".")
+		for _, segment := range segments {
+			if segment == "" {
+				return nil, fmt.Errorf("invalid field path %q: field paths must not have empty segments", fp)
+			}
+		}
+		paths = append(paths, segments)
+	}
+
+	doc, err := redactDocument(nil, bsoncore.Document(r), paths, repl, false)
+	if err != nil {
+		return nil, err
+	}
+	return bson.Raw(doc), nil
+}
+
+// redactDocument appends a copy of doc to dst in which the values at paths are replaced by repl. If isArray is true,
+// doc is the body of an array, so paths whose first segment is not an index are applied to each document element.
+func redactDocument(dst []byte, doc bsoncore.Document, paths [][]string, repl bsoncore.Value,
+	isArray bool) ([]byte, error) {
+
+	elems, err := doc.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	idx, dst := bsoncore.AppendDocumentStart(dst)
+	for _, elem := range elems {
+		key := elem.Key()
+
+		replace := false
+		var childPaths [][]string
+		for _, path := range paths {
+			switch {
+			case path[0] == key && len(path) == 1:
+				replace = true
+			case path[0] == key:
+				childPaths = append(childPaths, path[1:])
+			case isArray && !isArrayIndex(path[0]):
+				// The path applies to the fields of the array element itself.
+				childPaths = append(childPaths, path)
+			}
+		}
+
+		val := elem.Value()
+		switch {
+		case replace:
+			dst = bsoncore.AppendHeader(dst, repl.Type, key)
+			dst = append(dst, repl.Data...)
+		case len(childPaths) > 0 && (val.Type == bsontype.EmbeddedDocument || val.Type == bsontype.Array):
+			dst = bsoncore.AppendHeader(dst, val.Type, key)
+			if dst, err = redactDocument(dst, val.Data, childPaths, repl, val.Type == bsontype.Array); err != nil {
+				return nil, err
+			}
+		default:
+			dst = append(dst, elem...)
+		}
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
+func isArrayIndex(segment string) bool {
+	i, err := strconv.Atoi(segment)
+	return err == nil && i >= 0
+}
@@ -0,0 +1,175 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/internal/assert"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+func TestRedactRaw(t *testing.T) {
+	t.Parallel()
+
+	event := bson.D{
+		{"_id", bson.D{{"_data", "123"}}},
+		{"operationType", "insert"},
+		{"fullDocument", bson.D{
+			{"_id", 1},
+			{"name", "Alice"},
+			{"ssn", "123-45-6789"},
+			{"address", bson.D{{"street", "1 Main St"}, {"city", "Springfield"}}},
+			{"contacts", bson.A{
+				bson.D{{"type", "home"}, {"phone", "555-0100"}},
+				"not a document",
+				bson.D{{"type", "work"}, {"phone", "555-0101"}},
+			}},
+		}},
+		{"ssn", "top-level"},
+	}
+	redacted := bson.RawValue{Type: bson.TypeString, Value: bsoncore.AppendString(nil, "REDACTED")}
+
+	testCases := []struct {
+		name  string
+		paths []string
+		want  bson.D
+	}{
+		{
+			name:  "no paths",
+			paths: nil,
+			want:  event,
+		},
+		{
+			name:  "top-level field",
+			paths: []string{"ssn"},
+			want: bson.D{
+				{"_id", bson.D{{"_data", "123"}}},
+				{"operationType", "insert"},
+				{"fullDocument", event[2].Value},
+				{"ssn", "REDACTED"},
+			},
+		},
+		{
+			name:  "nested fields",
+			paths: []string{"fullDocument.ssn", "fullDocument.address.street", "fullDocument.missing.field"},
+			want: bson.D{
+				{"_id", bson.D{{"_data", "123"}}},
+				{"operationType", "insert"},
+				{"fullDocument", bson.D{
+					{"_id", 1},
+					{"name", "Alice"},
+					{"ssn", "REDACTED"},
+					{"address", bson.D{{"street", "REDACTED"}, {"city", "Springfield"}}},
+					{"contacts", event[2].Value.(bson.D)[4].Value},
+				}},
+				{"ssn", "top-level"},
+			},
+		},
+		{
+			name:  "every array element",
+			paths: []string{"fullDocument.contacts.phone"},
+			want: bson.D{
+				{"_id", bson.D{{"_data", "123"}}},
+				{"operationType", "insert"},
+				{"fullDocument", bson.D{
+					{"_id", 1},
+					{"name", "Alice"},
+					{"ssn", "123-45-6789"},
+					{"address", bson.D{{"street", "1 Main St"}, {"city", "Springfield"}}},
+					{"contacts", bson.A{
+						bson.D{{"type", "home"}, {"phone", "REDACTED"}},
+						"not a document",
+						bson.D{{"type", "work"}, {"phone", "REDACTED"}},
+					}},
+				}},
+				{"ssn", "top-level"},
+			},
+		},
+		{
+			name:  "array index",
+			paths: []string{"fullDocument.contacts.2.phone", "fullDocument.contacts.1"},
+			want: bson.D{
+				{"_id", bson.D{{"_data", "123"}}},
+				{"operationType", "insert"},
+				{"fullDocument", bson.D{
+					{"_id", 1},
+					{"name", "Alice"},
+					{"ssn", "123-45-6789"},
+					{"address", bson.D{{"street", "1 Main St"}, {"city", "Springfield"}}},
+					{"contacts", bson.A{
+						bson.D{{"type", "home"}, {"phone", "555-0100"}},
+						"REDACTED",
+						bson.D{{"type", "work"}, {"phone", "REDACTED"}},
+					}},
+				}},
+				{"ssn", "top-level"},
+			},
+		},
+		{
+			name:  "whole subdocument",
+			paths: []string{"fullDocument"},
+			want: bson.D{
+				{"_id", bson.D{{"_data", "123"}}},
+				{"operationType", "insert"},
+				{"fullDocument", "REDACTED"},
+				{"ssn", "top-level"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			original, err := bson.Marshal(event)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			input := append(bson.Raw{}, original...)
+
+			got, err := RedactRaw(input, tc.paths, redacted)
+			assert.Nil(t, err, "RedactRaw error: %v", err)
+			want, err := bson.Marshal(tc.want)
+			assert.Nil(t, err, "Marshal error: %v", err)
+			assert.Equal(t, bson.Raw(want), got, "expected document %v, got %v", bson.Raw(want), got)
+			assert.Equal(t, bson.Raw(original), input, "expected input document to be unchanged")
+		})
+	}
+}
+
+func TestRedactRawErrors(t *testing.T) {
+	t.Parallel()
+
+	doc, err := bson.Marshal(bson.D{{"a", 1}})
+	assert.Nil(t, err, "Marshal error: %v", err)
+	null := bson.RawValue{Type: bson.TypeNull}
+
+	testCases := []struct {
+		name        string
+		doc         bson.Raw
+		paths       []string
+		replacement bson.RawValue
+		errMsg      string
+	}{
+		{"invalid document", bson.Raw{0x05, 0x00}, nil, null, "invalid document"},
+		{"empty path", doc, []string{""}, null, `invalid field path ""`},
+		{"empty segment", doc, []string{"a..b"}, null, `invalid field path "a..b"`},
+		{"missing replacement type", doc, []string{"a"}, bson.RawValue{}, "invalid replacement"},
+		{"invalid replacement", doc, []string{"a"}, bson.RawValue{Type: bson.TypeInt32}, "invalid replacement"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := RedactRaw(tc.doc, tc.paths, tc.replacement)
+			assert.ErrorContains(t, err, tc.errMsg)
+		})
+	}
+}
@@ -33,6 +33,13 @@ type BulkWriteResult struct {
 
 	// A map of operation index to the _id of each upserted document.
 	UpsertedIDs map[int64]interface{}
+
+	// The operationTime returned by the server for the last write command executed by the bulk write. This can be
+	// passed to ChangeStreamOptions.SetStartAtOperationTime to start a change stream at or after the last write.
+	// startAtOperationTime includes events at that time, so the change stream may return events of the last write
+	// command again. Skip events whose clusterTime is less than or equal to OperationTime to only see later writes. This
+	// field is nil if the server did not report an operationTime (e.g. for standalone deployments).
+	OperationTime *primitive.Timestamp
 }
 
 // InsertOneResult is the result type returned by an InsertOne operation.
@@ -0,0 +1,463 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/x/bsonx/bsoncore"
+)
+
+// ErrUnsupportedResumeTokenVersion is returned by DecodeResumeToken if the resume token was created with a format
+// version that the driver cannot decode.
+var ErrUnsupportedResumeTokenVersion = errors.New("unsupported resume token version")
+
+// ResumeTokenInfo contains the information decoded from a resume token by DecodeResumeToken.
+type ResumeTokenInfo struct {
+	// ClusterTime is the cluster time of the event. For a high water mark token, such as a postBatchResumeToken, it is
+	// the cluster time up to which the change stream has scanned the oplog.
+	ClusterTime primitive.Timestamp
+
+	// Version is the format version of the token. Servers 4.0.7 through 4.0.x create version 0 tokens, and newer
+	// servers create version 1 or version 2 tokens.
+	Version int
+
+	// HighWaterMark is true if the token is not associated with an event, such as a postBatchResumeToken returned
+	// while no events are available. It is always false for version 0 tokens.
+	HighWaterMark bool
+
+	// TxnOpIndex is the index of the event among the events with the same cluster time, such as the operations in
+	// a transaction.
+	TxnOpIndex int64
+
+	// FromInvalidate is true if the token is for an invalidate event. It is always false for version 0 tokens.
+	FromInvalidate bool
+
+	// UUID is the UUID of the collection affected by the event, or nil if the token does not contain one.
+	UUID []byte
+
+	// DocumentKey is the documentKey of the event, or nil if the token does not contain one or it cannot be decoded.
+	// The documentKey cannot be decoded if it contains values that the driver does not support, such as
+	// floating-point numbers, or if the token has a "_typeBits" field, which means that the original BSON types of
+	// the values are ambiguous.
+	DocumentKey bson.Raw
+}
+
+// DecodeResumeToken decodes the information embedded in a resume token, such as the token returned by
+// ChangeStream.ResumeToken or the _id field of a change event. This can be used to report the progress of a change
+// stream or for debugging.
+//
+// The "_data" field of the token is a hex-encoded KeyString, which is not part of the public server API and may change
+// in future server versions. An error wrapping ErrUnsupportedResumeTokenVersion is returned for tokens with an unknown
+// format version. Tokens created by servers older than 4.0.7 are not supported.
+func DecodeResumeToken(token bson.Raw) (ResumeTokenInfo, error) {
+	var info ResumeTokenInfo
+
+	dataVal, err := token.LookupErr("_data")
+	if err != nil {
+		return info, fmt.Errorf("error looking up _data field of resume token: %w", err)
+	}
+	data, ok := dataVal.StringValueOK()
+	if !ok {
+		return info, fmt.Errorf("resume token _data field has type %v, expected a hex string", dataVal.Type)
+	}
+	b, err := hex.DecodeString(data)
+	if err != nil {
+		return info, fmt.Errorf("error decoding resume token _data field: %w", err)
+	}
+
+	ks := keyStringReader{b: b}
+	if info.ClusterTime, err = ks.readTimestamp(); err != nil {
+		return info, fmt.Errorf("error decoding resume token cluster time: %w", err)
+	}
+	version, err := ks.readInt()
+	if err != nil {
+		return info, fmt.Errorf("error decoding resume token version: %w", err)
+	}
+	if version < 0 || version > 2 {
+		return info, fmt.Errorf("%w: %d", ErrUnsupportedResumeTokenVersion, version)
+	}
+	info.Version = int(version)
+
+	if info.Version >= 1 {
+		tokenType, err := ks.readInt()
+		if err != nil {
+			return info, fmt.Errorf("error decoding resume token type: %w", err)
+		}
+		info.HighWaterMark = tokenType == 0
+	}
+	if info.TxnOpIndex, err = ks.readInt(); err != nil {
+		return info, fmt.Errorf("error decoding resume token txnOpIndex: %w", err)
+	}
+	if info.Version >= 1 {
+		if info.FromInvalidate, err = ks.readBool(); err != nil {
+			return info, fmt.Errorf("error decoding resume token fromInvalidate: %w", err)
+		}
+	}
+
+	// The remaining values are the collection UUID and the documentKey, in that order for version 1 and 2 tokens and
+	// in the reverse order for version 0 tokens. Either may be absent. Version 2 tokens contain an event identifier
+	// document with the operationType and documentKey instead of the documentKey.
+	_, err = token.LookupErr("_typeBits")
+	hasTypeBits := err == nil
+	for {
+		ctype, ok := ks.peek()
+		if !ok || ctype == ksEnd {
+			break
+		}
+		switch ctype {
+		case ksBinData:
+			subtype, data, err := ks.readBinary()
+			if err != nil {
+				return info, fmt.Errorf("error decoding resume token UUID: %w", err)
+			}
+			if subtype == bsontype.BinaryUUID {
+				info.UUID = data
+			}
+		case ksObject:
+			ks.pos++
+			doc, err := ks.readDocument()
+			if err != nil {
+				// The documentKey is optional, so the token is still decoded without it. The values after it
+				// cannot be located, so decoding stops here.
+				return info, nil
+			}
+			switch {
+			case hasTypeBits:
+			case info.Version >= 2:
+				info.DocumentKey = eventIdentifierDocumentKey(doc)
+			default:
+				info.DocumentKey = doc
+			}
+		default:
+			return info, nil
+		}
+	}
+	return info, nil
+}
+
+// eventIdentifierDocumentKey returns the documentKey from the event identifier of a version 2 resume token. Events
+// that do not have a documentKey, such as DDL events, have an operationType but no documentKey in their identifier.
+func eventIdentifierDocumentKey(eventID bson.Raw) bson.Raw {
+	if _, err := eventID.LookupErr("operationType"); err != nil {
+		return eventID
+	}
+	docKey, ok := eventID.Lookup("documentKey").DocumentOK()
+	if !ok {
+		return nil
+	}
+	return docKey
+}
+
+// KeyString type bytes. See https://github.com/mongodb/mongo/blob/master/src/mongo/db/storage/key_string.cpp.
+const (
+	ksEnd                           byte = 4
+	ksMinKey                        byte = 10
+	ksUndefined                     byte = 15
+	ksNull                          byte = 20
+	ksNumericNegative8ByteInt       byte = 32
+	ksNumericNegative1ByteInt       byte = 39
+	ksNumericZero                   byte = 41
+	ksNumericPositive1ByteInt       byte = 43
+	ksNumericPositive8ByteInt       byte = 50
+	ksNumericPositiveLargeMagnitude byte = 51
+	ksString                        byte = 60
+	ksObject                        byte = 70
+	ksArray                         byte = 80
+	ksBinData                       byte = 90
+	ksOID                           byte = 100
+	ksBoolFalse                     byte = 110
+	ksBoolTrue                      byte = 111
+	ksDate                          byte = 120
+	ksTimestamp                     byte = 130
+	ksMaxKey                        byte = 240
+)
+
+// keyStringReader decodes the values in a KeyString encoded with ascending order. It only supports the value types
+// that can be decoded without the KeyString's type bits.
+type keyStringReader struct {
+	b   []byte
+	pos int
+}
+
+func (r *keyStringReader) peek() (byte, bool) {
+	if r.pos >= len(r.b) {
+		return 0, false
+	}
+	return r.b[r.pos], true
+}
+
+func (r *keyStringReader) readByte() (byte, error) {
+	c, ok := r.peek()
+	if !ok {
+		return 0, errors.New("unexpected end of KeyString")
+	}
+	r.pos++
+	return c, nil
+}
+
+func (r *keyStringReader) readBytes(n int) ([]byte, error) {
+	if n < 0 || len(r.b)-r.pos < n {
+		return nil, errors.New("unexpected end of KeyString")
+	}
+	b := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *keyStringReader) readType(expected byte) error {
+	ctype, err := r.readByte()
+	if err != nil {
+		return err
+	}
+	if ctype != expected {
+		return fmt.Errorf("unexpected KeyString type %d, expected %d", ctype, expected)
+	}
+	return nil
+}
+
+func (r *keyStringReader) readTimestamp() (primitive.Timestamp, error) {
+	if err := r.readType(ksTimestamp); err != nil {
+		return primitive.Timestamp{}, err
+	}
+	return r.readTimestampValue()
+}
+
+func (r *keyStringReader) readTimestampValue() (primitive.Timestamp, error) {
+	b, err := r.readBytes(8)
+	if err != nil {
+		return primitive.Timestamp{}, err
+	}
+	return primitive.Timestamp{T: binary.BigEndian.Uint32(b[:4]), I: binary.BigEndian.Uint32(b[4:])}, nil
+}
+
+func (r *keyStringReader) readInt() (int64, error) {
+	ctype, err := r.readByte()
+	if err != nil {
+		return 0, err
+	}
+	return r.readIntValue(ctype)
+}
+
+// readIntValue reads an integer with the given numeric type byte. Integers are stored as their magnitude shifted
+// left by one bit in the fewest big-endian bytes possible, with the bytes inverted for negative numbers. The low bit
+// is set if the number has a fractional part, which is not supported.
+func (r *keyStringReader) readIntValue(ctype byte) (int64, error) {
+	var n int
+	var negative bool
+	switch {
+	case ctype == ksNumericZero:
+		return 0, nil
+	case ctype >= ksNumericPositive1ByteInt && ctype <= ksNumericPositive8ByteInt:
+		n = int(ctype-ksNumericPositive1ByteInt) + 1
+	case ctype >= ksNumericNegative8ByteInt && ctype <= ksNumericNegative1ByteInt:
+		n = int(ksNumericNegative1ByteInt-ctype) + 1
+		negative = true
+	default:
+		return 0, fmt.Errorf("unsupported KeyString numeric type %d", ctype)
+	}
+
+	b, err := r.readBytes(n)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, c := range b {
+		if negative {
+			c = ^c
+		}
+		v = v<<8 | uint64(c)
+	}
+	if v&1 != 0 {
+		return 0, errors.New("unsupported KeyString number with a fractional part")
+	}
+	v >>= 1
+	if negative {
+		return -int64(v), nil
+	}
+	return int64(v), nil
+}
+
+func (r *keyStringReader) readBool() (bool, error) {
+	ctype, err := r.readByte()
+	if err != nil {
+		return false, err
+	}
+	switch ctype {
+	case ksBoolFalse:
+		return false, nil
+	case ksBoolTrue:
+		return true, nil
+	}
+	return false, fmt.Errorf("unexpected KeyString type %d, expected a boolean", ctype)
+}
+
+func (r *keyStringReader) readBinary() (byte, []byte, error) {
+	if err := r.readType(ksBinData); err != nil {
+		return 0, nil, err
+	}
+	size, err := r.readByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	n := int(size)
+	if size == 0xff {
+		b, err := r.readBytes(4)
+		if err != nil {
+			return 0, nil, err
+		}
+		n = int(binary.BigEndian.Uint32(b))
+	}
+	subtype, err := r.readByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	data, err := r.readBytes(n)
+	if err != nil {
+		return 0, nil, err
+	}
+	return subtype, append([]byte(nil), data...), nil
+}
+
+// readCString reads a NUL-terminated string in which NUL bytes are escaped as 0x00 0xFF.
+func (r *keyStringReader) readCString() (string, error) {
+	var s []byte
+	for {
+		c, err := r.readByte()
+		if err != nil {
+			return "", err
+		}
+		if c != 0 {
+			s = append(s, c)
+			continue
+		}
+		if next, ok := r.peek(); !ok || next != 0xff {
+			return string(s), nil
+		}
+		r.pos++
+		s = append(s, 0)
+	}
+}
+
+// readDocument reads the elements of an object after its type byte. Each element is stored as a type byte, the field
+// name, and the value, and the object is terminated by a 0 byte.
+func (r *keyStringReader) readDocument() (bson.Raw, error) {
+	idx, doc := bsoncore.AppendDocumentStart(nil)
+	for {
+		ctype, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if ctype == 0 {
+			break
+		}
+		name, err := r.readCString()
+		if err != nil {
+			return nil, err
+		}
+		if doc, err = r.appendElement(doc, name); err != nil {
+			return nil, err
+		}
+	}
+	doc, err := bsoncore.AppendDocumentEnd(doc, idx)
+	return bson.Raw(doc), err
+}
+
+// appendElement reads the next value and appends it to dst as a BSON element with the given key.
+func (r *keyStringReader) appendElement(dst []byte, key string) ([]byte, error) {
+	ctype, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case ctype == ksMinKey:
+		return bsoncore.AppendMinKeyElement(dst, key), nil
+	case ctype == ksMaxKey:
+		return bsoncore.AppendMaxKeyElement(dst, key), nil
+	case ctype == ksUndefined:
+		return bsoncore.AppendUndefinedElement(dst, key), nil
+	case ctype == ksNull:
+		return bsoncore.AppendNullElement(dst, key), nil
+	case ctype >= ksNumericNegative8ByteInt && ctype <= ksNumericPositiveLargeMagnitude:
+		v, err := r.readIntValue(ctype)
+		if err != nil {
+			return nil, err
+		}
+		if v >= math.MinInt32 && v <= math.MaxInt32 {
+			return bsoncore.AppendInt32Element(dst, key, int32(v)), nil
+		}
+		return bsoncore.AppendInt64Element(dst, key, v), nil
+	case ctype == ksString:
+		s, err := r.readCString()
+		if err != nil {
+			return nil, err
+		}
+		return bsoncore.AppendStringElement(dst, key, s), nil
+	case ctype == ksObject:
+		doc, err := r.readDocument()
+		if err != nil {
+			return nil, err
+		}
+		return bsoncore.AppendDocumentElement(dst, key, doc), nil
+	case ctype == ksArray:
+		idx, dst := bsoncore.AppendArrayElementStart(dst, key)
+		for i := 0; ; i++ {
+			c, ok := r.peek()
+			if !ok {
+				return nil, errors.New("unexpected end of KeyString")
+			}
+			if c == 0 {
+				r.pos++
+				break
+			}
+			if dst, err = r.appendElement(dst, strconv.Itoa(i)); err != nil {
+				return nil, err
+			}
+		}
+		return bsoncore.AppendArrayEnd(dst, idx)
+	case ctype == ksBinData:
+		r.pos--
+		subtype, data, err := r.readBinary()
+		if err != nil {
+			return nil, err
+		}
+		return bsoncore.AppendBinaryElement(dst, key, subtype, data), nil
+	case ctype == ksOID:
+		b, err := r.readBytes(12)
+		if err != nil {
+			return nil, err
+		}
+		var oid primitive.ObjectID
+		copy(oid[:], b)
+		return bsoncore.AppendObjectIDElement(dst, key, oid), nil
+	case ctype == ksBoolFalse, ctype == ksBoolTrue:
+		return bsoncore.AppendBooleanElement(dst, key, ctype == ksBoolTrue), nil
+	case ctype == ksDate:
+		b, err := r.readBytes(8)
+		if err != nil {
+			return nil, err
+		}
+		// The sign bit is flipped so that negative dates sort before positive ones.
+		return bsoncore.AppendDateTimeElement(dst, key, int64(binary.BigEndian.Uint64(b)^(1<<63))), nil
+	case ctype == ksTimestamp:
+		ts, err := r.readTimestampValue()
+		if err != nil {
+			return nil, err
+		}
+		return bsoncore.AppendTimestampElement(dst, key, ts.T, ts.I), nil
+	}
+	return nil, fmt.Errorf("unsupported KeyString type %d", ctype)
+}
@@ -0,0 +1,156 @@
+// Copyright (C) MongoDB, Inc. 2023-present.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License. You may obtain
+// a copy of the License at http://www.apache.org/licenses/LICENSE-2.0
+
+package mongo
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/internal/assert"
+)
+
+func TestDecodeResumeToken(t *testing.T) {
+	token := func(t *testing.T, data interface{}, typeBits ...[]byte) bson.Raw {
+		t.Helper()
+
+		doc := bson.D{{"_data", data}}
+		if len(typeBits) > 0 {
+			doc = append(doc, bson.E{"_typeBits", primitive.Binary{Data: typeBits[0]}})
+		}
+		raw, err := bson.Marshal(doc)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return raw
+	}
+	docOf := func(t *testing.T, v interface{}) bson.Raw {
+		t.Helper()
+
+		raw, err := bson.Marshal(v)
+		assert.Nil(t, err, "Marshal error: %v", err)
+		return raw
+	}
+
+	uuid, _ := hex.DecodeString("A5093ABB38FE4B9EA67F01BB1A96D812")
+	oid, _ := primitive.ObjectIDFromHex("63515a49ee11d2c5bda8c4f8")
+	ts := primitive.Timestamp{T: 0x63515A49, I: 1}
+
+	testCases := []struct {
+		name string
+		data string
+		want ResumeTokenInfo
+	}{
+		{
+			// Resume token for an insert event from a 4.0 server.
+			"version 0 event",
+			"825C1A6E2A00000003292B0446645F6964006463515A49EE11D2C5BDA8C4F8005A1004A5093ABB38FE4B9EA67F01BB1A96D81204",
+			ResumeTokenInfo{
+				ClusterTime: primitive.Timestamp{T: 0x5C1A6E2A, I: 3},
+				TxnOpIndex:  2,
+				UUID:        uuid,
+				DocumentKey: docOf(t, bson.D{{"_id", oid}}),
+			},
+		},
+		{
+			// postBatchResumeToken from a 4.2+ server.
+			"version 1 high water mark",
+			"8263515A49000000012B0229296E04",
+			ResumeTokenInfo{ClusterTime: ts, Version: 1, HighWaterMark: true},
+		},
+		{
+			// Resume token for an insert event from a 4.2+ server.
+			"version 1 event",
+			"8263515A49000000012B022C0100296E5A1004A5093ABB38FE4B9EA67F01BB1A96D81246645F6964006463515A49EE11D2C5BDA8C4F8" +
+				"0004",
+			ResumeTokenInfo{
+				ClusterTime: ts,
+				Version:     1,
+				UUID:        uuid,
+				DocumentKey: docOf(t, bson.D{{"_id", oid}}),
+			},
+		},
+		{
+			// Resume token for an event in a transaction on a sharded collection with the shard key {region: 1, _id: 1}.
+			"version 1 compound documentKey",
+			"8263515A49000000012B022C01002B046F5A1004A5093ABB38FE4B9EA67F01BB1A96D812463C726567696F6E003C657500FF78001E" +
+				"5F69640027F50004",
+			ResumeTokenInfo{
+				ClusterTime:    ts,
+				Version:        1,
+				TxnOpIndex:     2,
+				FromInvalidate: true,
+				UUID:           uuid,
+				DocumentKey:    docOf(t, bson.D{{"region", "eu\x00x"}, {"_id", int32(-5)}}),
+			},
+		},
+		{
+			"version 1 documentKey with a double",
+			"8263515A49000000012B022C0100296E5A1004A5093ABB38FE4B9EA67F01BB1A96D812461E5F6964002B030004",
+			ResumeTokenInfo{ClusterTime: ts, Version: 1, UUID: uuid},
+		},
+		{
+			// Resume token for an insert event from a server that creates version 2 tokens.
+			"version 2 event",
+			"8263515A49000000012B042C0100296E5A1004A5093ABB38FE4B9EA67F01BB1A96D812463C6F7065726174696F6E54797065003C69" +
+				"6E736572740046646F63756D656E744B65790046645F6964006463515A49EE11D2C5BDA8C4F8000004",
+			ResumeTokenInfo{
+				ClusterTime: ts,
+				Version:     2,
+				UUID:        uuid,
+				DocumentKey: docOf(t, bson.D{{"_id", oid}}),
+			},
+		},
+		{
+			// Resume token for a create event, which has no documentKey.
+			"version 2 DDL event",
+			"8263515A49000000012B042C0100296E5A1004A5093ABB38FE4B9EA67F01BB1A96D812463C6F7065726174696F6E54797065003C63" +
+				"7265617465000004",
+			ResumeTokenInfo{ClusterTime: ts, Version: 2, UUID: uuid},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := DecodeResumeToken(token(t, tc.data))
+			assert.Nil(t, err, "DecodeResumeToken error: %v", err)
+			assert.Equal(t, tc.want, got, "expected info %v, got %v", tc.want, got)
+		})
+	}
+
+	t.Run("documentKey is not decoded with type bits", func(t *testing.T) {
+		data := "8263515A49000000012B022C0100296E5A1004A5093ABB38FE4B9EA67F01BB1A96D812461E5F6964002B020004"
+		got, err := DecodeResumeToken(token(t, data, []byte{0x02}))
+		assert.Nil(t, err, "DecodeResumeToken error: %v", err)
+		assert.Nil(t, got.DocumentKey, "expected no documentKey, got %v", got.DocumentKey)
+		assert.Equal(t, uuid, got.UUID, "expected UUID %v, got %v", uuid, got.UUID)
+	})
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := DecodeResumeToken(token(t, "8263515A49000000012B062C0100296E04"))
+		assert.True(t, errors.Is(err, ErrUnsupportedResumeTokenVersion),
+			"expected error %v, got %v", ErrUnsupportedResumeTokenVersion, err)
+		assert.ErrorContains(t, err, "unsupported resume token version: 3")
+	})
+	t.Run("errors", func(t *testing.T) {
+		testCases := []struct {
+			name  string
+			token bson.Raw
+			err   string
+		}{
+			{"missing _data", docOf(t, bson.D{{"foo", "bar"}}), "error looking up _data field"},
+			{"binary _data", token(t, primitive.Binary{Data: []byte{1}}), "expected a hex string"},
+			{"not hex", token(t, "82ZZ"), "error decoding resume token _data field"},
+			{"no cluster time", token(t, "2B02"), "error decoding resume token cluster time"},
+			{"truncated", token(t, "8263515A49000000012B022C01"), "error decoding resume token type"},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := DecodeResumeToken(tc.token)
+				assert.ErrorContains(t, err, tc.err)
+			})
+		}
+	})
+}
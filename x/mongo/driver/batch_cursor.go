@@ -51,6 +51,7 @@ type BatchCursor struct {
 	postBatchResumeToken bsoncore.Document
 	crypt                Crypt
 	serverAPI            *ServerAPIOptions
+	requestIDFn          func() string
 
 	// legacy server (< 3.2) fields
 	limit       int32
@@ -159,6 +160,7 @@ type CursorOptions struct {
 	CommandMonitor        *event.CommandMonitor
 	Crypt                 Crypt
 	ServerAPI             *ServerAPIOptions
+	RequestIDFn           func() string
 	MarshalValueEncoderFn func(io.Writer) (*bson.Encoder, error)
 }
 
@@ -182,6 +184,7 @@ func NewBatchCursor(cr CursorResponse, clientSession *session.Client, clock *ses
 		postBatchResumeToken: cr.postBatchResumeToken,
 		crypt:                opts.Crypt,
 		serverAPI:            opts.ServerAPI,
+		requestIDFn:          opts.RequestIDFn,
 		serverDescription:    cr.Desc,
 		encoderFn:            opts.MarshalValueEncoderFn,
 	}
@@ -326,6 +329,7 @@ func (bc *BatchCursor) KillCursor(ctx context.Context) error {
 		Legacy:         LegacyKillCursors,
 		CommandMonitor: bc.cmdMonitor,
 		ServerAPI:      bc.serverAPI,
+		RequestIDFn:    bc.requestIDFn,
 	}.Execute(ctx)
 }
 
@@ -426,6 +430,7 @@ func (bc *BatchCursor) getMore(ctx context.Context) {
 		CommandMonitor: bc.cmdMonitor,
 		Crypt:          bc.crypt,
 		ServerAPI:      bc.serverAPI,
+		RequestIDFn:    bc.requestIDFn,
 	}.Execute(ctx)
 
 	// Once the cursor has been drained, we can unpin the connection if one is currently pinned.
@@ -26,6 +26,20 @@ type CompressionOpts struct {
 	UncompressedSize int32
 }
 
+// NegotiateCompressor returns the compressor that a connection uses given the compressors configured on the client,
+// in order of preference, and the compressors the server reported in its hello response. It returns the first client
+// compressor that the server also supports, or an empty string if there is none.
+func NegotiateCompressor(clientCompressors, serverCompressors []string) string {
+	for _, method := range clientCompressors {
+		for _, serverMethod := range serverCompressors {
+			if method == serverMethod {
+				return method
+			}
+		}
+	}
+	return ""
+}
+
 var zstdEncoders sync.Map // map[zstd.EncoderLevel]*zstd.Encoder
 
 func getZstdEncoder(level zstd.EncoderLevel) (*zstd.Encoder, error) {
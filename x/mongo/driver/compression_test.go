@@ -62,6 +62,32 @@ func TestDecompressFailures(t *testing.T) {
 	})
 }
 
+func TestNegotiateCompressor(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		client []string
+		server []string
+		want   string
+	}{
+		{"no client compressors", nil, []string{"zstd"}, ""},
+		{"no server compressors", []string{"zstd"}, nil, ""},
+		{"no common compressor", []string{"zstd"}, []string{"snappy", "zlib"}, ""},
+		{"client order wins", []string{"zlib", "zstd"}, []string{"zstd", "zlib"}, "zlib"},
+		{"skips unsupported", []string{"snappy", "zstd"}, []string{"zlib", "zstd"}, "zstd"},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := NegotiateCompressor(tc.client, tc.server)
+			assert.Equal(t, tc.want, got, "expected compressor %q, got %q", tc.want, got)
+		})
+	}
+}
+
 func BenchmarkCompressPayload(b *testing.B) {
 	payload := func() []byte {
 		buf, err := os.ReadFile("compression.go")
@@ -58,6 +58,8 @@ const (
 	cryptMinWireVersion int32 = 8
 	// minimum wire version necessary to use read snapshots
 	readSnapshotMinWireVersion int32 = 13
+	// minimum wire version necessary to send a comment with every command
+	requestIDMinWireVersion int32 = 9
 )
 
 // RetryablePoolError is a connection pool error that can be retried while executing an operation.
@@ -304,8 +306,28 @@ type Operation struct {
 	// nil, which means that the timeout of the operation's caller will be used.
 	Timeout *time.Duration
 
+	// MaxRetryDuration is the maximum amount of time that can be spent retrying this operation, measured from the
+	// first failed attempt. Once exceeded, no further retries are attempted, even if the operation's Timeout or context
+	// deadline has not expired. The default value is nil, which means retries are only limited by the RetryMode and
+	// the operation's Timeout or context deadline.
+	MaxRetryDuration *time.Duration
+
+	// NowFn returns the current time. It is used to measure the time spent retrying and the time remaining until a
+	// Timeout context's deadline, and allows that behavior to be tested deterministically. If nil, time.Now is used.
+	NowFn func() time.Time
+
 	Logger *logger.Logger
 
+	// RequestIDFn returns an application-defined request ID that is added to the command's comment so the command
+	// can be correlated with the request that caused it. It is called once per call to Execute, so retries of the
+	// command use the same request ID. The request ID is only added for server versions 4.4 and above, which accept a
+	// comment on all commands. If the command already has a comment, the comment becomes
+	// {requestId: <request ID>, comment: <comment>}. Otherwise, the comment is {requestId: <request ID>}.
+	RequestIDFn func() string
+
+	// requestID is the result of RequestIDFn for the current call to Execute.
+	requestID string
+
 	// cmdName is only set when serializing OP_MSG and is used internally in readWireMessage.
 	cmdName string
 }
@@ -415,6 +437,10 @@ func (op Operation) Execute(ctx context.Context) error {
 		return err
 	}
 
+	if op.RequestIDFn != nil {
+		op.requestID = op.RequestIDFn()
+	}
+
 	// If no deadline is set on the passed-in context, op.Timeout is set, and context is not already
 	// a Timeout context, honor op.Timeout in new Timeout context for operation execution.
 	if _, deadlineSet := ctx.Deadline(); !deadlineSet && op.Timeout != nil && !internal.IsTimeoutContext(ctx) {
@@ -471,6 +497,22 @@ func (op Operation) Execute(ctx context.Context) error {
 	first := true
 	currIndex := 0
 
+	// canRetry returns true if there are retries remaining (negative retries means retry indefinitely)
+	// and the time spent retrying since the first failed attempt has not exceeded MaxRetryDuration.
+	var firstFailure time.Time
+	canRetry := func() bool {
+		if retries == 0 {
+			return false
+		}
+		if op.MaxRetryDuration == nil {
+			return true
+		}
+		if firstFailure.IsZero() {
+			firstFailure = op.now()
+		}
+		return op.now().Sub(firstFailure) < *op.MaxRetryDuration
+	}
+
 	// resetForRetry records the error that caused the retry, decrements retries, and resets the
 	// retry loop variables to request a new server and a new connection for the next attempt.
 	resetForRetry := func(err error) {
@@ -528,7 +570,7 @@ func (op Operation) Execute(ctx context.Context) error {
 				// If the returned error is retryable and there are retries remaining (negative
 				// retries means retry indefinitely), then retry the operation. Set the server
 				// and connection to nil to request a new server and connection.
-				if rerr, ok := err.(RetryablePoolError); ok && rerr.Retryable() && retries != 0 {
+				if rerr, ok := err.(RetryablePoolError); ok && rerr.Retryable() && canRetry() {
 					resetForRetry(err)
 					continue
 				}
@@ -668,10 +710,10 @@ func (op Operation) Execute(ctx context.Context) error {
 		if ctx.Err() != nil {
 			err = ctx.Err()
 		} else if deadline, ok := ctx.Deadline(); ok {
-			if internal.IsTimeoutContext(ctx) && time.Now().Add(srvr.RTTMonitor().P90()).After(deadline) {
+			if internal.IsTimeoutContext(ctx) && op.now().Add(srvr.RTTMonitor().P90()).After(deadline) {
 				err = internal.WrapErrorf(ErrDeadlineWouldBeExceeded,
-					"remaining time %v until context deadline is less than 90th percentile RTT\n%v", time.Until(deadline), srvr.RTTMonitor().Stats())
-			} else if time.Now().Add(srvr.RTTMonitor().Min()).After(deadline) {
+					"remaining time %v until context deadline is less than 90th percentile RTT\n%v", deadline.Sub(op.now()), srvr.RTTMonitor().Stats())
+			} else if op.now().Add(srvr.RTTMonitor().Min()).After(deadline) {
 				err = context.DeadlineExceeded
 			}
 		}
@@ -724,7 +766,7 @@ func (op Operation) Execute(ctx context.Context) error {
 			// If retries are supported for the current operation on the first server description,
 			// the error is considered retryable, and there are retries remaining (negative retries
 			// means retry indefinitely), then retry the operation.
-			if retrySupported && retryableErr && retries != 0 {
+			if retrySupported && retryableErr && canRetry() {
 				if op.Client != nil && op.Client.Committing {
 					// Apply majority write concern for retries
 					op.Client.UpdateCommitTransactionWriteConcern()
@@ -821,7 +863,7 @@ func (op Operation) Execute(ctx context.Context) error {
 			// If retries are supported for the current operation on the first server description,
 			// the error is considered retryable, and there are retries remaining (negative retries
 			// means retry indefinitely), then retry the operation.
-			if retrySupported && retryableErr && retries != 0 {
+			if retrySupported && retryableErr && canRetry() {
 				if op.Client != nil && op.Client.Committing {
 					// Apply majority write concern for retries
 					op.Client.UpdateCommitTransactionWriteConcern()
@@ -1254,6 +1296,10 @@ func (op Operation) createMsgWireMessage(ctx context.Context, maxTimeMS uint64,
 // addCommandFields adds the fields for a command to the wire message in dst. This assumes that the start of the document
 // has already been added and does not add the final 0 byte.
 func (op Operation) addCommandFields(ctx context.Context, dst []byte, desc description.SelectedServer) ([]byte, error) {
+	if op.requestID != "" && desc.WireVersion != nil && desc.WireVersion.Max >= requestIDMinWireVersion {
+		op.CommandFn = op.commandWithRequestID
+	}
+
 	if !op.shouldEncrypt() {
 		return op.CommandFn(dst, desc)
 	}
@@ -1285,6 +1331,37 @@ func (op Operation) addCommandFields(ctx context.Context, dst []byte, desc descr
 	return dst, nil
 }
 
+// commandWithRequestID appends the fields for the command to dst, replacing the command's comment, if any, with a
+// comment that contains the request ID and the original comment.
+func (op Operation) commandWithRequestID(dst []byte, desc description.SelectedServer) ([]byte, error) {
+	cidx, cmd := bsoncore.AppendDocumentStart(nil)
+	cmd, err := op.CommandFn(cmd, desc)
+	if err != nil {
+		return dst, err
+	}
+	cmd, _ = bsoncore.AppendDocumentEnd(cmd, cidx)
+
+	elems, err := bsoncore.Document(cmd).Elements()
+	if err != nil {
+		return dst, err
+	}
+	var comment bsoncore.Value
+	for _, elem := range elems {
+		if elem.Key() == "comment" {
+			comment = elem.Value()
+			continue
+		}
+		dst = append(dst, elem...)
+	}
+
+	idx, dst := bsoncore.AppendDocumentElementStart(dst, "comment")
+	dst = bsoncore.AppendStringElement(dst, "requestId", op.requestID)
+	if comment.Type != 0 {
+		dst = bsoncore.AppendValueElement(dst, "comment", comment)
+	}
+	return bsoncore.AppendDocumentEnd(dst, idx)
+}
+
 // addServerAPI adds the relevant fields for server API specification to the wire message in dst.
 func (op Operation) addServerAPI(dst []byte) []byte {
 	sa := op.ServerAPI
@@ -1421,6 +1498,14 @@ func (op Operation) addClusterTime(dst []byte, desc description.SelectedServer)
 	// return bsoncore.AppendDocumentElement(dst, "$clusterTime", clusterTime)
 }
 
+// now returns the current time according to NowFn, or time.Now if NowFn is not set.
+func (op Operation) now() time.Time {
+	if op.NowFn != nil {
+		return op.NowFn()
+	}
+	return time.Now()
+}
+
 // calculateMaxTimeMS calculates the value of the 'maxTimeMS' field to potentially append
 // to the wire message based on the current context's deadline and the 90th percentile RTT
 // if the ctx is a Timeout context. If the context is not a Timeout context, it uses the
@@ -1429,7 +1514,7 @@ func (op Operation) addClusterTime(dst []byte, desc description.SelectedServer)
 func (op Operation) calculateMaxTimeMS(ctx context.Context, rtt90 time.Duration, rttStats string) (uint64, error) {
 	if internal.IsTimeoutContext(ctx) {
 		if deadline, ok := ctx.Deadline(); ok {
-			remainingTimeout := time.Until(deadline)
+			remainingTimeout := deadline.Sub(op.now())
 			maxTime := remainingTimeout - rtt90
 
 			// Always round up to the next millisecond value so we never truncate the calculated
@@ -1816,6 +1901,7 @@ func (op Operation) publishStartedEvent(ctx context.Context, info startedInforma
 			ServerConnectionID:   convertInt64PtrToInt32Ptr(info.serverConnID),
 			ServerConnectionID64: info.serverConnID,
 			ServiceID:            info.serviceID,
+			Metadata:             internal.CommandMetadata(ctx),
 		}
 		op.CommandMonitor.Started(ctx, started)
 	}
@@ -32,6 +32,7 @@ type AbortTransaction struct {
 	writeConcern  *writeconcern.WriteConcern
 	retry         *driver.RetryMode
 	serverAPI     *driver.ServerAPIOptions
+	requestIDFn   func() string
 }
 
 // NewAbortTransaction constructs and returns a new AbortTransaction.
@@ -64,6 +65,7 @@ func (at *AbortTransaction) Execute(ctx context.Context) error {
 		Selector:          at.selector,
 		WriteConcern:      at.writeConcern,
 		ServerAPI:         at.serverAPI,
+		RequestIDFn:       at.requestIDFn,
 	}.Execute(ctx)
 
 }
@@ -197,3 +199,13 @@ func (at *AbortTransaction) ServerAPI(serverAPI *driver.ServerAPIOptions) *Abort
 	at.serverAPI = serverAPI
 	return at
 }
+
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (at *AbortTransaction) RequestIDFn(fn func() string) *AbortTransaction {
+	if at == nil {
+		at = new(AbortTransaction)
+	}
+
+	at.requestIDFn = fn
+	return at
+}
@@ -45,10 +45,12 @@ type Aggregate struct {
 	writeConcern             *writeconcern.WriteConcern
 	crypt                    driver.Crypt
 	serverAPI                *driver.ServerAPIOptions
+	requestIDFn              func() string
 	let                      bsoncore.Document
 	hasOutputStage           bool
 	customOptions            map[string]bsoncore.Value
 	timeout                  *time.Duration
+	maxRetryDuration         *time.Duration
 
 	result driver.CursorResponse
 }
@@ -67,6 +69,7 @@ func (a *Aggregate) Result(opts driver.CursorOptions) (*driver.BatchCursor, erro
 
 	clock := a.clock
 	opts.ServerAPI = a.serverAPI
+	opts.RequestIDFn = a.requestIDFn
 	return driver.NewBatchCursor(a.result, clientSession, clock, opts)
 }
 
@@ -108,9 +111,11 @@ func (a *Aggregate) Execute(ctx context.Context) error {
 		Crypt:                          a.crypt,
 		MinimumWriteConcernWireVersion: 5,
 		ServerAPI:                      a.serverAPI,
+		RequestIDFn:                    a.requestIDFn,
 		IsOutputAggregate:              a.hasOutputStage,
 		MaxTime:                        a.maxTime,
 		Timeout:                        a.timeout,
+		MaxRetryDuration:               a.maxRetryDuration,
 	}.Execute(ctx)
 
 }
@@ -357,6 +362,17 @@ func (a *Aggregate) Retry(retry driver.RetryMode) *Aggregate {
 	return a
 }
 
+// MaxRetryDuration sets the maximum amount of time that can be spent retrying this operation. Once exceeded, no
+// further retries are attempted and the last error is returned.
+func (a *Aggregate) MaxRetryDuration(maxRetryDuration *time.Duration) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.maxRetryDuration = maxRetryDuration
+	return a
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (a *Aggregate) Crypt(crypt driver.Crypt) *Aggregate {
 	if a == nil {
@@ -377,6 +393,16 @@ func (a *Aggregate) ServerAPI(serverAPI *driver.ServerAPIOptions) *Aggregate {
 	return a
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (a *Aggregate) RequestIDFn(fn func() string) *Aggregate {
+	if a == nil {
+		a = new(Aggregate)
+	}
+
+	a.requestIDFn = fn
+	return a
+}
+
 // Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
 func (a *Aggregate) Let(let bsoncore.Document) *Aggregate {
 	if a == nil {
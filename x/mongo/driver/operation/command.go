@@ -36,6 +36,7 @@ type Command struct {
 	resultCursor   *driver.BatchCursor
 	crypt          driver.Crypt
 	serverAPI      *driver.ServerAPIOptions
+	requestIDFn    func() string
 	createCursor   bool
 	cursorOpts     driver.CursorOptions
 	timeout        *time.Duration
@@ -108,6 +109,7 @@ func (c *Command) Execute(ctx context.Context) error {
 		Selector:       c.selector,
 		Crypt:          c.crypt,
 		ServerAPI:      c.serverAPI,
+		RequestIDFn:    c.requestIDFn,
 		Timeout:        c.timeout,
 		Logger:         c.logger,
 	}.Execute(ctx)
@@ -213,6 +215,16 @@ func (c *Command) ServerAPI(serverAPI *driver.ServerAPIOptions) *Command {
 	return c
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (c *Command) RequestIDFn(fn func() string) *Command {
+	if c == nil {
+		c = new(Command)
+	}
+
+	c.requestIDFn = fn
+	return c
+}
+
 // Timeout sets the timeout for this operation.
 func (c *Command) Timeout(timeout *time.Duration) *Command {
 	if c == nil {
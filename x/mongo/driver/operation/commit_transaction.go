@@ -33,6 +33,7 @@ type CommitTransaction struct {
 	writeConcern  *writeconcern.WriteConcern
 	retry         *driver.RetryMode
 	serverAPI     *driver.ServerAPIOptions
+	requestIDFn   func() string
 }
 
 // NewCommitTransaction constructs and returns a new CommitTransaction.
@@ -66,6 +67,7 @@ func (ct *CommitTransaction) Execute(ctx context.Context) error {
 		Selector:          ct.selector,
 		WriteConcern:      ct.writeConcern,
 		ServerAPI:         ct.serverAPI,
+		RequestIDFn:       ct.requestIDFn,
 	}.Execute(ctx)
 
 }
@@ -199,3 +201,13 @@ func (ct *CommitTransaction) ServerAPI(serverAPI *driver.ServerAPIOptions) *Comm
 	ct.serverAPI = serverAPI
 	return ct
 }
+
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (ct *CommitTransaction) RequestIDFn(fn func() string) *CommitTransaction {
+	if ct == nil {
+		ct = new(CommitTransaction)
+	}
+
+	ct.requestIDFn = fn
+	return ct
+}
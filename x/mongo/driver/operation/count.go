@@ -24,23 +24,25 @@ import (
 
 // Count represents a count operation.
 type Count struct {
-	maxTime        *time.Duration
-	query          bsoncore.Document
-	session        *session.Client
-	clock          *session.ClusterClock
-	collection     string
-	comment        bsoncore.Value
-	monitor        *event.CommandMonitor
-	crypt          driver.Crypt
-	database       string
-	deployment     driver.Deployment
-	readConcern    *readconcern.ReadConcern
-	readPreference *readpref.ReadPref
-	selector       description.ServerSelector
-	retry          *driver.RetryMode
-	result         CountResult
-	serverAPI      *driver.ServerAPIOptions
-	timeout        *time.Duration
+	maxTime          *time.Duration
+	query            bsoncore.Document
+	session          *session.Client
+	clock            *session.ClusterClock
+	collection       string
+	comment          bsoncore.Value
+	monitor          *event.CommandMonitor
+	crypt            driver.Crypt
+	database         string
+	deployment       driver.Deployment
+	readConcern      *readconcern.ReadConcern
+	readPreference   *readpref.ReadPref
+	selector         description.ServerSelector
+	retry            *driver.RetryMode
+	result           CountResult
+	serverAPI        *driver.ServerAPIOptions
+	requestIDFn      func() string
+	timeout          *time.Duration
+	maxRetryDuration *time.Duration
 }
 
 // CountResult represents a count result returned by the server.
@@ -125,7 +127,9 @@ func (c *Count) Execute(ctx context.Context) error {
 		ReadPreference:    c.readPreference,
 		Selector:          c.selector,
 		ServerAPI:         c.serverAPI,
+		RequestIDFn:       c.requestIDFn,
 		Timeout:           c.timeout,
+		MaxRetryDuration:  c.maxRetryDuration,
 	}.Execute(ctx)
 
 	// Swallow error if NamespaceNotFound(26) is returned from aggregate on non-existent namespace
@@ -290,6 +294,17 @@ func (c *Count) Retry(retry driver.RetryMode) *Count {
 	return c
 }
 
+// MaxRetryDuration sets the maximum amount of time that can be spent retrying this operation. Once exceeded, no
+// further retries are attempted and the last error is returned.
+func (c *Count) MaxRetryDuration(maxRetryDuration *time.Duration) *Count {
+	if c == nil {
+		c = new(Count)
+	}
+
+	c.maxRetryDuration = maxRetryDuration
+	return c
+}
+
 // ServerAPI sets the server API version for this operation.
 func (c *Count) ServerAPI(serverAPI *driver.ServerAPIOptions) *Count {
 	if c == nil {
@@ -300,6 +315,16 @@ func (c *Count) ServerAPI(serverAPI *driver.ServerAPIOptions) *Count {
 	return c
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (c *Count) RequestIDFn(fn func() string) *Count {
+	if c == nil {
+		c = new(Count)
+	}
+
+	c.requestIDFn = fn
+	return c
+}
+
 // Timeout sets the timeout for this operation.
 func (c *Count) Timeout(timeout *time.Duration) *Count {
 	if c == nil {
@@ -42,6 +42,7 @@ type Create struct {
 	selector                     description.ServerSelector
 	writeConcern                 *writeconcern.WriteConcern
 	serverAPI                    *driver.ServerAPIOptions
+	requestIDFn                  func() string
 	expireAfterSeconds           *int64
 	timeSeries                   bsoncore.Document
 	encryptedFields              bsoncore.Document
@@ -77,6 +78,7 @@ func (c *Create) Execute(ctx context.Context) error {
 		Selector:          c.selector,
 		WriteConcern:      c.writeConcern,
 		ServerAPI:         c.serverAPI,
+		RequestIDFn:       c.requestIDFn,
 	}.Execute(ctx)
 
 }
@@ -361,6 +363,16 @@ func (c *Create) ServerAPI(serverAPI *driver.ServerAPIOptions) *Create {
 	return c
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (c *Create) RequestIDFn(fn func() string) *Create {
+	if c == nil {
+		c = new(Create)
+	}
+
+	c.requestIDFn = fn
+	return c
+}
+
 // ExpireAfterSeconds sets the seconds to wait before deleting old time-series data.
 func (c *Create) ExpireAfterSeconds(eas int64) *Create {
 	if c == nil {
@@ -37,6 +37,7 @@ type CreateIndexes struct {
 	writeConcern *writeconcern.WriteConcern
 	result       CreateIndexesResult
 	serverAPI    *driver.ServerAPIOptions
+	requestIDFn  func() string
 	timeout      *time.Duration
 }
 
@@ -116,6 +117,7 @@ func (ci *CreateIndexes) Execute(ctx context.Context) error {
 		Selector:          ci.selector,
 		WriteConcern:      ci.writeConcern,
 		ServerAPI:         ci.serverAPI,
+		RequestIDFn:       ci.requestIDFn,
 		Timeout:           ci.timeout,
 	}.Execute(ctx)
 
@@ -267,6 +269,16 @@ func (ci *CreateIndexes) ServerAPI(serverAPI *driver.ServerAPIOptions) *CreateIn
 	return ci
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (ci *CreateIndexes) RequestIDFn(fn func() string) *CreateIndexes {
+	if ci == nil {
+		ci = new(CreateIndexes)
+	}
+
+	ci.requestIDFn = fn
+	return ci
+}
+
 // Timeout sets the timeout for this operation.
 func (ci *CreateIndexes) Timeout(timeout *time.Duration) *CreateIndexes {
 	if ci == nil {
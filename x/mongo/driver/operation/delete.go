@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/logger"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -24,31 +25,35 @@ import (
 
 // Delete performs a delete operation
 type Delete struct {
-	comment      bsoncore.Value
-	deletes      []bsoncore.Document
-	ordered      *bool
-	session      *session.Client
-	clock        *session.ClusterClock
-	collection   string
-	monitor      *event.CommandMonitor
-	crypt        driver.Crypt
-	database     string
-	deployment   driver.Deployment
-	selector     description.ServerSelector
-	writeConcern *writeconcern.WriteConcern
-	retry        *driver.RetryMode
-	hint         *bool
-	result       DeleteResult
-	serverAPI    *driver.ServerAPIOptions
-	let          bsoncore.Document
-	timeout      *time.Duration
-	logger       *logger.Logger
+	comment          bsoncore.Value
+	deletes          []bsoncore.Document
+	ordered          *bool
+	session          *session.Client
+	clock            *session.ClusterClock
+	collection       string
+	monitor          *event.CommandMonitor
+	crypt            driver.Crypt
+	database         string
+	deployment       driver.Deployment
+	selector         description.ServerSelector
+	writeConcern     *writeconcern.WriteConcern
+	retry            *driver.RetryMode
+	hint             *bool
+	result           DeleteResult
+	serverAPI        *driver.ServerAPIOptions
+	requestIDFn      func() string
+	let              bsoncore.Document
+	timeout          *time.Duration
+	maxRetryDuration *time.Duration
+	logger           *logger.Logger
 }
 
 // DeleteResult represents a delete result returned by the server.
 type DeleteResult struct {
 	// Number of documents successfully deleted.
 	N int64
+	// The operationTime returned by the server, if any.
+	OperationTime *primitive.Timestamp
 }
 
 func buildDeleteResult(response bsoncore.Document) (DeleteResult, error) {
@@ -59,6 +64,12 @@ func buildDeleteResult(response bsoncore.Document) (DeleteResult, error) {
 	dr := DeleteResult{}
 	for _, element := range elements {
 		switch element.Key() {
+		case "operationTime":
+			t, i, ok := element.Value().TimestampOK()
+			if !ok {
+				return dr, fmt.Errorf("response field 'operationTime' is type timestamp, but received BSON type %s", element.Value().Type)
+			}
+			dr.OperationTime = &primitive.Timestamp{T: t, I: i}
 		case "n":
 			var ok bool
 			dr.N, ok = element.Value().AsInt64OK()
@@ -83,6 +94,9 @@ func (d *Delete) Result() DeleteResult { return d.result }
 func (d *Delete) processResponse(info driver.ResponseInfo) error {
 	dr, err := buildDeleteResult(info.ServerResponse)
 	d.result.N += dr.N
+	if dr.OperationTime != nil {
+		d.result.OperationTime = dr.OperationTime
+	}
 	return err
 }
 
@@ -112,7 +126,9 @@ func (d *Delete) Execute(ctx context.Context) error {
 		Selector:          d.selector,
 		WriteConcern:      d.writeConcern,
 		ServerAPI:         d.serverAPI,
+		RequestIDFn:       d.requestIDFn,
 		Timeout:           d.timeout,
+		MaxRetryDuration:  d.maxRetryDuration,
 		Logger:            d.logger,
 	}.Execute(ctx)
 
@@ -274,6 +290,17 @@ func (d *Delete) Retry(retry driver.RetryMode) *Delete {
 	return d
 }
 
+// MaxRetryDuration sets the maximum amount of time that can be spent retrying this operation. Once exceeded, no
+// further retries are attempted and the last error is returned.
+func (d *Delete) MaxRetryDuration(maxRetryDuration *time.Duration) *Delete {
+	if d == nil {
+		d = new(Delete)
+	}
+
+	d.maxRetryDuration = maxRetryDuration
+	return d
+}
+
 // Hint is a flag to indicate that the update document contains a hint. Hint is only supported by
 // servers >= 4.4. Older servers >= 3.4 will report an error for using the hint option. For servers <
 // 3.4, the driver will return an error if the hint option is used.
@@ -296,6 +323,16 @@ func (d *Delete) ServerAPI(serverAPI *driver.ServerAPIOptions) *Delete {
 	return d
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (d *Delete) RequestIDFn(fn func() string) *Delete {
+	if d == nil {
+		d = new(Delete)
+	}
+
+	d.requestIDFn = fn
+	return d
+}
+
 // Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
 func (d *Delete) Let(let bsoncore.Document) *Delete {
 	if d == nil {
@@ -23,25 +23,27 @@ import (
 
 // Distinct performs a distinct operation.
 type Distinct struct {
-	collation      bsoncore.Document
-	key            *string
-	maxTime        *time.Duration
-	query          bsoncore.Document
-	session        *session.Client
-	clock          *session.ClusterClock
-	collection     string
-	comment        bsoncore.Value
-	monitor        *event.CommandMonitor
-	crypt          driver.Crypt
-	database       string
-	deployment     driver.Deployment
-	readConcern    *readconcern.ReadConcern
-	readPreference *readpref.ReadPref
-	selector       description.ServerSelector
-	retry          *driver.RetryMode
-	result         DistinctResult
-	serverAPI      *driver.ServerAPIOptions
-	timeout        *time.Duration
+	collation        bsoncore.Document
+	key              *string
+	maxTime          *time.Duration
+	query            bsoncore.Document
+	session          *session.Client
+	clock            *session.ClusterClock
+	collection       string
+	comment          bsoncore.Value
+	monitor          *event.CommandMonitor
+	crypt            driver.Crypt
+	database         string
+	deployment       driver.Deployment
+	readConcern      *readconcern.ReadConcern
+	readPreference   *readpref.ReadPref
+	selector         description.ServerSelector
+	retry            *driver.RetryMode
+	result           DistinctResult
+	serverAPI        *driver.ServerAPIOptions
+	requestIDFn      func() string
+	timeout          *time.Duration
+	maxRetryDuration *time.Duration
 }
 
 // DistinctResult represents a distinct result returned by the server.
@@ -104,7 +106,9 @@ func (d *Distinct) Execute(ctx context.Context) error {
 		ReadPreference:    d.readPreference,
 		Selector:          d.selector,
 		ServerAPI:         d.serverAPI,
+		RequestIDFn:       d.requestIDFn,
 		Timeout:           d.timeout,
+		MaxRetryDuration:  d.maxRetryDuration,
 	}.Execute(ctx)
 
 }
@@ -290,6 +294,17 @@ func (d *Distinct) Retry(retry driver.RetryMode) *Distinct {
 	return d
 }
 
+// MaxRetryDuration sets the maximum amount of time that can be spent retrying this operation. Once exceeded, no
+// further retries are attempted and the last error is returned.
+func (d *Distinct) MaxRetryDuration(maxRetryDuration *time.Duration) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.maxRetryDuration = maxRetryDuration
+	return d
+}
+
 // ServerAPI sets the server API version for this operation.
 func (d *Distinct) ServerAPI(serverAPI *driver.ServerAPIOptions) *Distinct {
 	if d == nil {
@@ -300,6 +315,16 @@ func (d *Distinct) ServerAPI(serverAPI *driver.ServerAPIOptions) *Distinct {
 	return d
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (d *Distinct) RequestIDFn(fn func() string) *Distinct {
+	if d == nil {
+		d = new(Distinct)
+	}
+
+	d.requestIDFn = fn
+	return d
+}
+
 // Timeout sets the timeout for this operation.
 func (d *Distinct) Timeout(timeout *time.Duration) *Distinct {
 	if d == nil {
@@ -33,6 +33,7 @@ type DropCollection struct {
 	writeConcern *writeconcern.WriteConcern
 	result       DropCollectionResult
 	serverAPI    *driver.ServerAPIOptions
+	requestIDFn  func() string
 	timeout      *time.Duration
 }
 
@@ -101,6 +102,7 @@ func (dc *DropCollection) Execute(ctx context.Context) error {
 		Selector:          dc.selector,
 		WriteConcern:      dc.writeConcern,
 		ServerAPI:         dc.serverAPI,
+		RequestIDFn:       dc.requestIDFn,
 		Timeout:           dc.timeout,
 	}.Execute(ctx)
 
@@ -211,6 +213,16 @@ func (dc *DropCollection) ServerAPI(serverAPI *driver.ServerAPIOptions) *DropCol
 	return dc
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (dc *DropCollection) RequestIDFn(fn func() string) *DropCollection {
+	if dc == nil {
+		dc = new(DropCollection)
+	}
+
+	dc.requestIDFn = fn
+	return dc
+}
+
 // Timeout sets the timeout for this operation.
 func (dc *DropCollection) Timeout(timeout *time.Duration) *DropCollection {
 	if dc == nil {
@@ -29,6 +29,7 @@ type DropDatabase struct {
 	selector     description.ServerSelector
 	writeConcern *writeconcern.WriteConcern
 	serverAPI    *driver.ServerAPIOptions
+	requestIDFn  func() string
 }
 
 // NewDropDatabase constructs and returns a new DropDatabase.
@@ -53,6 +54,7 @@ func (dd *DropDatabase) Execute(ctx context.Context) error {
 		Selector:       dd.selector,
 		WriteConcern:   dd.writeConcern,
 		ServerAPI:      dd.serverAPI,
+		RequestIDFn:    dd.requestIDFn,
 	}.Execute(ctx)
 
 }
@@ -152,3 +154,13 @@ func (dd *DropDatabase) ServerAPI(serverAPI *driver.ServerAPIOptions) *DropDatab
 	dd.serverAPI = serverAPI
 	return dd
 }
+
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (dd *DropDatabase) RequestIDFn(fn func() string) *DropDatabase {
+	if dd == nil {
+		dd = new(DropDatabase)
+	}
+
+	dd.requestIDFn = fn
+	return dd
+}
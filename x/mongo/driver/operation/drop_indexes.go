@@ -35,6 +35,7 @@ type DropIndexes struct {
 	writeConcern *writeconcern.WriteConcern
 	result       DropIndexesResult
 	serverAPI    *driver.ServerAPIOptions
+	requestIDFn  func() string
 	timeout      *time.Duration
 }
 
@@ -98,6 +99,7 @@ func (di *DropIndexes) Execute(ctx context.Context) error {
 		Selector:          di.selector,
 		WriteConcern:      di.writeConcern,
 		ServerAPI:         di.serverAPI,
+		RequestIDFn:       di.requestIDFn,
 		Timeout:           di.timeout,
 	}.Execute(ctx)
 
@@ -231,6 +233,16 @@ func (di *DropIndexes) ServerAPI(serverAPI *driver.ServerAPIOptions) *DropIndexe
 	return di
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (di *DropIndexes) RequestIDFn(fn func() string) *DropIndexes {
+	if di == nil {
+		di = new(DropIndexes)
+	}
+
+	di.requestIDFn = fn
+	return di
+}
+
 // Timeout sets the timeout for this operation.
 func (di *DropIndexes) Timeout(timeout *time.Duration) *DropIndexes {
 	if di == nil {
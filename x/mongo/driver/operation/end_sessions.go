@@ -19,15 +19,16 @@ import (
 
 // EndSessions performs an endSessions operation.
 type EndSessions struct {
-	sessionIDs bsoncore.Document
-	session    *session.Client
-	clock      *session.ClusterClock
-	monitor    *event.CommandMonitor
-	crypt      driver.Crypt
-	database   string
-	deployment driver.Deployment
-	selector   description.ServerSelector
-	serverAPI  *driver.ServerAPIOptions
+	sessionIDs  bsoncore.Document
+	session     *session.Client
+	clock       *session.ClusterClock
+	monitor     *event.CommandMonitor
+	crypt       driver.Crypt
+	database    string
+	deployment  driver.Deployment
+	selector    description.ServerSelector
+	serverAPI   *driver.ServerAPIOptions
+	requestIDFn func() string
 }
 
 // NewEndSessions constructs and returns a new EndSessions.
@@ -59,6 +60,7 @@ func (es *EndSessions) Execute(ctx context.Context) error {
 		Deployment:        es.deployment,
 		Selector:          es.selector,
 		ServerAPI:         es.serverAPI,
+		RequestIDFn:       es.requestIDFn,
 	}.Execute(ctx)
 
 }
@@ -159,3 +161,13 @@ func (es *EndSessions) ServerAPI(serverAPI *driver.ServerAPIOptions) *EndSession
 	es.serverAPI = serverAPI
 	return es
 }
+
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (es *EndSessions) RequestIDFn(fn func() string) *EndSessions {
+	if es == nil {
+		es = new(EndSessions)
+	}
+
+	es.requestIDFn = fn
+	return es
+}
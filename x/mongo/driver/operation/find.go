@@ -60,7 +60,9 @@ type Find struct {
 	retry               *driver.RetryMode
 	result              driver.CursorResponse
 	serverAPI           *driver.ServerAPIOptions
+	requestIDFn         func() string
 	timeout             *time.Duration
+	maxRetryDuration    *time.Duration
 	logger              *logger.Logger
 }
 
@@ -74,6 +76,7 @@ func NewFind(filter bsoncore.Document) *Find {
 // Result returns the result of executing this operation.
 func (f *Find) Result(opts driver.CursorOptions) (*driver.BatchCursor, error) {
 	opts.ServerAPI = f.serverAPI
+	opts.RequestIDFn = f.requestIDFn
 	return driver.NewBatchCursor(f.result, f.session, f.clock, opts)
 }
 
@@ -106,7 +109,9 @@ func (f *Find) Execute(ctx context.Context) error {
 		Selector:          f.selector,
 		Legacy:            driver.LegacyFind,
 		ServerAPI:         f.serverAPI,
+		RequestIDFn:       f.requestIDFn,
 		Timeout:           f.timeout,
+		MaxRetryDuration:  f.maxRetryDuration,
 		Logger:            f.logger,
 	}.Execute(ctx)
 
@@ -530,6 +535,17 @@ func (f *Find) Retry(retry driver.RetryMode) *Find {
 	return f
 }
 
+// MaxRetryDuration sets the maximum amount of time that can be spent retrying this operation. Once exceeded, no
+// further retries are attempted and the last error is returned.
+func (f *Find) MaxRetryDuration(maxRetryDuration *time.Duration) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.maxRetryDuration = maxRetryDuration
+	return f
+}
+
 // ServerAPI sets the server API version for this operation.
 func (f *Find) ServerAPI(serverAPI *driver.ServerAPIOptions) *Find {
 	if f == nil {
@@ -540,6 +556,16 @@ func (f *Find) ServerAPI(serverAPI *driver.ServerAPIOptions) *Find {
 	return f
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (f *Find) RequestIDFn(fn func() string) *Find {
+	if f == nil {
+		f = new(Find)
+	}
+
+	f.requestIDFn = fn
+	return f
+}
+
 // Timeout sets the timeout for this operation.
 func (f *Find) Timeout(timeout *time.Duration) *Find {
 	if f == nil {
@@ -48,8 +48,10 @@ type FindAndModify struct {
 	crypt                    driver.Crypt
 	hint                     bsoncore.Value
 	serverAPI                *driver.ServerAPIOptions
+	requestIDFn              func() string
 	let                      bsoncore.Document
 	timeout                  *time.Duration
+	maxRetryDuration         *time.Duration
 
 	result FindAndModifyResult
 }
@@ -130,19 +132,21 @@ func (fam *FindAndModify) Execute(ctx context.Context) error {
 		CommandFn:         fam.command,
 		ProcessResponseFn: fam.processResponse,
 
-		RetryMode:      fam.retry,
-		Type:           driver.Write,
-		Client:         fam.session,
-		Clock:          fam.clock,
-		CommandMonitor: fam.monitor,
-		Database:       fam.database,
-		Deployment:     fam.deployment,
-		MaxTime:        fam.maxTime,
-		Selector:       fam.selector,
-		WriteConcern:   fam.writeConcern,
-		Crypt:          fam.crypt,
-		ServerAPI:      fam.serverAPI,
-		Timeout:        fam.timeout,
+		RetryMode:        fam.retry,
+		Type:             driver.Write,
+		Client:           fam.session,
+		Clock:            fam.clock,
+		CommandMonitor:   fam.monitor,
+		Database:         fam.database,
+		Deployment:       fam.deployment,
+		MaxTime:          fam.maxTime,
+		Selector:         fam.selector,
+		WriteConcern:     fam.writeConcern,
+		Crypt:            fam.crypt,
+		ServerAPI:        fam.serverAPI,
+		RequestIDFn:      fam.requestIDFn,
+		Timeout:          fam.timeout,
+		MaxRetryDuration: fam.maxRetryDuration,
 	}.Execute(ctx)
 
 }
@@ -426,6 +430,17 @@ func (fam *FindAndModify) Retry(retry driver.RetryMode) *FindAndModify {
 	return fam
 }
 
+// MaxRetryDuration sets the maximum amount of time that can be spent retrying this operation. Once exceeded, no
+// further retries are attempted and the last error is returned.
+func (fam *FindAndModify) MaxRetryDuration(maxRetryDuration *time.Duration) *FindAndModify {
+	if fam == nil {
+		fam = new(FindAndModify)
+	}
+
+	fam.maxRetryDuration = maxRetryDuration
+	return fam
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (fam *FindAndModify) Crypt(crypt driver.Crypt) *FindAndModify {
 	if fam == nil {
@@ -456,6 +471,16 @@ func (fam *FindAndModify) ServerAPI(serverAPI *driver.ServerAPIOptions) *FindAnd
 	return fam
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (fam *FindAndModify) RequestIDFn(fn func() string) *FindAndModify {
+	if fam == nil {
+		fam = new(FindAndModify)
+	}
+
+	fam.requestIDFn = fn
+	return fam
+}
+
 // Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
 func (fam *FindAndModify) Let(let bsoncore.Document) *FindAndModify {
 	if fam == nil {
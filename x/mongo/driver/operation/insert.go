@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/logger"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -40,7 +41,9 @@ type Insert struct {
 	retry                    *driver.RetryMode
 	result                   InsertResult
 	serverAPI                *driver.ServerAPIOptions
+	requestIDFn              func() string
 	timeout                  *time.Duration
+	maxRetryDuration         *time.Duration
 	logger                   *logger.Logger
 }
 
@@ -48,6 +51,8 @@ type Insert struct {
 type InsertResult struct {
 	// Number of documents successfully inserted.
 	N int64
+	// The operationTime returned by the server, if any.
+	OperationTime *primitive.Timestamp
 }
 
 func buildInsertResult(response bsoncore.Document) (InsertResult, error) {
@@ -58,6 +63,12 @@ func buildInsertResult(response bsoncore.Document) (InsertResult, error) {
 	ir := InsertResult{}
 	for _, element := range elements {
 		switch element.Key() {
+		case "operationTime":
+			t, i, ok := element.Value().TimestampOK()
+			if !ok {
+				return ir, fmt.Errorf("response field 'operationTime' is type timestamp, but received BSON type %s", element.Value().Type)
+			}
+			ir.OperationTime = &primitive.Timestamp{T: t, I: i}
 		case "n":
 			var ok bool
 			ir.N, ok = element.Value().AsInt64OK()
@@ -82,6 +93,9 @@ func (i *Insert) Result() InsertResult { return i.result }
 func (i *Insert) processResponse(info driver.ResponseInfo) error {
 	ir, err := buildInsertResult(info.ServerResponse)
 	i.result.N += ir.N
+	if ir.OperationTime != nil {
+		i.result.OperationTime = ir.OperationTime
+	}
 	return err
 }
 
@@ -111,7 +125,9 @@ func (i *Insert) Execute(ctx context.Context) error {
 		Selector:          i.selector,
 		WriteConcern:      i.writeConcern,
 		ServerAPI:         i.serverAPI,
+		RequestIDFn:       i.requestIDFn,
 		Timeout:           i.timeout,
+		MaxRetryDuration:  i.maxRetryDuration,
 		Logger:            i.logger,
 	}.Execute(ctx)
 
@@ -275,6 +291,17 @@ func (i *Insert) Retry(retry driver.RetryMode) *Insert {
 	return i
 }
 
+// MaxRetryDuration sets the maximum amount of time that can be spent retrying this operation. Once exceeded, no
+// further retries are attempted and the last error is returned.
+func (i *Insert) MaxRetryDuration(maxRetryDuration *time.Duration) *Insert {
+	if i == nil {
+		i = new(Insert)
+	}
+
+	i.maxRetryDuration = maxRetryDuration
+	return i
+}
+
 // ServerAPI sets the server API version for this operation.
 func (i *Insert) ServerAPI(serverAPI *driver.ServerAPIOptions) *Insert {
 	if i == nil {
@@ -285,6 +312,16 @@ func (i *Insert) ServerAPI(serverAPI *driver.ServerAPIOptions) *Insert {
 	return i
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (i *Insert) RequestIDFn(fn func() string) *Insert {
+	if i == nil {
+		i = new(Insert)
+	}
+
+	i.requestIDFn = fn
+	return i
+}
+
 // Timeout sets the timeout for this operation.
 func (i *Insert) Timeout(timeout *time.Duration) *Insert {
 	if i == nil {
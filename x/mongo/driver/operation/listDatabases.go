@@ -36,7 +36,9 @@ type ListDatabases struct {
 	selector            description.ServerSelector
 	crypt               driver.Crypt
 	serverAPI           *driver.ServerAPIOptions
+	requestIDFn         func() string
 	timeout             *time.Duration
+	maxRetryDuration    *time.Duration
 
 	result ListDatabasesResult
 }
@@ -151,18 +153,20 @@ func (ld *ListDatabases) Execute(ctx context.Context) error {
 		CommandFn:         ld.command,
 		ProcessResponseFn: ld.processResponse,
 
-		Client:         ld.session,
-		Clock:          ld.clock,
-		CommandMonitor: ld.monitor,
-		Database:       ld.database,
-		Deployment:     ld.deployment,
-		ReadPreference: ld.readPreference,
-		RetryMode:      ld.retry,
-		Type:           driver.Read,
-		Selector:       ld.selector,
-		Crypt:          ld.crypt,
-		ServerAPI:      ld.serverAPI,
-		Timeout:        ld.timeout,
+		Client:           ld.session,
+		Clock:            ld.clock,
+		CommandMonitor:   ld.monitor,
+		Database:         ld.database,
+		Deployment:       ld.deployment,
+		ReadPreference:   ld.readPreference,
+		RetryMode:        ld.retry,
+		Type:             driver.Read,
+		Selector:         ld.selector,
+		Crypt:            ld.crypt,
+		ServerAPI:        ld.serverAPI,
+		RequestIDFn:      ld.requestIDFn,
+		Timeout:          ld.timeout,
+		MaxRetryDuration: ld.maxRetryDuration,
 	}.Execute(ctx)
 
 }
@@ -296,6 +300,17 @@ func (ld *ListDatabases) Retry(retry driver.RetryMode) *ListDatabases {
 	return ld
 }
 
+// MaxRetryDuration sets the maximum amount of time that can be spent retrying this operation. Once exceeded, no
+// further retries are attempted and the last error is returned.
+func (ld *ListDatabases) MaxRetryDuration(maxRetryDuration *time.Duration) *ListDatabases {
+	if ld == nil {
+		ld = new(ListDatabases)
+	}
+
+	ld.maxRetryDuration = maxRetryDuration
+	return ld
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (ld *ListDatabases) Crypt(crypt driver.Crypt) *ListDatabases {
 	if ld == nil {
@@ -316,6 +331,16 @@ func (ld *ListDatabases) ServerAPI(serverAPI *driver.ServerAPIOptions) *ListData
 	return ld
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (ld *ListDatabases) RequestIDFn(fn func() string) *ListDatabases {
+	if ld == nil {
+		ld = new(ListDatabases)
+	}
+
+	ld.requestIDFn = fn
+	return ld
+}
+
 // Timeout sets the timeout for this operation.
 func (ld *ListDatabases) Timeout(timeout *time.Duration) *ListDatabases {
 	if ld == nil {
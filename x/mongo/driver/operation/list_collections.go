@@ -36,7 +36,9 @@ type ListCollections struct {
 	result                driver.CursorResponse
 	batchSize             *int32
 	serverAPI             *driver.ServerAPIOptions
+	requestIDFn           func() string
 	timeout               *time.Duration
+	maxRetryDuration      *time.Duration
 }
 
 // NewListCollections constructs and returns a new ListCollections.
@@ -49,6 +51,7 @@ func NewListCollections(filter bsoncore.Document) *ListCollections {
 // Result returns the result of executing this operation.
 func (lc *ListCollections) Result(opts driver.CursorOptions) (*driver.ListCollectionsBatchCursor, error) {
 	opts.ServerAPI = lc.serverAPI
+	opts.RequestIDFn = lc.requestIDFn
 	bc, err := driver.NewBatchCursor(lc.result, lc.session, lc.clock, opts)
 	if err != nil {
 		return nil, err
@@ -87,7 +90,9 @@ func (lc *ListCollections) Execute(ctx context.Context) error {
 		Selector:          lc.selector,
 		Legacy:            driver.LegacyListCollections,
 		ServerAPI:         lc.serverAPI,
+		RequestIDFn:       lc.requestIDFn,
 		Timeout:           lc.timeout,
+		MaxRetryDuration:  lc.maxRetryDuration,
 	}.Execute(ctx)
 
 }
@@ -235,6 +240,17 @@ func (lc *ListCollections) Retry(retry driver.RetryMode) *ListCollections {
 	return lc
 }
 
+// MaxRetryDuration sets the maximum amount of time that can be spent retrying this operation. Once exceeded, no
+// further retries are attempted and the last error is returned.
+func (lc *ListCollections) MaxRetryDuration(maxRetryDuration *time.Duration) *ListCollections {
+	if lc == nil {
+		lc = new(ListCollections)
+	}
+
+	lc.maxRetryDuration = maxRetryDuration
+	return lc
+}
+
 // BatchSize specifies the number of documents to return in every batch.
 func (lc *ListCollections) BatchSize(batchSize int32) *ListCollections {
 	if lc == nil {
@@ -255,6 +271,16 @@ func (lc *ListCollections) ServerAPI(serverAPI *driver.ServerAPIOptions) *ListCo
 	return lc
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (lc *ListCollections) RequestIDFn(fn func() string) *ListCollections {
+	if lc == nil {
+		lc = new(ListCollections)
+	}
+
+	lc.requestIDFn = fn
+	return lc
+}
+
 // Timeout sets the timeout for this operation.
 func (lc *ListCollections) Timeout(timeout *time.Duration) *ListCollections {
 	if lc == nil {
@@ -20,19 +20,21 @@ import (
 
 // ListIndexes performs a listIndexes operation.
 type ListIndexes struct {
-	batchSize  *int32
-	maxTime    *time.Duration
-	session    *session.Client
-	clock      *session.ClusterClock
-	collection string
-	monitor    *event.CommandMonitor
-	database   string
-	deployment driver.Deployment
-	selector   description.ServerSelector
-	retry      *driver.RetryMode
-	crypt      driver.Crypt
-	serverAPI  *driver.ServerAPIOptions
-	timeout    *time.Duration
+	batchSize        *int32
+	maxTime          *time.Duration
+	session          *session.Client
+	clock            *session.ClusterClock
+	collection       string
+	monitor          *event.CommandMonitor
+	database         string
+	deployment       driver.Deployment
+	selector         description.ServerSelector
+	retry            *driver.RetryMode
+	crypt            driver.Crypt
+	serverAPI        *driver.ServerAPIOptions
+	requestIDFn      func() string
+	timeout          *time.Duration
+	maxRetryDuration *time.Duration
 
 	result driver.CursorResponse
 }
@@ -49,6 +51,7 @@ func (li *ListIndexes) Result(opts driver.CursorOptions) (*driver.BatchCursor, e
 
 	clock := li.clock
 	opts.ServerAPI = li.serverAPI
+	opts.RequestIDFn = li.requestIDFn
 	return driver.NewBatchCursor(li.result, clientSession, clock, opts)
 }
 
@@ -70,19 +73,21 @@ func (li *ListIndexes) Execute(ctx context.Context) error {
 		CommandFn:         li.command,
 		ProcessResponseFn: li.processResponse,
 
-		Client:         li.session,
-		Clock:          li.clock,
-		CommandMonitor: li.monitor,
-		Database:       li.database,
-		Deployment:     li.deployment,
-		MaxTime:        li.maxTime,
-		Selector:       li.selector,
-		Crypt:          li.crypt,
-		Legacy:         driver.LegacyListIndexes,
-		RetryMode:      li.retry,
-		Type:           driver.Read,
-		ServerAPI:      li.serverAPI,
-		Timeout:        li.timeout,
+		Client:           li.session,
+		Clock:            li.clock,
+		CommandMonitor:   li.monitor,
+		Database:         li.database,
+		Deployment:       li.deployment,
+		MaxTime:          li.maxTime,
+		Selector:         li.selector,
+		Crypt:            li.crypt,
+		Legacy:           driver.LegacyListIndexes,
+		RetryMode:        li.retry,
+		Type:             driver.Read,
+		ServerAPI:        li.serverAPI,
+		RequestIDFn:      li.requestIDFn,
+		Timeout:          li.timeout,
+		MaxRetryDuration: li.maxRetryDuration,
 	}.Execute(ctx)
 
 }
@@ -202,6 +207,17 @@ func (li *ListIndexes) Retry(retry driver.RetryMode) *ListIndexes {
 	return li
 }
 
+// MaxRetryDuration sets the maximum amount of time that can be spent retrying this operation. Once exceeded, no
+// further retries are attempted and the last error is returned.
+func (li *ListIndexes) MaxRetryDuration(maxRetryDuration *time.Duration) *ListIndexes {
+	if li == nil {
+		li = new(ListIndexes)
+	}
+
+	li.maxRetryDuration = maxRetryDuration
+	return li
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (li *ListIndexes) Crypt(crypt driver.Crypt) *ListIndexes {
 	if li == nil {
@@ -222,6 +238,16 @@ func (li *ListIndexes) ServerAPI(serverAPI *driver.ServerAPIOptions) *ListIndexe
 	return li
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (li *ListIndexes) RequestIDFn(fn func() string) *ListIndexes {
+	if li == nil {
+		li = new(ListIndexes)
+	}
+
+	li.requestIDFn = fn
+	return li
+}
+
 // Timeout sets the timeout for this operation.
 func (li *ListIndexes) Timeout(timeout *time.Duration) *ListIndexes {
 	if li == nil {
@@ -14,6 +14,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/logger"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -43,8 +44,10 @@ type Update struct {
 	result                   UpdateResult
 	crypt                    driver.Crypt
 	serverAPI                *driver.ServerAPIOptions
+	requestIDFn              func() string
 	let                      bsoncore.Document
 	timeout                  *time.Duration
+	maxRetryDuration         *time.Duration
 	logger                   *logger.Logger
 }
 
@@ -62,6 +65,8 @@ type UpdateResult struct {
 	NModified int64
 	// Information about upserted documents.
 	Upserted []Upsert
+	// The operationTime returned by the server, if any.
+	OperationTime *primitive.Timestamp
 }
 
 func buildUpdateResult(response bsoncore.Document) (UpdateResult, error) {
@@ -72,6 +77,12 @@ func buildUpdateResult(response bsoncore.Document) (UpdateResult, error) {
 	ur := UpdateResult{}
 	for _, element := range elements {
 		switch element.Key() {
+		case "operationTime":
+			t, i, ok := element.Value().TimestampOK()
+			if !ok {
+				return ur, fmt.Errorf("response field 'operationTime' is type timestamp, but received BSON type %s", element.Value().Type)
+			}
+			ur.OperationTime = &primitive.Timestamp{T: t, I: i}
 		case "nModified":
 			var ok bool
 			ur.NModified, ok = element.Value().AsInt64OK()
@@ -127,6 +138,9 @@ func (u *Update) processResponse(info driver.ResponseInfo) error {
 
 	u.result.N += ur.N
 	u.result.NModified += ur.NModified
+	if ur.OperationTime != nil {
+		u.result.OperationTime = ur.OperationTime
+	}
 	if info.CurrentIndex > 0 {
 		for ind := range ur.Upserted {
 			ur.Upserted[ind].Index += int64(info.CurrentIndex)
@@ -163,7 +177,9 @@ func (u *Update) Execute(ctx context.Context) error {
 		WriteConcern:      u.writeConcern,
 		Crypt:             u.crypt,
 		ServerAPI:         u.serverAPI,
+		RequestIDFn:       u.requestIDFn,
 		Timeout:           u.timeout,
+		MaxRetryDuration:  u.maxRetryDuration,
 		Logger:            u.logger,
 	}.Execute(ctx)
 
@@ -363,6 +379,17 @@ func (u *Update) Retry(retry driver.RetryMode) *Update {
 	return u
 }
 
+// MaxRetryDuration sets the maximum amount of time that can be spent retrying this operation. Once exceeded, no
+// further retries are attempted and the last error is returned.
+func (u *Update) MaxRetryDuration(maxRetryDuration *time.Duration) *Update {
+	if u == nil {
+		u = new(Update)
+	}
+
+	u.maxRetryDuration = maxRetryDuration
+	return u
+}
+
 // Crypt sets the Crypt object to use for automatic encryption and decryption.
 func (u *Update) Crypt(crypt driver.Crypt) *Update {
 	if u == nil {
@@ -383,6 +410,16 @@ func (u *Update) ServerAPI(serverAPI *driver.ServerAPIOptions) *Update {
 	return u
 }
 
+// RequestIDFn sets a function that returns the request ID to add to the comment of the command.
+func (u *Update) RequestIDFn(fn func() string) *Update {
+	if u == nil {
+		u = new(Update)
+	}
+
+	u.requestIDFn = fn
+	return u
+}
+
 // Let specifies the let document to use. This option is only valid for server versions 5.0 and above.
 func (u *Update) Let(let bsoncore.Document) *Update {
 	if u == nil {
@@ -17,6 +17,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"go.mongodb.org/mongo-driver/bson/bsontype"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/uuid"
@@ -318,6 +319,14 @@ func TestOperation(t *testing.T) {
 				}
 			})
 		}
+		t.Run("uses NowFn to compute the remaining timeout", func(t *testing.T) {
+			deadline, _ := timeoutCtx.Deadline()
+			op := Operation{NowFn: func() time.Time { return deadline.Add(-1500 * time.Millisecond) }}
+
+			got, err := op.calculateMaxTimeMS(timeoutCtx, 100*time.Millisecond, "")
+			assert.Nil(t, err, "calculateMaxTimeMS error: %v", err)
+			assert.Equal(t, uint64(1400), got, "expected maxTimeMS 1400, got %v", got)
+		})
 	})
 	t.Run("updateClusterTimes", func(t *testing.T) {
 		clustertime := bsoncore.BuildDocumentFromElements(nil,
@@ -581,6 +590,139 @@ func TestOperation(t *testing.T) {
 			})
 		}
 	})
+	t.Run("addCommandFields with request ID", func(t *testing.T) {
+		ping := func(comment *bsoncore.Value) func([]byte, description.SelectedServer) ([]byte, error) {
+			return func(dst []byte, _ description.SelectedServer) ([]byte, error) {
+				dst = bsoncore.AppendInt32Element(dst, "ping", 1)
+				if comment != nil {
+					dst = bsoncore.AppendValueElement(dst, "comment", *comment)
+				}
+				return bsoncore.AppendInt32Element(dst, "x", 1), nil
+			}
+		}
+		comment := bsoncore.Value{Type: bsontype.String, Data: bsoncore.AppendString(nil, "foo")}
+
+		testCases := []struct {
+			name        string
+			requestID   string
+			comment     *bsoncore.Value
+			wireVersion int32
+			want        bsoncore.Document
+		}{
+			{
+				"no request ID",
+				"",
+				&comment,
+				9,
+				bsoncore.NewDocumentBuilder().AppendInt32("ping", 1).AppendString("comment", "foo").AppendInt32("x", 1).Build(),
+			},
+			{
+				"unsupported wire version",
+				"abc",
+				&comment,
+				8,
+				bsoncore.NewDocumentBuilder().AppendInt32("ping", 1).AppendString("comment", "foo").AppendInt32("x", 1).Build(),
+			},
+			{
+				"without comment",
+				"abc",
+				nil,
+				9,
+				bsoncore.NewDocumentBuilder().
+					AppendInt32("ping", 1).
+					AppendInt32("x", 1).
+					AppendDocument("comment", bsoncore.NewDocumentBuilder().AppendString("requestId", "abc").Build()).
+					Build(),
+			},
+			{
+				"with comment",
+				"abc",
+				&comment,
+				9,
+				bsoncore.NewDocumentBuilder().
+					AppendInt32("ping", 1).
+					AppendInt32("x", 1).
+					AppendDocument("comment", bsoncore.NewDocumentBuilder().
+						AppendString("requestId", "abc").
+						AppendString("comment", "foo").
+						Build()).
+					Build(),
+			},
+		}
+		for _, tc := range testCases {
+			tc := tc
+
+			t.Run(tc.name, func(t *testing.T) {
+				op := Operation{CommandFn: ping(tc.comment), requestID: tc.requestID}
+				desc := description.SelectedServer{
+					Server: description.Server{
+						WireVersion: &description.VersionRange{Max: tc.wireVersion},
+					},
+				}
+
+				idx, dst := bsoncore.AppendDocumentStart(nil)
+				dst, err := op.addCommandFields(context.Background(), dst, desc)
+				noerr(t, err)
+				got, err := bsoncore.AppendDocumentEnd(dst, idx)
+				noerr(t, err)
+
+				assert.Equal(t, tc.want, bsoncore.Document(got), "expected command %v, got %v", tc.want,
+					bsoncore.Document(got))
+			})
+		}
+	})
+	t.Run("command events include the server connection ID", func(t *testing.T) {
+		// Use an ID that does not fit in an int32 to check that the deprecated ServerConnectionID field is unset.
+		serverConnID := int64(1) << 33
+		conn := &mockConnection{
+			rDesc: description.Server{
+				WireVersion: &description.VersionRange{Max: 6},
+			},
+			rServerConnID: &serverConnID,
+		}
+		var started []*event.CommandStartedEvent
+		var succeeded []*event.CommandSucceededEvent
+		monitor := &event.CommandMonitor{
+			Started: func(_ context.Context, evt *event.CommandStartedEvent) {
+				started = append(started, evt)
+			},
+			Succeeded: func(_ context.Context, evt *event.CommandSucceededEvent) {
+				succeeded = append(succeeded, evt)
+			},
+		}
+		op := Operation{
+			CommandFn: func(dst []byte, desc description.SelectedServer) ([]byte, error) {
+				return bsoncore.AppendInt32Element(dst, "ping", 1), nil
+			},
+			Database:       "admin",
+			Deployment:     SingleConnectionDeployment{conn},
+			CommandMonitor: monitor,
+		}
+
+		// Run two commands on the same connection.
+		for i := 0; i < 2; i++ {
+			conn.rReadWM = createExhaustServerResponse(bsoncore.BuildDocumentFromElements(nil,
+				bsoncore.AppendInt32Element(nil, "ok", 1),
+			), false)
+			err := op.Execute(context.Background())
+			assert.Nil(t, err, "Execute error: %v", err)
+		}
+
+		assert.Equal(t, 2, len(started), "expected 2 started events, got %v", len(started))
+		assert.Equal(t, 2, len(succeeded), "expected 2 succeeded events, got %v", len(succeeded))
+		for i := range started {
+			startedID := started[i].ServerConnectionID64
+			assert.NotNil(t, startedID, "expected ServerConnectionID64 on started event %v", i)
+			assert.Equal(t, serverConnID, *startedID, "expected server connection ID %v, got %v", serverConnID,
+				*startedID)
+			assert.Nil(t, started[i].ServerConnectionID, "expected deprecated ServerConnectionID to be nil")
+
+			succeededID := succeeded[i].ServerConnectionID64
+			assert.NotNil(t, succeededID, "expected ServerConnectionID64 on succeeded event %v", i)
+			assert.Equal(t, serverConnID, *succeededID, "expected server connection ID %v, got %v", serverConnID,
+				*succeededID)
+		}
+	})
 	t.Run("ExecuteExhaust", func(t *testing.T) {
 		t.Run("errors if connection is not streaming", func(t *testing.T) {
 			conn := &mockConnection{
@@ -807,6 +949,18 @@ func (ms *mockRetryServer) RTTMonitor() RTTMonitor {
 	return &internal.ZeroRTTMonitor{}
 }
 
+// fakeClock is a clock that advances by step every time Now is called.
+type fakeClock struct {
+	now  time.Time
+	step time.Duration
+}
+
+func (fc *fakeClock) Now() time.Time {
+	now := fc.now
+	fc.now = fc.now.Add(fc.step)
+	return now
+}
+
 func TestRetry(t *testing.T) {
 	t.Run("retries multiple times with RetryContext", func(t *testing.T) {
 		d := new(mockDeployment)
@@ -838,6 +992,87 @@ func TestRetry(t *testing.T) {
 			time.Now().After(deadline),
 			"expected operation to complete only after the context deadline is exceeded")
 	})
+	t.Run("stops retrying after MaxRetryDuration", func(t *testing.T) {
+		d := new(mockDeployment)
+		ms := new(mockRetryServer)
+		d.returns.server = ms
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		retry := RetryContext
+		maxRetryDuration := 20 * time.Millisecond
+		start := time.Now()
+		err := Operation{
+			CommandFn:        func([]byte, description.SelectedServer) ([]byte, error) { return nil, nil },
+			Deployment:       d,
+			Database:         "testing",
+			RetryMode:        &retry,
+			Type:             Read,
+			MaxRetryDuration: &maxRetryDuration,
+		}.Execute(ctx)
+		elapsed := time.Since(start)
+
+		// Expect the last retryable error to be returned rather than a context error.
+		_, ok := err.(retryableError)
+		assert.True(t, ok, "expected a retryableError from Execute(), got %v", err)
+
+		assert.True(t,
+			ms.numCallsToConnection >= 2,
+			"expected Connection() to be called at least 2 times, got %d", ms.numCallsToConnection)
+		assert.True(t,
+			elapsed < time.Second,
+			"expected retries to stop shortly after MaxRetryDuration, took %v", elapsed)
+		assert.Nil(t, ctx.Err(), "expected the context deadline to not be exceeded, got %v", ctx.Err())
+	})
+	t.Run("MaxRetryDuration with fake clock", func(t *testing.T) {
+		d := new(mockDeployment)
+		ms := new(mockRetryServer)
+		d.returns.server = ms
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		// Advance the clock by 10ms every time it is read. The first retry check records the first failure at t=0
+		// and sees 10ms elapsed, the second sees 20ms, and the third sees 30ms, which exceeds MaxRetryDuration.
+		clock := &fakeClock{now: time.Unix(0, 0), step: 10 * time.Millisecond}
+		retry := RetryContext
+		maxRetryDuration := 25 * time.Millisecond
+		err := Operation{
+			CommandFn:        func([]byte, description.SelectedServer) ([]byte, error) { return nil, nil },
+			Deployment:       d,
+			Database:         "testing",
+			RetryMode:        &retry,
+			Type:             Read,
+			MaxRetryDuration: &maxRetryDuration,
+			NowFn:            clock.Now,
+		}.Execute(ctx)
+		assert.NotNil(t, err, "expected an error from Execute()")
+		assert.Equal(t, 3, ms.numCallsToConnection,
+			"expected Connection() to be called 3 times, got %d", ms.numCallsToConnection)
+	})
+	t.Run("zero MaxRetryDuration disables retries", func(t *testing.T) {
+		d := new(mockDeployment)
+		ms := new(mockRetryServer)
+		d.returns.server = ms
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		retry := RetryContext
+		maxRetryDuration := time.Duration(0)
+		err := Operation{
+			CommandFn:        func([]byte, description.SelectedServer) ([]byte, error) { return nil, nil },
+			Deployment:       d,
+			Database:         "testing",
+			RetryMode:        &retry,
+			Type:             Read,
+			MaxRetryDuration: &maxRetryDuration,
+		}.Execute(ctx)
+		assert.NotNil(t, err, "expected an error from Execute()")
+		assert.Equal(t, 1, ms.numCallsToConnection,
+			"expected Connection() to be called once, got %d", ms.numCallsToConnection)
+	})
 }
 
 func TestConvertI64PtrToI32Ptr(t *testing.T) {
@@ -55,6 +55,8 @@ type connection struct {
 	addr                 address.Address
 	idleTimeout          time.Duration
 	idleDeadline         atomic.Value // Stores a time.Time
+	idleTimeoutFn        func() time.Duration
+	idleStart            atomic.Value // Stores a time.Time
 	readTimeout          time.Duration
 	writeTimeout         time.Duration
 	desc                 description.Server
@@ -90,6 +92,7 @@ func newConnection(addr address.Address, opts ...ConnectionOption) *connection {
 		id:                   id,
 		addr:                 addr,
 		idleTimeout:          cfg.idleTimeout,
+		idleTimeoutFn:        cfg.idleTimeoutFn,
 		readTimeout:          cfg.readTimeout,
 		writeTimeout:         cfg.writeTimeout,
 		connectDone:          make(chan struct{}),
@@ -258,32 +261,20 @@ func (c *connection) connect(ctx context.Context) (err error) {
 		return ConnectionError{Wrapped: err, init: true}
 	}
 
-	if len(c.desc.Compression) > 0 {
-	clientMethodLoop:
-		for _, method := range c.config.compressors {
-			for _, serverMethod := range c.desc.Compression {
-				if method != serverMethod {
-					continue
-				}
-
-				switch strings.ToLower(method) {
-				case "snappy":
-					c.compressor = wiremessage.CompressorSnappy
-				case "zlib":
-					c.compressor = wiremessage.CompressorZLib
-					c.zliblevel = wiremessage.DefaultZlibLevel
-					if c.config.zlibLevel != nil {
-						c.zliblevel = *c.config.zlibLevel
-					}
-				case "zstd":
-					c.compressor = wiremessage.CompressorZstd
-					c.zstdLevel = wiremessage.DefaultZstdLevel
-					if c.config.zstdLevel != nil {
-						c.zstdLevel = *c.config.zstdLevel
-					}
-				}
-				break clientMethodLoop
-			}
+	switch strings.ToLower(driver.NegotiateCompressor(c.config.compressors, c.desc.Compression)) {
+	case "snappy":
+		c.compressor = wiremessage.CompressorSnappy
+	case "zlib":
+		c.compressor = wiremessage.CompressorZLib
+		c.zliblevel = wiremessage.DefaultZlibLevel
+		if c.config.zlibLevel != nil {
+			c.zliblevel = *c.config.zlibLevel
+		}
+	case "zstd":
+		c.compressor = wiremessage.CompressorZstd
+		c.zstdLevel = wiremessage.DefaultZstdLevel
+		if c.config.zstdLevel != nil {
+			c.zstdLevel = *c.config.zstdLevel
 		}
 	}
 	return nil
@@ -494,6 +485,16 @@ func (c *connection) closed() bool {
 
 func (c *connection) idleTimeoutExpired() bool {
 	now := time.Now()
+	// The idle timeout function is evaluated every time the connection is checked so that the
+	// timeout can change while the connection sits in the pool.
+	if c.idleTimeoutFn != nil {
+		idleStart, ok := c.idleStart.Load().(time.Time)
+		if !ok {
+			return false
+		}
+		timeout := c.idleTimeoutFn()
+		return timeout > 0 && now.After(idleStart.Add(timeout))
+	}
 	if c.idleTimeout > 0 {
 		idleDeadline, ok := c.idleDeadline.Load().(time.Time)
 		if ok && now.After(idleDeadline) {
@@ -505,6 +506,10 @@ func (c *connection) idleTimeoutExpired() bool {
 }
 
 func (c *connection) bumpIdleDeadline() {
+	if c.idleTimeoutFn != nil {
+		c.idleStart.Store(time.Now())
+		return
+	}
 	if c.idleTimeout > 0 {
 		c.idleDeadline.Store(time.Now().Add(c.idleTimeout))
 	}
@@ -52,6 +52,7 @@ type connectionConfig struct {
 	dialer                   Dialer
 	handshaker               Handshaker
 	idleTimeout              time.Duration
+	idleTimeoutFn            func() time.Duration
 	cmdMonitor               *event.CommandMonitor
 	readTimeout              time.Duration
 	writeTimeout             time.Duration
@@ -135,6 +136,15 @@ func WithIdleTimeout(fn func(time.Duration) time.Duration) ConnectionOption {
 	}
 }
 
+// WithIdleTimeoutFunc configures a function that returns the maximum idle time to allow for a
+// connection. The function is called each time the connection's idle time is checked and takes
+// precedence over the value configured by WithIdleTimeout.
+func WithIdleTimeoutFunc(fn func(func() time.Duration) func() time.Duration) ConnectionOption {
+	return func(c *connectionConfig) {
+		c.idleTimeoutFn = fn(c.idleTimeoutFn)
+	}
+}
+
 // WithReadTimeout configures the maximum read time for a connection.
 func WithReadTimeout(fn func(time.Duration) time.Duration) ConnectionOption {
 	return func(c *connectionConfig) {
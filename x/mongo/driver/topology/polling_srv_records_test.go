@@ -15,6 +15,7 @@ import (
 	"testing"
 	"time"
 
+	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/internal/require"
 	"go.mongodb.org/mongo-driver/mongo/address"
@@ -124,7 +125,16 @@ func compareHosts(t *testing.T, received []description.Server, expected []string
 	}
 }
 
+// allowLowHeartbeatIntervals lets the calling test use heartbeat intervals below internal.MinHeartbeatInterval so that
+// SRV records are polled quickly. The returned function restores the check.
+func allowLowHeartbeatIntervals() func() {
+	internal.AllowLowHeartbeatIntervals = true
+	return func() { internal.AllowLowHeartbeatIntervals = false }
+}
+
 func TestPollingSRVRecordsSpec(t *testing.T) {
+	defer allowLowHeartbeatIntervals()()
+
 	for _, uri := range []string{
 		"mongodb+srv://test1.test.build.10gen.cc/?heartbeatFrequencyMS=100",
 		// Test with user:pass as a regression test for GODRIVER-2620
@@ -167,6 +177,8 @@ func testPollingSRVRecordsSpec(t *testing.T, uri string) {
 }
 
 func TestPollSRVRecords(t *testing.T) {
+	defer allowLowHeartbeatIntervals()()
+
 	t.Run("Not unknown or sharded topology", func(t *testing.T) {
 		uri := "mongodb+srv://test1.test.build.10gen.cc/?heartbeatFrequencyMS=100"
 		cfg, err := NewConfig(options.Client().ApplyURI(uri), nil)
@@ -262,6 +274,8 @@ func TestPollSRVRecords(t *testing.T) {
 }
 
 func TestPollingSRVRecordsLoadBalanced(t *testing.T) {
+	defer allowLowHeartbeatIntervals()()
+
 	createLBTopology := func(t *testing.T, uri string) *Topology {
 		t.Helper()
 
@@ -307,6 +321,8 @@ func TestPollingSRVRecordsLoadBalanced(t *testing.T) {
 }
 
 func TestPollSRVRecordsMaxHosts(t *testing.T) {
+	defer allowLowHeartbeatIntervals()()
+
 	// simulateSRVPoll creates a topology with srvMaxHosts, mocks the DNS changes described by
 	// recordsToAdd and recordsToRemove, and returns the the topology.
 	simulateSRVPoll := func(srvMaxHosts int, recordsToAdd []*net.SRV, recordsToRemove []*net.SRV) (*Topology, func(ctx context.Context) error) {
@@ -379,6 +395,8 @@ func TestPollSRVRecordsMaxHosts(t *testing.T) {
 }
 
 func TestPollSRVRecordsServiceName(t *testing.T) {
+	defer allowLowHeartbeatIntervals()()
+
 	// simulateSRVPoll creates a topology with srvServiceName, mocks the DNS changes described by
 	// recordsToAdd and recordsToRemove, and returns the topology.
 	simulateSRVPoll := func(srvServiceName string, recordsToAdd []*net.SRV, recordsToRemove []*net.SRV) (*Topology, func(ctx context.Context) error) {
@@ -73,6 +73,7 @@ type poolConfig struct {
 	MaxPoolSize      uint64
 	MaxConnecting    uint64
 	MaxIdleTime      time.Duration
+	MaxIdleTimeFunc  func() time.Duration
 	MaintainInterval time.Duration
 	PoolMonitor      *event.PoolMonitor
 	Logger           *logger.Logger
@@ -190,6 +191,11 @@ func newPool(config poolConfig, connOpts ...ConnectionOption) *pool {
 	if config.MaxIdleTime != time.Duration(0) {
 		connOpts = append(connOpts, WithIdleTimeout(func(_ time.Duration) time.Duration { return config.MaxIdleTime }))
 	}
+	if config.MaxIdleTimeFunc != nil {
+		connOpts = append(connOpts, WithIdleTimeoutFunc(
+			func(func() time.Duration) func() time.Duration { return config.MaxIdleTimeFunc },
+		))
+	}
 
 	var maxConnecting uint64 = 2
 	if config.MaxConnecting > 0 {
@@ -878,11 +884,13 @@ func (p *pool) clear(err error, serviceID *primitive.ObjectID) {
 	}
 
 	if sendEvent && p.monitor != nil {
+		generation := p.generation.getGeneration(serviceID)
 		p.monitor.Event(&event.PoolEvent{
-			Type:      event.PoolCleared,
-			Address:   p.address.String(),
-			ServiceID: serviceID,
-			Error:     err,
+			Type:       event.PoolCleared,
+			Address:    p.address.String(),
+			ServiceID:  serviceID,
+			Error:      err,
+			Generation: &generation,
 		})
 	}
 }
@@ -1010,10 +1018,16 @@ func (p *pool) createConnections(ctx context.Context, wg *sync.WaitGroup) {
 		}
 
 		if p.monitor != nil {
+			var generation *uint64
+			if !conn.config.loadBalanced {
+				gen := p.generation.getGeneration(nil)
+				generation = &gen
+			}
 			p.monitor.Event(&event.PoolEvent{
 				Type:         event.ConnectionCreated,
 				Address:      p.address.String(),
 				ConnectionID: conn.driverConnectionID,
+				Generation:   generation,
 			})
 		}
 
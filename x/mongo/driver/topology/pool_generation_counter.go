@@ -89,9 +89,11 @@ func (p *poolGenerationMap) removeConnection(serviceIDPtr *primitive.ObjectID) {
 
 	// If the serviceID is being tracked, decrement the connection count and delete this serviceID to prevent the map
 	// from growing unboundedly. This case would happen if a server behind a load-balancer was permanently removed
-	// and its connections were pruned after a network error or idle timeout.
+	// and its connections were pruned after a network error or idle timeout. The entry for primitive.NilObjectID,
+	// which is used by deployments that are not behind a load balancer, is never deleted so its generation number
+	// keeps increasing across pool clears, even if all connections were closed.
 	stats.numConns--
-	if stats.numConns == 0 {
+	if stats.numConns == 0 && serviceID != primitive.NilObjectID {
 		delete(p.generationMap, serviceID)
 	}
 }
@@ -11,9 +11,11 @@ import (
 	"errors"
 	"net"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"go.mongodb.org/mongo-driver/event"
 	"go.mongodb.org/mongo-driver/internal/assert"
 	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
@@ -342,6 +344,71 @@ func TestPool(t *testing.T) {
 
 			p.close(context.Background())
 		})
+		t.Run("clear increments the generation in pool events", func(t *testing.T) {
+			t.Parallel()
+
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 2, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+
+			var mu sync.Mutex
+			var events []*event.PoolEvent
+			p := newPool(poolConfig{
+				Address: address.Address(addr.String()),
+				PoolMonitor: &event.PoolMonitor{
+					Event: func(evt *event.PoolEvent) {
+						if evt.Type == event.ConnectionCreated || evt.Type == event.PoolCleared {
+							mu.Lock()
+							events = append(events, evt)
+							mu.Unlock()
+						}
+					},
+				},
+			})
+			err := p.ready()
+			noerr(t, err)
+
+			conn, err := p.checkOut(context.Background())
+			noerr(t, err)
+			err = p.checkIn(conn)
+			noerr(t, err)
+
+			p.clear(errors.New("test error"), nil)
+			err = p.ready()
+			noerr(t, err)
+
+			conn, err = p.checkOut(context.Background())
+			noerr(t, err)
+			assert.Equalf(t, uint64(1), conn.generation, "expected connection to belong to the new generation")
+			err = p.checkIn(conn)
+			noerr(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			want := []struct {
+				typ        string
+				generation uint64
+			}{
+				{event.ConnectionCreated, 0},
+				{event.PoolCleared, 1},
+				{event.ConnectionCreated, 1},
+			}
+			assert.Equalf(t, len(want), len(events), "expected %d events, got %d", len(want), len(events))
+			for i := 0; i < len(want) && i < len(events); i++ {
+				assert.Equalf(t, want[i].typ, events[i].Type, "unexpected type for event %d", i)
+				if events[i].Generation == nil {
+					t.Errorf("expected generation for %s event %d to be set", events[i].Type, i)
+					continue
+				}
+				assert.Equalf(t, want[i].generation, *events[i].Generation,
+					"unexpected generation for %s event %d", events[i].Type, i)
+			}
+
+			p.close(context.Background())
+		})
 		t.Run("calling ready multiple times does not return an error", func(t *testing.T) {
 			t.Parallel()
 
@@ -1115,6 +1182,64 @@ func TestPool(t *testing.T) {
 			assert.Equalf(t, 3, p.availableConnectionCount(), "should be 3 idle connections in pool")
 			assert.Equalf(t, 3, p.totalConnectionCount(), "should be 3 total connection in pool")
 
+			p.close(context.Background())
+		})
+		t.Run("removes idle connections when the idle timeout function returns a shorter timeout", func(t *testing.T) {
+			t.Parallel()
+
+			cleanup := make(chan struct{})
+			defer close(cleanup)
+			addr := bootstrapConnections(t, 3, func(nc net.Conn) {
+				<-cleanup
+				_ = nc.Close()
+			})
+
+			idleTimeout := int64(time.Hour)
+			closed := make(chan *event.PoolEvent, 10)
+			d := newdialer(&net.Dialer{})
+			p := newPool(poolConfig{
+				Address:         address.Address(addr.String()),
+				MaxIdleTimeFunc: func() time.Duration { return time.Duration(atomic.LoadInt64(&idleTimeout)) },
+				// Set the pool's maintain interval to 10ms so that it allows the test to run quickly.
+				MaintainInterval: 10 * time.Millisecond,
+				PoolMonitor: &event.PoolMonitor{
+					Event: func(evt *event.PoolEvent) {
+						if evt.Type == event.ConnectionClosed {
+							closed <- evt
+						}
+					},
+				},
+			}, WithDialer(func(Dialer) Dialer { return d }))
+			err := p.ready()
+			noerr(t, err)
+
+			conns := make([]*connection, 3)
+			for i := range conns {
+				conns[i], err = p.checkOut(context.Background())
+				noerr(t, err)
+			}
+			for _, c := range conns {
+				err = p.checkIn(c)
+				noerr(t, err)
+			}
+
+			// Wait for a few maintain intervals and assert that no connections are removed while the
+			// idle timeout function returns a long timeout.
+			time.Sleep(50 * time.Millisecond)
+			assert.Equalf(t, 0, d.lenclosed(), "should have closed 0 connections")
+			assert.Equalf(t, 3, p.availableConnectionCount(), "should be 3 idle connections in pool")
+
+			// Shorten the idle timeout and assert that maintain() closes all of the idle connections
+			// and publishes a ConnectionClosed event with reason "idle" for each of them.
+			atomic.StoreInt64(&idleTimeout, int64(time.Millisecond))
+			assertConnectionsClosed(t, d, 3)
+			assert.Equalf(t, 0, p.availableConnectionCount(), "should be 0 idle connections in pool")
+			assert.Equalf(t, 0, p.totalConnectionCount(), "should be 0 total connection in pool")
+			for i := 0; i < 3; i++ {
+				evt := <-closed
+				assert.Equalf(t, event.ReasonIdle, evt.Reason, "expected ConnectionClosed reason %q", event.ReasonIdle)
+			}
+
 			p.close(context.Background())
 		})
 	})
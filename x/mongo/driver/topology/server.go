@@ -17,6 +17,7 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/internal"
 	"go.mongodb.org/mongo-driver/internal/logger"
 	"go.mongodb.org/mongo-driver/mongo/address"
 	"go.mongodb.org/mongo-driver/mongo/description"
@@ -24,7 +25,6 @@ import (
 	"go.mongodb.org/mongo-driver/x/mongo/driver/operation"
 )
 
-const minHeartbeatInterval = 500 * time.Millisecond
 const wireVersion42 = 8 // Wire version for MongoDB 4.2
 
 // Server state constants.
@@ -176,6 +176,7 @@ func NewServer(addr address.Address, topologyID primitive.ObjectID, opts ...Serv
 		MaxPoolSize:      cfg.maxConns,
 		MaxConnecting:    cfg.maxConnecting,
 		MaxIdleTime:      cfg.poolMaxIdleTime,
+		MaxIdleTimeFunc:  cfg.poolMaxIdleTimeFunc,
 		MaintainInterval: cfg.poolMaintainInterval,
 		PoolMonitor:      cfg.poolMonitor,
 		Logger:           cfg.logger,
@@ -536,7 +537,7 @@ func (s *Server) ProcessError(err error, conn driver.Connection) driver.ProcessE
 func (s *Server) update() {
 	defer s.closewg.Done()
 	heartbeatTicker := time.NewTicker(s.cfg.heartbeatInterval)
-	rateLimiter := time.NewTicker(minHeartbeatInterval)
+	rateLimiter := time.NewTicker(internal.MinHeartbeatInterval)
 	defer heartbeatTicker.Stop()
 	defer rateLimiter.Stop()
 	checkNow := s.checkNow
@@ -1017,6 +1018,9 @@ func (s *Server) publishServerHeartbeatSucceededEvent(connectionID string,
 		ConnectionID:  connectionID,
 		Awaited:       await,
 	}
+	if s != nil && s.rttMonitor != nil {
+		serverHeartbeatSucceeded.AverageRTT = s.rttMonitor.EWMA()
+	}
 
 	if s != nil && s.cfg.serverMonitor != nil && s.cfg.serverMonitor.ServerHeartbeatSucceeded != nil {
 		s.cfg.serverMonitor.ServerHeartbeatSucceeded(serverHeartbeatSucceeded)
@@ -39,6 +39,7 @@ type serverConfig struct {
 	poolMonitor          *event.PoolMonitor
 	logger               *logger.Logger
 	poolMaxIdleTime      time.Duration
+	poolMaxIdleTimeFunc  func() time.Duration
 	poolMaintainInterval time.Duration
 }
 
@@ -145,6 +146,16 @@ func WithConnectionPoolMaxIdleTime(fn func(time.Duration) time.Duration) ServerO
 	}
 }
 
+// WithConnectionPoolMaxIdleTimeFunc configures a function that returns the maximum time that a connection can remain
+// idle in the connection pool before being removed. The function is called each time the pool checks whether an idle
+// connection has expired, so the returned value can change over the lifetime of the pool. If the function returns 0,
+// connections will not be removed because of their age. If set, it takes precedence over WithConnectionPoolMaxIdleTime.
+func WithConnectionPoolMaxIdleTimeFunc(fn func(func() time.Duration) func() time.Duration) ServerOption {
+	return func(cfg *serverConfig) {
+		cfg.poolMaxIdleTimeFunc = fn(cfg.poolMaxIdleTimeFunc)
+	}
+}
+
 // WithConnectionPoolMaintainInterval configures the interval that the background connection pool
 // maintenance goroutine runs.
 func WithConnectionPoolMaintainInterval(fn func(time.Duration) time.Duration) ServerOption {
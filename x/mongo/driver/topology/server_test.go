@@ -747,6 +747,31 @@ func TestServer(t *testing.T) {
 			assert.Equal(t, succeeded.Reply.Addr, s.address, "expected address %v, got %v", s.address, succeeded.Reply.Addr)
 			assert.False(t, succeeded.Awaited, "expected awaited to be false")
 		})
+		t.Run("average RTT", func(t *testing.T) {
+			publishedEvents = nil
+			for i := 0; i < 3; i++ {
+				if err = channelConn.AddResponse(makeHelloReply()); err != nil {
+					t.Fatalf("error adding response: %v", err)
+				}
+				_, err = s.check()
+				_ = channelConn.GetWrittenMessage()
+				assert.Nil(t, err, "check error: %v", err)
+			}
+
+			var succeededCount int
+			for _, evt := range publishedEvents {
+				succeeded, ok := evt.(event.ServerHeartbeatSucceededEvent)
+				if !ok {
+					continue
+				}
+				succeededCount++
+
+				assert.True(t, succeeded.AverageRTT > 0, "expected a positive average RTT, got %v", succeeded.AverageRTT)
+				assert.Equal(t, s.rttMonitor.EWMA(), succeeded.AverageRTT,
+					"expected average RTT %v, got %v", s.rttMonitor.EWMA(), succeeded.AverageRTT)
+			}
+			assert.Equal(t, 3, succeededCount, "expected %v heartbeat succeeded events, got %v", 3, succeededCount)
+		})
 		t.Run("failure", func(t *testing.T) {
 			publishedEvents = nil
 			// do a heartbeat with a non-nil connection
@@ -56,6 +56,10 @@ var ErrTopologyConnected = errors.New("topology is connected or connecting")
 // selection process took longer than allowed by the timeout.
 var ErrServerSelectionTimeout = errors.New("server selection timeout")
 
+// ErrNoSuitableServer is returned from server selection when the topology is configured to try server selection only
+// once and the current topology description does not contain a suitable server.
+var ErrNoSuitableServer = errors.New("no suitable server available")
+
 // MonitorMode represents the way in which a server is monitored.
 type MonitorMode uint8
 
@@ -419,8 +423,8 @@ func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelect
 			suitable, selectErr = t.selectServerFromDescription(t.Description(), selectionState)
 			doneOnce = true
 		} else {
-			// if the first pass didn't select a server, the previous description did not contain a suitable server, so
-			// we subscribe to the topology and attempt to obtain a server from that subscription
+			// if the first pass didn't select a server, the previous description did not contain a suitable server so
+			// we subscribe to the topology and attempt to obtain a server from that subscription.
 			if sub == nil {
 				var err error
 				sub, err = t.Subscribe()
@@ -430,7 +434,11 @@ func (t *Topology) SelectServer(ctx context.Context, ss description.ServerSelect
 				defer t.Unsubscribe(sub)
 			}
 
-			suitable, selectErr = t.selectServerFromSubscription(ctx, sub.Updates, selectionState)
+			if t.cfg.ServerSelectionTryOnce {
+				suitable, selectErr = t.selectServerAfterInitialChecks(ctx, sub.Updates, selectionState)
+			} else {
+				suitable, selectErr = t.selectServerFromSubscription(ctx, sub.Updates, selectionState)
+			}
 		}
 		if selectErr != nil {
 			return nil, selectErr
@@ -556,6 +564,46 @@ func (t *Topology) selectServerFromSubscription(ctx context.Context, subscriptio
 	}
 }
 
+// selectServerAfterInitialChecks is used instead of selectServerFromSubscription when server selection should only be
+// tried once. Servers that have never been checked are not yet known to be unsuitable, so it waits for their initial
+// checks to complete, but it fails with ErrNoSuitableServer as soon as every server has been checked at least once and
+// none of them is suitable.
+func (t *Topology) selectServerAfterInitialChecks(ctx context.Context, subscriptionCh <-chan description.Topology,
+	selectionState serverSelectionState) ([]description.Server, error) {
+
+	current := t.Description()
+	for hasUncheckedServers(current) {
+		select {
+		case <-ctx.Done():
+			return nil, ServerSelectionError{Wrapped: ctx.Err(), Desc: current}
+		case <-selectionState.timeoutChan:
+			return nil, ServerSelectionError{Wrapped: ErrServerSelectionTimeout, Desc: current}
+		case current = <-subscriptionCh:
+		}
+
+		suitable, err := t.selectServerFromDescription(current, selectionState)
+		if err != nil {
+			return nil, err
+		}
+		if len(suitable) > 0 {
+			return suitable, nil
+		}
+	}
+
+	return nil, ServerSelectionError{Wrapped: ErrNoSuitableServer, Desc: current}
+}
+
+// hasUncheckedServers returns true if the given topology description contains a server whose initial check has not
+// completed yet. Such servers have an Unknown kind and no error, unlike servers whose checks have failed.
+func hasUncheckedServers(desc description.Topology) bool {
+	for _, s := range desc.Servers {
+		if s.Kind == description.Unknown && s.LastError == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // selectServerFromDescription process the given topology description and returns a slice of suitable servers.
 func (t *Topology) selectServerFromDescription(desc description.Topology,
 	selectionState serverSelectionState) ([]description.Server, error) {
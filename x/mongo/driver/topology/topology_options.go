@@ -34,6 +34,7 @@ type Config struct {
 	ServerOpts             []ServerOption
 	URI                    string
 	ServerSelectionTimeout time.Duration
+	ServerSelectionTryOnce bool
 	ServerMonitor          *event.ServerMonitor
 	SRVMaxHosts            int
 	SRVServiceName         string
@@ -245,6 +246,12 @@ func NewConfig(co *options.ClientOptions, clock *session.ClusterClock) (*Config,
 			func(time.Duration) time.Duration { return *co.MaxConnIdleTime },
 		))
 	}
+	// ConnectionIdleTimeoutFunc
+	if co.ConnectionIdleTimeoutFunc != nil {
+		serverOpts = append(serverOpts, WithConnectionPoolMaxIdleTimeFunc(
+			func(func() time.Duration) func() time.Duration { return co.ConnectionIdleTimeoutFunc },
+		))
+	}
 	// MaxPoolSize
 	if co.MaxPoolSize != nil {
 		serverOpts = append(
@@ -295,6 +302,10 @@ func NewConfig(co *options.ClientOptions, clock *session.ClusterClock) (*Config,
 	if co.ServerSelectionTimeout != nil {
 		cfgp.ServerSelectionTimeout = *co.ServerSelectionTimeout
 	}
+	// ServerSelectionTryOnce
+	if co.ServerSelectionTryOnce != nil {
+		cfgp.ServerSelectionTryOnce = *co.ServerSelectionTryOnce
+	}
 	// SocketTimeout
 	if co.SocketTimeout != nil {
 		connOpts = append(
@@ -93,6 +93,15 @@ func TestTopologyNewConfig(t *testing.T) {
 		assert.Nil(t, err, "error constructing topology config: %v", err)
 		assert.Equal(t, time.Duration(1), cfg.ServerSelectionTimeout)
 	})
+	t.Run("ServerSelectionTryOnce", func(t *testing.T) {
+		cfg, err := NewConfig(options.Client(), nil)
+		assert.Nil(t, err, "error constructing topology config: %v", err)
+		assert.False(t, cfg.ServerSelectionTryOnce, "expected ServerSelectionTryOnce to be false by default")
+
+		cfg, err = NewConfig(options.Client().SetServerSelectionTryOnce(true), nil)
+		assert.Nil(t, err, "error constructing topology config: %v", err)
+		assert.True(t, cfg.ServerSelectionTryOnce, "expected ServerSelectionTryOnce to be true")
+	})
 	t.Run("default SeedList", func(t *testing.T) {
 		cfg, err := NewConfig(options.Client(), nil)
 		assert.Nil(t, err, "error constructing topology config: %v", err)
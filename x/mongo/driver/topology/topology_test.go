@@ -411,6 +411,75 @@ func TestServerSelection(t *testing.T) {
 		_, err = topo.SelectServer(context.Background(), description.WriteSelector())
 		assert.Equal(t, ErrSubscribeAfterClosed, err, "expected error %v, got %v", ErrSubscribeAfterClosed, err)
 	})
+	t.Run("try once fails immediately if no server is suitable", func(t *testing.T) {
+		topo, err := New(nil)
+		noerr(t, err)
+		topo.cfg.ServerSelectionTryOnce = true
+
+		atomic.StoreInt64(&topo.state, topologyConnected)
+		desc := description.Topology{
+			Servers: []description.Server{
+				{Addr: address.Address("one"), Kind: description.RSSecondary},
+			},
+		}
+		topo.desc.Store(desc)
+
+		start := time.Now()
+		_, err = topo.SelectServer(context.Background(), description.WriteSelector())
+		elapsed := time.Since(start)
+		assert.True(t, errors.Is(err, ErrNoSuitableServer), "expected error %v, got %v", ErrNoSuitableServer, err)
+		var sse ServerSelectionError
+		assert.True(t, errors.As(err, &sse), "expected error to be a ServerSelectionError, got %T", err)
+		assert.Equal(t, desc, sse.Desc, "expected topology description %v, got %v", desc, sse.Desc)
+		assert.True(t, elapsed < time.Second, "expected server selection to fail immediately, took %v", elapsed)
+	})
+	t.Run("try once waits for initial checks", func(t *testing.T) {
+		addr := address.Address("one").Canonicalize()
+		testCases := []struct {
+			name    string
+			checked description.Server
+			wantErr error
+		}{
+			{"check succeeds", description.Server{Addr: addr, Kind: description.Standalone}, nil},
+			{"check fails", description.NewServerFromError(addr, errors.New("check failed"), nil), ErrNoSuitableServer},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				// Mimic a freshly connected topology whose server has not completed its first heartbeat yet.
+				topo, err := New(nil)
+				noerr(t, err)
+				topo.cfg.ServerSelectionTryOnce = true
+				topo.cfg.ServerSelectionTimeout = 10 * time.Second
+				atomic.StoreInt64(&topo.state, topologyConnected)
+
+				topo.fsm.Kind = description.Single
+				topo.fsm.Servers = []description.Server{description.NewDefaultServer(addr)}
+				topo.desc.Store(topo.fsm.Topology)
+				topo.servers[addr] = NewServer(addr, topo.id)
+
+				errCh := make(chan error, 1)
+				go func() {
+					_, err := topo.SelectServer(context.Background(), description.WriteSelector())
+					errCh <- err
+				}()
+
+				// Complete the initial check once server selection is waiting for it.
+				assert.Eventually(t, func() bool {
+					topo.subLock.Lock()
+					defer topo.subLock.Unlock()
+					return len(topo.subscribers) > 0
+				}, time.Second, 10*time.Millisecond, "expected server selection to subscribe to the topology")
+				topo.apply(context.Background(), tc.checked)
+
+				select {
+				case err = <-errCh:
+				case <-time.After(time.Second):
+					t.Fatal("timed out waiting for server selection")
+				}
+				assert.True(t, errors.Is(err, tc.wantErr), "expected error %v, got %v", tc.wantErr, err)
+			})
+		}
+	})
 }
 
 func TestSessionTimeout(t *testing.T) {